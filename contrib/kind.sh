@@ -776,6 +776,7 @@ create_ovn_kube_manifests() {
     --gateway-mode="${OVN_GATEWAY_MODE}" \
     --gateway-options="${OVN_GATEWAY_OPTS}" \
     --enable-ipsec="${ENABLE_IPSEC}" \
+    --ipsec-signer-name="k8s.ovn.org/kind-ipsec-signer" \
     --hybrid-enabled="${OVN_HYBRID_OVERLAY_ENABLE}" \
     --disable-snat-multiple-gws="${OVN_DISABLE_SNAT_MULTIPLE_GWS}" \
     --disable-forwarding="${OVN_DISABLE_FORWARDING}" \
@@ -828,6 +829,7 @@ install_ovn() {
   run_kubectl apply -f k8s.ovn.org_egressfirewalls.yaml
   run_kubectl apply -f k8s.ovn.org_egressips.yaml
   run_kubectl apply -f k8s.ovn.org_egressqoses.yaml
+  run_kubectl apply -f k8s.ovn.org_hybridoverlaypeers.yaml
   run_kubectl apply -f ovn-setup.yaml
   MASTER_NODES=$(kind get nodes --name "${KIND_CLUSTER_NAME}" | sort | head -n "${KIND_NUM_MASTER}")
   # We want OVN HA not Kubernetes HA
@@ -973,8 +975,8 @@ calculate_timeout() {
 }
 
 # install_ipsec will apply the IPsec DaemonSet, create a CA that can be used by the IPsec pods. It will then add it to
-# configmap -n ovn-kubernetes signer-ca. After that, it will monitor all CSRs that are pending and it will sign those
-# with the CA cert. After each iteration, it will check if the ovn-ipsec DaemonSet pods rolled out successfully.
+# configmap -n ovn-kubernetes signer-ca. After that, it will monitor all CSRs that are pending, which ovnkube-node
+# creates once it reads the CA, and it will sign those with the CA cert. After each iteration, it will check if the ovn-ipsec DaemonSet pods rolled out successfully.
 # Make sure to run this at the very end of the setup process.
 install_ipsec() {
   pushd "${MANIFEST_OUTPUT_DIR}"
@@ -110,6 +110,9 @@ while [ "$1" != "" ]; do
   --enable-ipsec)
     ENABLE_IPSEC=$VALUE
     ;;
+  --ipsec-signer-name)
+    IPSEC_SIGNER_NAME=$VALUE
+    ;;
   --ovn-monitor-all)
     OVN_MONITOR_ALL=$VALUE
     ;;
@@ -327,6 +330,13 @@ echo "ovn_gateway_opts: ${ovn_gateway_opts}"
 enable_ipsec=${ENABLE_IPSEC:-false}
 echo "enable_ipsec: ${enable_ipsec}"
 
+ipsec_signer_name=${IPSEC_SIGNER_NAME:-}
+echo "ipsec_signer_name: ${ipsec_signer_name}"
+if ${enable_ipsec} && [[ -z "${ipsec_signer_name}" ]]; then
+  echo "--ipsec-signer-name must be provided with --enable-ipsec"
+  exit 1
+fi
+
 ovn_db_replicas=${OVN_DB_REPLICAS:-3}
 echo "ovn_db_replicas: ${ovn_db_replicas}"
 ovn_db_minAvailable=$(((${ovn_db_replicas} + 1) / 2))
@@ -457,6 +467,8 @@ ovn_image=${ovnkube_image} \
   ovn_v4_join_subnet=${ovn_v4_join_subnet} \
   ovn_v6_join_subnet=${ovn_v6_join_subnet} \
   ovn_multicast_enable=${ovn_multicast_enable} \
+  ovn_ipsec_enable=${enable_ipsec} \
+  ovn_ipsec_signer_name=${ipsec_signer_name} \
   ovn_egress_ip_enable=${ovn_egress_ip_enable} \
   ovn_egress_ip_healthcheck_port=${ovn_egress_ip_healthcheck_port} \
   ovn_multi_network_enable=${ovn_multi_network_enable} \
@@ -501,6 +513,8 @@ ovn_image=${image} \
   ovn_v4_join_subnet=${ovn_v4_join_subnet} \
   ovn_v6_join_subnet=${ovn_v6_join_subnet} \
   ovn_multicast_enable=${ovn_multicast_enable} \
+  ovn_ipsec_enable=${enable_ipsec} \
+  ovn_ipsec_signer_name=${ipsec_signer_name} \
   ovn_egress_ip_enable=${ovn_egress_ip_enable} \
   ovn_egress_ip_healthcheck_port=${ovn_egress_ip_healthcheck_port} \
   ovn_netflow_targets=${ovn_netflow_targets} \
@@ -533,6 +547,8 @@ ovn_image=${ovnkube_image} \
   ovn_v4_join_subnet=${ovn_v4_join_subnet} \
   ovn_v6_join_subnet=${ovn_v6_join_subnet} \
   ovn_multicast_enable=${ovn_multicast_enable} \
+  ovn_ipsec_enable=${enable_ipsec} \
+  ovn_ipsec_signer_name=${ipsec_signer_name} \
   ovn_egress_ip_enable=${ovn_egress_ip_enable} \
   ovn_egress_ip_healthcheck_port=${ovn_egress_ip_healthcheck_port} \
   ovn_egress_firewall_enable=${ovn_egress_firewall_enable} \
@@ -564,6 +580,8 @@ ovn_image=${image} \
   ovn_v4_join_subnet=${ovn_v4_join_subnet} \
   ovn_v6_join_subnet=${ovn_v6_join_subnet} \
   ovn_multicast_enable=${ovn_multicast_enable} \
+  ovn_ipsec_enable=${enable_ipsec} \
+  ovn_ipsec_signer_name=${ipsec_signer_name} \
   ovn_egress_ip_enable=${ovn_egress_ip_enable} \
   ovn_egress_ip_healthcheck_port=${ovn_egress_ip_healthcheck_port} \
   ovn_egress_firewall_enable=${ovn_egress_firewall_enable} \
@@ -582,7 +600,6 @@ ovn_image=${image} \
   ovn_ssl_en=${ovn_ssl_en} \
   ovn_nb_port=${ovn_nb_port} \
   ovn_sb_port=${ovn_sb_port} \
-  enable_ipsec=${enable_ipsec} \
   j2 ../templates/ovnkube-db.yaml.j2 -o ${output_dir}/ovnkube-db.yaml
 
 ovn_image=${image} \
@@ -601,7 +618,6 @@ ovn_image=${image} \
   ovn_sb_port=${ovn_sb_port} \
   ovn_nb_raft_port=${ovn_nb_raft_port} \
   ovn_sb_raft_port=${ovn_sb_raft_port} \
-  enable_ipsec=${enable_ipsec} \
   j2 ../templates/ovnkube-db-raft.yaml.j2 -o ${output_dir}/ovnkube-db-raft.yaml
 
 ovn_image=${image} \
@@ -638,5 +654,6 @@ cp ../templates/ovnkube-monitor.yaml.j2 ${output_dir}/ovnkube-monitor.yaml
 cp ../templates/k8s.ovn.org_egressfirewalls.yaml.j2 ${output_dir}/k8s.ovn.org_egressfirewalls.yaml
 cp ../templates/k8s.ovn.org_egressips.yaml.j2 ${output_dir}/k8s.ovn.org_egressips.yaml
 cp ../templates/k8s.ovn.org_egressqoses.yaml.j2 ${output_dir}/k8s.ovn.org_egressqoses.yaml
+cp ../templates/k8s.ovn.org_hybridoverlaypeers.yaml.j2 ${output_dir}/k8s.ovn.org_hybridoverlaypeers.yaml
 
 exit 0
@@ -213,6 +213,10 @@ ovn_enable_lflow_cache=${OVN_ENABLE_LFLOW_CACHE:-}
 ovn_lflow_cache_limit=${OVN_LFLOW_CACHE_LIMIT:-}
 ovn_lflow_cache_limit_kb=${OVN_LFLOW_CACHE_LIMIT_KB:-}
 ovn_multicast_enable=${OVN_MULTICAST_ENABLE:-}
+#OVN_IPSEC_ENABLE - enable IPsec encryption of the traffic between nodes
+ovn_ipsec_enable=${OVN_IPSEC_ENABLE:-false}
+#OVN_IPSEC_SIGNER_NAME - signer of the IPsec certificate signing requests of the nodes
+ovn_ipsec_signer_name=${OVN_IPSEC_SIGNER_NAME:-}
 #OVN_EGRESSIP_ENABLE - enable egress IP for ovn-kubernetes
 ovn_egressip_enable=${OVN_EGRESSIP_ENABLE:-false}
 #OVN_EGRESSIP_HEALTHCHECK_PORT - egress IP node check to use grpc on this port
@@ -740,10 +744,6 @@ nb-ovsdb() {
     ovn-nbctl set-ssl ${ovn_nb_pk} ${ovn_nb_cert} ${ovn_ca_cert}
     echo "=============== nb-ovsdb ========== reconfigured for SSL"
   }
- [[ "true" == "${ENABLE_IPSEC}" ]] && {
-    ovn-nbctl set nb_global . ipsec=true
-    echo "=============== nb-ovsdb ========== reconfigured for ipsec"
-  }
   ovn-nbctl --inactivity-probe=0 set-connection p${transport}:${ovn_nb_port}:$(bracketify ${ovn_db_host})
   if memory_trim_on_compaction_supported "nbdb"
   then
@@ -970,6 +970,16 @@ ovn-master() {
       multicast_enabled_flag="--enable-multicast"
   fi
 
+  ipsec_enabled_flag=
+  if [[ ${ovn_ipsec_enable} == "true" ]]; then
+      ipsec_enabled_flag="--enable-ipsec"
+      # the compact mode master also requests the IPsec certificate of its node
+      if [[ ${ovnkube_compact_mode_enable} == "true" ]]; then
+          ipsec_enabled_flag="${ipsec_enabled_flag} --ovnkube-node-ipsec-signer-name=${ovn_ipsec_signer_name}"
+      fi
+  fi
+  echo "ipsec_enabled_flag: ${ipsec_enabled_flag}"
+
   egressip_enabled_flag=
   if [[ ${ovn_egressip_enable} == "true" ]]; then
       egressip_enabled_flag="--enable-egress-ip"
@@ -1052,6 +1062,7 @@ ovn-master() {
     ${ovn_master_ssl_opts} \
     ${ovnkube_metrics_tls_opts} \
     ${multicast_enabled_flag} \
+    ${ipsec_enabled_flag} \
     ${ovn_acl_logging_rate_limit_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
@@ -1157,6 +1168,12 @@ ovn-network-controller-manager() {
   fi
   echo "multicast_enabled_flag=${multicast_enabled_flag}"
 
+  ipsec_enabled_flag=
+  if [[ ${ovn_ipsec_enable} == "true" ]]; then
+      ipsec_enabled_flag="--enable-ipsec"
+  fi
+  echo "ipsec_enabled_flag: ${ipsec_enabled_flag}"
+
   egressip_enabled_flag=
   if [[ ${ovn_egressip_enable} == "true" ]]; then
       egressip_enabled_flag="--enable-egress-ip"
@@ -1225,6 +1242,7 @@ ovn-network-controller-manager() {
     ${ovn_master_ssl_opts} \
     ${ovnkube_metrics_tls_opts} \
     ${multicast_enabled_flag} \
+    ${ipsec_enabled_flag} \
     ${ovn_acl_logging_rate_limit_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
@@ -1417,6 +1435,12 @@ ovn-node() {
       multicast_enabled_flag="--enable-multicast"
   fi
 
+  ipsec_enabled_flag=
+  if [[ ${ovn_ipsec_enable} == "true" ]]; then
+      ipsec_enabled_flag="--enable-ipsec --ovnkube-node-ipsec-signer-name=${ovn_ipsec_signer_name}"
+  fi
+  echo "ipsec_enabled_flag: ${ipsec_enabled_flag}"
+
   egressip_enabled_flag=
   if [[ ${ovn_egressip_enable} == "true" ]]; then
       egressip_enabled_flag="--enable-egress-ip"
@@ -1588,6 +1612,7 @@ ovn-node() {
     ${lflow_cache_limit} \
     ${lflow_cache_limit_kb} \
     ${multicast_enabled_flag} \
+    ${ipsec_enabled_flag} \
     ${egressip_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
     ${disable_ovn_iface_id_ver_flag} \
@@ -1,11 +1,11 @@
 package main
 
 import (
+	"io"
 	"os"
 
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
-	"github.com/containernetworking/cni/pkg/version"
 	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
 	"github.com/urfave/cli/v2"
@@ -19,11 +19,18 @@ func main() {
 
 	p := cni.NewCNIPlugin("")
 	c.Action = func(ctx *cli.Context) error {
+		// skel predates the CNI 1.1 STATUS and GC verbs, dispatch them here
+		switch os.Getenv("CNI_COMMAND") {
+		case "STATUS":
+			return cmdWithStdin(p.CmdStatus)
+		case "GC":
+			return cmdWithStdin(p.CmdGC)
+		}
 		skel.PluginMain(
 			p.CmdAdd,
 			p.CmdCheck,
 			p.CmdDel,
-			version.All,
+			cni.SupportedVersions,
 			bv.BuildString("ovn-k8s-cni-overlay"))
 		return nil
 	}
@@ -37,3 +44,12 @@ func main() {
 		e.Print()
 	}
 }
+
+// cmdWithStdin calls cmd with the network configuration read from stdin
+func cmdWithStdin(cmd func(*skel.CmdArgs) error) error {
+	stdinData, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return types.NewError(types.ErrIOFailure, "error reading from stdin", err.Error())
+	}
+	return cmd(&skel.CmdArgs{StdinData: stdinData})
+}
@@ -55,6 +55,7 @@ func getFlagsByCategory() map[string][]cli.Flag {
 	m["K8s-related Options"] = config.K8sFlags
 	m["OVN Northbound DB Options"] = config.OvnNBFlags
 	m["OVN Southbound DB Options"] = config.OvnSBFlags
+	m["OVN DB Backup Options"] = config.OvnDBBackupFlags
 	return m
 }
 
@@ -89,6 +90,34 @@ func main() {
 	c.Action = func(c *cli.Context) error {
 		return runOvnKubeDBChecker(c)
 	}
+	c.Commands = []*cli.Command{
+		{
+			Name:  "restore",
+			Usage: "restore an OVN database from a backup",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "db",
+					Usage:    "the database to restore, either nb or sb",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "backup-file",
+					Usage:    "the backup to restore the database from",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name: "create-cluster-local-address",
+					Usage: "when set, create a new RAFT cluster from the backup with this " +
+						"local address (e.g. ssl:10.0.0.1:6643) for when the cluster lost " +
+						"quorum; the database server must be stopped. Otherwise the backup " +
+						"is restored through the running database server",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return runOvnKubeDBRestore(c)
+			},
+		},
+	}
 
 	ctx := context.Background()
 
@@ -191,3 +220,25 @@ func runOvnKubeDBChecker(ctx *cli.Context) error {
 	close(stopChan)
 	return nil
 }
+
+func runOvnKubeDBRestore(ctx *cli.Context) error {
+	var db string
+	switch ctx.String("db") {
+	case "nb":
+		db = util.OvnNbdbLocation
+	case "sb":
+		db = util.OvnSbdbLocation
+	default:
+		return fmt.Errorf("invalid db %q, must be either nb or sb", ctx.String("db"))
+	}
+
+	if err := util.SetExec(kexec.New()); err != nil {
+		return fmt.Errorf("failed to initialize exec helper: %v", err)
+	}
+
+	backupFile := ctx.String("backup-file")
+	if localAddress := ctx.String("create-cluster-local-address"); localAddress != "" {
+		return ovndbmanager.CreateClusterFromBackup(db, backupFile, localAddress)
+	}
+	return ovndbmanager.RestoreDB(db, backupFile)
+}
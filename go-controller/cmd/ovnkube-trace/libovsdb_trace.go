@@ -0,0 +1,572 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// the verdicts of a libovsdb trace
+	traceVerdictDelivered = "delivered"
+	traceVerdictExternal  = "external"
+	traceVerdictDropped   = "dropped"
+	traceVerdictRejected  = "rejected"
+	traceVerdictUnknown   = "unknown"
+
+	// ovnStageHintKey is the external ID northd sets on a logical flow to the
+	// first 8 hex digits of the UUID of the NB row the flow was built from
+	ovnStageHintKey = "stage-hint"
+)
+
+var (
+	// ovnTraceDatapathRegex matches the pipeline headers of ovn-trace output, e.g.
+	// `ingress(dp="ovn-worker", inport="default_pod1")`
+	ovnTraceDatapathRegex = regexp.MustCompile(`^\s*(?:ingress|egress)\(dp="([^"]+)"`)
+	// ovnTraceStageRegex matches the logical flows of ovn-trace output, e.g.
+	// " 4. ls_out_acl (northd.c:6453): reg0[7] == 1 && (outport == @a123 && ip4), priority 2001, uuid 43c9a1b2"
+	ovnTraceStageRegex = regexp.MustCompile(`^\s*\d+\. (\w+)(?: \([^)]*\))?: (.*), priority (\d+), uuid ([0-9a-f]+)$`)
+	// ovnTraceOutputRegex matches the ports ovn-trace outputs the packet to, e.g.
+	// `/* output to "breth0_ovn-worker", type "localnet" */`
+	ovnTraceOutputRegex = regexp.MustCompile(`output to "([^"]+)", type "([^"]*)"`)
+
+	// nbStageHintTables are the NB tables northd hints the logical flows of
+	nbStageHintTables = []string{
+		nbdb.ACLTable,
+		nbdb.LoadBalancerTable,
+		nbdb.LogicalRouterPolicyTable,
+		nbdb.LogicalRouterStaticRouteTable,
+		nbdb.NATTable,
+		nbdb.LogicalSwitchPortTable,
+		nbdb.LogicalRouterPortTable,
+	}
+)
+
+// TraceResult is the machine readable result of an ovn-trace run, annotated
+// with the contents of the OVN databases
+type TraceResult struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	// Microflow is the packet ovn-trace was run with
+	Microflow string     `json:"microflow"`
+	Hops      []TraceHop `json:"hops"`
+	// Verdict is one of delivered, external, dropped, rejected or unknown
+	Verdict    string `json:"verdict"`
+	OutputPort string `json:"outputPort,omitempty"`
+	// Explanation says why the traffic was allowed or denied by ACLs
+	Explanation string `json:"explanation,omitempty"`
+	// Egress is where the traffic leaves the cluster, if it is SNATed by a
+	// gateway router or goes to the host network of a node
+	Egress *TraceEgress `json:"egress,omitempty"`
+}
+
+// TraceEgress is the node traffic leaves the cluster from and the IP it is
+// SNATed to there
+type TraceEgress struct {
+	Node string `json:"node"`
+	// SNATIP is empty if the traffic leaves through the host network of the
+	// node without SNAT
+	SNATIP string `json:"snatIP,omitempty"`
+	// EgressIP is the EgressIP the SNAT is for, if any
+	EgressIP string `json:"egressIP,omitempty"`
+}
+
+// TraceHop is a logical flow ovn-trace reported the packet went through, and
+// the NB object the flow was built from
+type TraceHop struct {
+	Datapath string `json:"datapath"`
+	Stage    string `json:"stage"`
+	// UUID is the prefix of the UUID of the logical flow ovn-trace reports
+	UUID     string `json:"uuid"`
+	Match    string `json:"match"`
+	Priority int    `json:"priority"`
+	// Action is the first action of the logical flow
+	Action string `json:"action,omitempty"`
+	// Table and NBUUID are the NB row the logical flow was built from, if
+	// northd hinted it
+	Table  string      `json:"table,omitempty"`
+	NBUUID string      `json:"nbUUID,omitempty"`
+	Owner  *TraceOwner `json:"owner,omitempty"`
+	// Policy explains the verdict of an ACL hop in terms of the object it
+	// was created for
+	Policy *PolicyVerdict `json:"policy,omitempty"`
+}
+
+// TraceOwner is the Kubernetes object that owns an NB object, from its
+// external IDs
+type TraceOwner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// nbRow is an NB row northd can hint a logical flow with
+type nbRow interface {
+	GetUUID() string
+	GetExternalIDs() map[string]string
+}
+
+// parseOvnTrace parses the hops, the verdict and the output port of the
+// detailed output of ovn-trace
+func parseOvnTrace(output string) *TraceResult {
+	result := &TraceResult{Hops: []TraceHop{}, Verdict: traceVerdictUnknown}
+	datapath := ""
+	// the hop whose first action is the next line, if any
+	last := -1
+	for _, line := range strings.Split(output, "\n") {
+		if subMatches := ovnTraceDatapathRegex.FindStringSubmatch(line); subMatches != nil {
+			datapath = subMatches[1]
+			last = -1
+			continue
+		}
+		if subMatches := ovnTraceStageRegex.FindStringSubmatch(line); subMatches != nil {
+			priority, _ := strconv.Atoi(subMatches[3])
+			result.Hops = append(result.Hops, TraceHop{
+				Datapath: datapath,
+				Stage:    subMatches[1],
+				Match:    subMatches[2],
+				Priority: priority,
+				UUID:     subMatches[4],
+			})
+			last = len(result.Hops) - 1
+			continue
+		}
+		action := strings.TrimSpace(line)
+		if action == "" {
+			continue
+		}
+		if last >= 0 {
+			result.Hops[last].Action = action
+			last = -1
+		}
+		// the last port the packet is output to or dropped at decides, but
+		// a rejected packet is followed by the reply ovn-trace generates
+		switch {
+		case strings.HasPrefix(action, "reject"):
+			result.Verdict, result.OutputPort = traceVerdictRejected, ""
+			return result
+		case action == "drop;" || strings.Contains(action, "implicit drop"):
+			result.Verdict, result.OutputPort = traceVerdictDropped, ""
+		default:
+			subMatches := ovnTraceOutputRegex.FindStringSubmatch(action)
+			if subMatches == nil {
+				continue
+			}
+			result.Verdict, result.OutputPort = traceVerdictDelivered, subMatches[1]
+			if subMatches[2] == "localnet" || strings.HasPrefix(subMatches[1], types.K8sPrefix) {
+				result.Verdict = traceVerdictExternal
+			}
+		}
+	}
+	return result
+}
+
+// monitorLogicalFlowHints adds the stage hints of the logical flows to the
+// tables the SB client monitors, the only column of Logical_Flow a trace needs
+func monitorLogicalFlowHints(sbClient libovsdbclient.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+	lflow := sbdb.LogicalFlow{}
+	_, err := sbClient.Monitor(ctx, sbClient.NewMonitor(libovsdbclient.WithTable(&lflow, &lflow.ExternalIDs)))
+	return err
+}
+
+// logicalFlowStageHint returns the stage hint of the logical flow whose UUID
+// starts with prefix, or "" if there is none or the prefix is ambiguous
+func logicalFlowStageHint(sbClient libovsdbclient.Client, prefix string) string {
+	hint := ""
+	for uuid, row := range sbClient.Cache().Table(sbdb.LogicalFlowTable).RowsShallow() {
+		if !strings.HasPrefix(uuid, prefix) {
+			continue
+		}
+		lflowHint := row.(*sbdb.LogicalFlow).ExternalIDs[ovnStageHintKey]
+		if hint != "" && lflowHint != hint {
+			return ""
+		}
+		hint = lflowHint
+	}
+	return hint
+}
+
+// nbRowByUUIDPrefix returns the table and the row of the NB object a stage
+// hint refers to, or nil if it can't be found
+func nbRowByUUIDPrefix(nbClient libovsdbclient.Client, prefix string) (string, model.Model) {
+	for _, table := range nbStageHintTables {
+		for uuid, row := range nbClient.Cache().Table(table).RowsShallow() {
+			if strings.HasPrefix(uuid, prefix) {
+				return table, row
+			}
+		}
+	}
+	return "", nil
+}
+
+// annotateTrace resolves the logical flows of a trace to the NB rows they
+// were built from and these to the Kubernetes objects owning them. It fills in
+// the egress of the trace from the SNAT of a gateway router, and the ACLs
+// that explain its verdict.
+func annotateTrace(nbClient, sbClient libovsdbclient.Client, result *TraceResult) {
+	for i := range result.Hops {
+		hop := &result.Hops[i]
+		hint := logicalFlowStageHint(sbClient, hop.UUID)
+		if hint == "" {
+			continue
+		}
+		table, row := nbRowByUUIDPrefix(nbClient, hint)
+		if row == nil {
+			klog.V(4).Infof("No NB row found for the stage hint %s of logical flow %s", hint, hop.UUID)
+			continue
+		}
+		hop.Table = table
+		hop.NBUUID = row.(nbRow).GetUUID()
+		hop.Owner = nbObjectOwner(table, row.(nbRow).GetExternalIDs())
+		switch row := row.(type) {
+		case *nbdb.ACL:
+			hop.Policy = explainACL(row)
+		case *nbdb.LogicalSwitchPort:
+			if hop.Owner != nil && hop.Owner.Kind == "Pod" {
+				// pod logical switch ports are named <namespace>_<pod>
+				hop.Owner.Name = strings.Replace(row.Name, "_", "/", 1)
+			}
+		case *nbdb.NAT:
+			if row.Type != nbdb.NATTypeSNAT || !strings.HasPrefix(hop.Datapath, types.GWRouterPrefix) {
+				continue
+			}
+			result.Egress = &TraceEgress{
+				Node:   strings.TrimPrefix(hop.Datapath, types.GWRouterPrefix),
+				SNATIP: row.ExternalIP,
+			}
+			if hop.Owner != nil && hop.Owner.Kind == "EgressIP" {
+				result.Egress.EgressIP = hop.Owner.Name
+			}
+		}
+	}
+	if result.Egress == nil && result.Verdict == traceVerdictExternal && strings.HasPrefix(result.OutputPort, types.K8sPrefix) {
+		// routed to the host network of the node through its management
+		// port, without SNAT
+		result.Egress = &TraceEgress{Node: strings.TrimPrefix(result.OutputPort, types.K8sPrefix)}
+	}
+	if result.Verdict != traceVerdictUnknown {
+		result.Explanation = explainHops(result.Hops)
+	}
+}
+
+// nbObjectOwner returns the Kubernetes object that owns an NB object, from
+// its external IDs, or nil if it can't be found
+func nbObjectOwner(table string, externalIDs map[string]string) *TraceOwner {
+	if ownerType := externalIDs[libovsdbops.OwnerTypeKey.String()]; ownerType != "" {
+		name := externalIDs[libovsdbops.ObjectNameKey.String()]
+		switch ownerType {
+		case string(libovsdbops.NetworkPolicyOwnerType):
+			// <policyNamespace>:<policyName>
+			name = strings.Replace(name, ":", "/", 1)
+		case string(libovsdbops.EgressFirewallOwnerType):
+			name += "/default"
+		}
+		return &TraceOwner{Kind: ownerType, Name: name}
+	}
+	if kind := externalIDs[types.LoadBalancerKindExternalID]; kind != "" {
+		return &TraceOwner{Kind: kind, Name: externalIDs[types.LoadBalancerOwnerExternalID]}
+	}
+	switch table {
+	case nbdb.LogicalSwitchPortTable:
+		if externalIDs["pod"] == "true" {
+			return &TraceOwner{Kind: "Pod", Name: externalIDs["namespace"]}
+		}
+	case nbdb.LogicalRouterPolicyTable, nbdb.NATTable:
+		if name := externalIDs["name"]; name != "" {
+			return &TraceOwner{Kind: "EgressIP", Name: name}
+		}
+	}
+	return nil
+}
+
+// libovsdbTraceOptions holds the command line options of a libovsdb trace
+type libovsdbTraceOptions struct {
+	nbAddress      string
+	sbAddress      string
+	privKey        string
+	cert           string
+	caCert         string
+	certCommonName string
+	srcNamespace   string
+	srcPodName     string
+	dstNamespace   string
+	dstPodName     string
+	dstSvcName     string
+	dstIP          net.IP
+	protocol       string
+	dstPort        string
+	// nadName is the network to trace on, types.DefaultNetworkName for the
+	// default network
+	nadName string
+}
+
+// dbAuthConfig returns the configuration to connect to the database at address
+func (opts *libovsdbTraceOptions) dbAuthConfig(address string) config.OvnAuthConfig {
+	scheme := config.OvnDBSchemeTCP
+	switch {
+	case strings.HasPrefix(address, "ssl:"):
+		scheme = config.OvnDBSchemeSSL
+	case strings.HasPrefix(address, "unix:"):
+		scheme = config.OvnDBSchemeUnix
+	}
+	return config.OvnAuthConfig{
+		Address:        address,
+		PrivKey:        opts.privKey,
+		Cert:           opts.cert,
+		CACert:         opts.caCert,
+		CertCommonName: opts.certCommonName,
+		Scheme:         scheme,
+	}
+}
+
+// ovnTraceArgs returns the arguments to run ovn-trace with against the SB
+// database at sbAddress
+func (opts *libovsdbTraceOptions) ovnTraceArgs(sbAddress, datapath, microflow string, isService bool) []string {
+	args := []string{"--no-leader-only", "--db", sbAddress}
+	if strings.HasPrefix(sbAddress, "ssl:") {
+		args = append(args, "-p", opts.privKey, "-c", opts.cert, "-C", opts.caCert)
+	}
+	if isService {
+		args = append(args, "--ct=new")
+	}
+	return append(args, "--detailed", datapath, microflow)
+}
+
+// traceSource is where a trace starts from: the logical port of the source
+// pod on the network and its addresses
+type traceSource struct {
+	datapath string
+	inport   string
+	ethSrc   string
+	// ethDst is the MAC of the first hop router, empty on networks without
+	// a router
+	ethDst string
+	ipSrc  net.IP
+}
+
+// runLibovsdbTrace runs ovn-trace on this host from the source pod to the
+// destination against the SB database, annotates its result with the NB
+// objects and the Kubernetes objects the logical flows were built from, read
+// with libovsdb, and prints it as JSON. Unlike the other traces, it doesn't
+// exec into any pod when the database addresses are given.
+func runLibovsdbTrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, opts *libovsdbTraceOptions) error {
+	ovnTrace, err := exec.LookPath("ovn-trace")
+	if err != nil {
+		return fmt.Errorf("ovn-trace must be installed on this host to trace with -libovsdb: %v", err)
+	}
+	nbAddress, sbAddress := opts.nbAddress, opts.sbAddress
+	if nbAddress == "" || sbAddress == "" {
+		nbURI, sbURI, _, err := getDatabaseURIs(coreclient, restconfig, ovnNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to get database URIs, use -nb-address and -sb-address "+
+				"if pods can't be exec'ed into: %v", err)
+		}
+		if nbAddress == "" {
+			nbAddress = nbURI
+		}
+		if sbAddress == "" {
+			sbAddress = sbURI
+		}
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	nbClient, err := libovsdb.NewNBClientWithConfig(opts.dbAuthConfig(nbAddress), prometheus.NewRegistry(), stopCh)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the NB database at %s: %v", nbAddress, err)
+	}
+	defer nbClient.Close()
+	sbClient, err := libovsdb.NewSBClientWithConfig(opts.dbAuthConfig(sbAddress), prometheus.NewRegistry(), stopCh)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the SB database at %s: %v", sbAddress, err)
+	}
+	defer sbClient.Close()
+	if err := monitorLogicalFlowHints(sbClient); err != nil {
+		return fmt.Errorf("failed to monitor the logical flows of the SB database at %s: %v", sbAddress, err)
+	}
+
+	srcPod, err := coreclient.Pods(opts.srcNamespace).Get(context.TODO(), opts.srcPodName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get source pod %s/%s: %v", opts.srcNamespace, opts.srcPodName, err)
+	}
+	src, err := podTraceSource(coreclient, nbClient, srcPod, opts.nadName)
+	if err != nil {
+		return err
+	}
+	isIPv6 := utilnet.IsIPv6(src.ipSrc)
+
+	var dstIP net.IP
+	var destination string
+	switch {
+	case opts.dstIP != nil:
+		dstIP = opts.dstIP
+		destination = opts.dstIP.String()
+	case opts.dstSvcName != "":
+		svc, err := coreclient.Services(opts.dstNamespace).Get(context.TODO(), opts.dstSvcName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get destination service %s/%s: %v", opts.dstNamespace, opts.dstSvcName, err)
+		}
+		dstIP = ipOfFamily(svc.Spec.ClusterIPs, isIPv6)
+		destination = fmt.Sprintf("service %s/%s", svc.Namespace, svc.Name)
+	default:
+		dstPod, err := coreclient.Pods(opts.dstNamespace).Get(context.TODO(), opts.dstPodName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get destination pod %s/%s: %v", opts.dstNamespace, opts.dstPodName, err)
+		}
+		dstIPs, dstMAC, err := podNetworkAddresses(coreclient, dstPod, opts.nadName)
+		if err != nil {
+			return err
+		}
+		dstIP = ipOfFamily(dstIPs, isIPv6)
+		if src.ethDst == "" {
+			// no router on the network, the destination is on the same switch
+			src.ethDst = dstMAC
+		}
+		destination = fmt.Sprintf("pod %s/%s", dstPod.Namespace, dstPod.Name)
+	}
+	if dstIP == nil || utilnet.IsIPv6(dstIP) != isIPv6 {
+		return fmt.Errorf("no destination IP of the same family as the source IP %s", src.ipSrc)
+	}
+
+	l3ver := "ip4"
+	if isIPv6 {
+		l3ver = "ip6"
+	}
+	microflow := fmt.Sprintf(`inport=="%s" && eth.src==%s && eth.dst==%s && %s.src==%s && %s.dst==%s && ip.ttl==64 && %s`,
+		src.inport, src.ethSrc, src.ethDst, l3ver, src.ipSrc, l3ver, dstIP, getOvnTraceL4Match(opts.protocol, l3ver, opts.dstPort))
+	args := opts.ovnTraceArgs(sbAddress, src.datapath, microflow, opts.dstSvcName != "")
+	klog.V(4).Infof("ovn-trace command is %s %s", ovnTrace, strings.Join(args, " "))
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(ovnTrace, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run ovn-trace: %v: %s", err, stderr.String())
+	}
+
+	result := parseOvnTrace(stdout.String())
+	annotateTrace(nbClient, sbClient, result)
+	result.Source = fmt.Sprintf("pod %s/%s", srcPod.Namespace, srcPod.Name)
+	result.Destination = destination
+	result.Microflow = microflow
+	if opts.nadName != types.DefaultNetworkName {
+		result.Source += " on network " + opts.nadName
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// podTraceSource returns the logical port and the addresses of the pod on
+// the network, and the MAC of its first hop router
+func podTraceSource(coreclient *corev1client.CoreV1Client, nbClient libovsdbclient.Client, pod *kapi.Pod, nadName string) (*traceSource, error) {
+	src := &traceSource{inport: util.GetLogicalPortName(pod.Namespace, pod.Name)}
+	if nadName != types.DefaultNetworkName {
+		src.inport = util.GetSecondaryNetworkLogicalPortName(pod.Namespace, pod.Name, nadName)
+	} else if pod.Spec.HostNetwork {
+		src.inport = types.K8sPrefix + pod.Spec.NodeName
+	}
+	ips, mac, err := podNetworkAddresses(coreclient, pod, nadName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) > 0 {
+		src.ipSrc = utilnet.ParseIPSloppy(ips[0])
+	}
+	if src.ipSrc == nil {
+		return nil, fmt.Errorf("pod %s/%s has no IP", pod.Namespace, pod.Name)
+	}
+	src.ethSrc = mac
+
+	lsp, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: src.inport})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logical switch port %s: %v", src.inport, err)
+	}
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(nbClient, func(ls *nbdb.LogicalSwitch) bool {
+		for _, port := range ls.Ports {
+			if port == lsp.UUID {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil || len(switches) == 0 {
+		return nil, fmt.Errorf("failed to find the logical switch of port %s: %v", src.inport, err)
+	}
+	src.datapath = switches[0].Name
+	rtos, err := libovsdbops.GetLogicalRouterPort(nbClient, &nbdb.LogicalRouterPort{Name: types.RouterToSwitchPrefix + src.datapath})
+	if err != nil && err != libovsdbclient.ErrNotFound {
+		return nil, fmt.Errorf("failed to get router port %s%s: %v", types.RouterToSwitchPrefix, src.datapath, err)
+	}
+	if rtos != nil {
+		src.ethDst = rtos.MAC
+	}
+	return src, nil
+}
+
+// podNetworkAddresses returns the IPs and the MAC address of the pod on the
+// network, from its pod-networks annotation for secondary networks
+func podNetworkAddresses(coreclient *corev1client.CoreV1Client, pod *kapi.Pod, nadName string) ([]string, string, error) {
+	ips := []string{}
+	if nadName == types.DefaultNetworkName {
+		for _, podIP := range pod.Status.PodIPs {
+			ips = append(ips, podIP.IP)
+		}
+		mac, err := getPodMAC(coreclient, pod)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get the MAC address of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+		return ips, mac, nil
+	}
+	if pod.Spec.HostNetwork {
+		return nil, "", fmt.Errorf("pod %s/%s is host networked, it has no interface on network %s", pod.Namespace, pod.Name, nadName)
+	}
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get the addresses of pod %s/%s on network %s: %v", pod.Namespace, pod.Name, nadName, err)
+	}
+	for _, ipNet := range podAnnotation.IPs {
+		ips = append(ips, ipNet.IP.String())
+	}
+	return ips, podAnnotation.MAC.String(), nil
+}
+
+// ipOfFamily returns the first of the IPs of the given family
+func ipOfFamily(ips []string, isIPv6 bool) net.IP {
+	for _, ipStr := range ips {
+		ip := utilnet.ParseIPSloppy(ipStr)
+		if ip != nil && utilnet.IsIPv6(ip) == isIPv6 {
+			return ip
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+const (
+	ovnTraceToService = `# tcp,reg14=0x3,vlan_tci=0x0000,dl_src=0a:58:0a:80:01:03,dl_dst=0a:58:0a:80:01:01,nw_src=10.128.1.3,nw_dst=10.96.0.10
+ingress(dp="node1", inport="ns1_pod1")
+--------------------------------------
+ 0. ls_in_port_sec_l2 (northd.c:5607): inport == "ns1_pod1", priority 50, uuid 11111111
+    next;
+12. ls_in_lb (northd.c:6912): ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80, priority 120, uuid 22222222
+    reg0[1] = 0;
+    ct_lb_mark(backends=10.128.2.3:8080);
+
+ct_lb_mark
+----------
+ 1. ls_in_l2_lkup (northd.c:8730): eth.dst == 0a:58:0a:80:01:01, priority 50, uuid 33333333
+    outport = "stor-node1";
+    output;
+    /* output to "stor-node1", type "patch" */
+
+    egress(dp="node2", inport="stor-node2", outport="ns2_pod3")
+    -----------------------------------------------------------
+     9. ls_out_port_sec_l2 (northd.c:5704): outport == "ns2_pod3", priority 50, uuid 44444444
+        output;
+        /* output to "ns2_pod3", type "" */
+`
+
+	ovnTraceDenied = `ingress(dp="node1", inport="ns1_pod1")
+--------------------------------------
+ 0. ls_in_port_sec_l2 (northd.c:5607): inport == "ns1_pod1", priority 50, uuid 11111111
+    next;
+egress(dp="node1", inport="ns1_pod1", outport="ns1_pod2")
+---------------------------------------------------------
+ 4. ls_out_acl (northd.c:6453): outport == @pg_deny && ip, priority 2000, uuid 55555555
+    drop;
+`
+
+	ovnTraceToHost = `ingress(dp="ovn_cluster_router", inport="rtos-node1")
+-----------------------------------------------------
+13. lr_in_ip_routing (northd.c:10011): ip4.src == 10.128.1.0/24, priority 55, uuid 77777777
+    ip.ttl--;
+    /* output to "k8s-node1", type "" */
+`
+
+	ovnTraceToEgressIP = `ingress(dp="GR_node2", inport="rtoj-GR_node2")
+-----------------------------------------------
+ 3. lr_out_snat (northd.c:13151): ip && ip4.src == 10.128.1.3, priority 161, uuid 66666666
+    ct_snat(172.18.0.100);
+    /* output to "breth0_node2", type "localnet" */
+`
+)
+
+func TestParseOvnTrace(t *testing.T) {
+	tests := []struct {
+		desc        string
+		output      string
+		wantHops    int
+		wantVerdict string
+		wantOutport string
+	}{
+		{
+			desc:        "pod to service",
+			output:      ovnTraceToService,
+			wantHops:    4,
+			wantVerdict: traceVerdictDelivered,
+			wantOutport: "ns2_pod3",
+		},
+		{
+			desc:        "pod to pod denied by an ACL",
+			output:      ovnTraceDenied,
+			wantHops:    2,
+			wantVerdict: traceVerdictDropped,
+		},
+		{
+			desc:        "pod to IP out of the cluster",
+			output:      ovnTraceToEgressIP,
+			wantHops:    1,
+			wantVerdict: traceVerdictExternal,
+			wantOutport: "breth0_node2",
+		},
+		{
+			desc:        "no output",
+			output:      "",
+			wantVerdict: traceVerdictUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			result := parseOvnTrace(tt.output)
+			if len(result.Hops) != tt.wantHops || result.Verdict != tt.wantVerdict || result.OutputPort != tt.wantOutport {
+				t.Fatalf("got %d hops with verdict %q to %q, want %d hops with verdict %q to %q: %+v",
+					len(result.Hops), result.Verdict, result.OutputPort, tt.wantHops, tt.wantVerdict, tt.wantOutport, result.Hops)
+			}
+		})
+	}
+
+	result := parseOvnTrace(ovnTraceToService)
+	want := TraceHop{
+		Datapath: "node1",
+		Stage:    "ls_in_lb",
+		UUID:     "22222222",
+		Match:    "ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80",
+		Priority: 120,
+		Action:   "reg0[1] = 0;",
+	}
+	if result.Hops[1] != want {
+		t.Errorf("got hop %+v, want %+v", result.Hops[1], want)
+	}
+	if result.Hops[3].Datapath != "node2" {
+		t.Errorf("got hop %+v, want it on datapath node2", result.Hops[3])
+	}
+}
+
+func TestAnnotateTrace(t *testing.T) {
+	tcp := nbdb.LoadBalancerProtocolTCP
+	nbData := []libovsdbtest.TestData{
+		&nbdb.LogicalSwitchPort{UUID: "aaaaaaa1-0000-0000-0000-000000000000", Name: "ns1_pod1",
+			ExternalIDs: map[string]string{"namespace": "ns1", "pod": "true"}},
+		&nbdb.LogicalSwitchPort{UUID: "aaaaaaa2-0000-0000-0000-000000000000", Name: "ns2_pod3",
+			ExternalIDs: map[string]string{"namespace": "ns2", "pod": "true"}},
+		&nbdb.LoadBalancer{UUID: "aaaaaaa3-0000-0000-0000-000000000000", Name: "Service_ns2/svc_TCP_cluster", Protocol: &tcp,
+			Vips: map[string]string{"10.96.0.10:80": "10.128.2.3:8080"},
+			ExternalIDs: map[string]string{
+				types.LoadBalancerKindExternalID:  "Service",
+				types.LoadBalancerOwnerExternalID: "ns2/svc",
+			}},
+		&nbdb.ACL{UUID: "aaaaaaa4-0000-0000-0000-000000000000", Action: nbdb.ACLActionDrop, Direction: nbdb.ACLDirectionToLport,
+			Match: "outport == @pg_deny", Priority: types.DefaultDenyPriority,
+			ExternalIDs: map[string]string{
+				libovsdbops.OwnerTypeKey.String():       string(libovsdbops.NetpolNamespaceOwnerType),
+				libovsdbops.ObjectNameKey.String():      "ns1",
+				libovsdbops.PolicyDirectionKey.String(): "Ingress",
+				libovsdbops.TypeKey.String():            "defaultDeny",
+			}},
+		&nbdb.NAT{UUID: "aaaaaaa5-0000-0000-0000-000000000000", Type: nbdb.NATTypeSNAT, LogicalIP: "10.128.1.3",
+			ExternalIP: "172.18.0.100", ExternalIDs: map[string]string{"name": "egressip1"}},
+		&nbdb.PortGroup{UUID: "pg-deny-UUID", Name: "pg_deny", ACLs: []string{"aaaaaaa4-0000-0000-0000-000000000000"}},
+		&nbdb.LogicalSwitch{UUID: "node1-UUID", Name: "node1", Ports: []string{"aaaaaaa1-0000-0000-0000-000000000000"},
+			LoadBalancer: []string{"aaaaaaa3-0000-0000-0000-000000000000"}},
+		&nbdb.LogicalSwitch{UUID: "node2-UUID", Name: "node2", Ports: []string{"aaaaaaa2-0000-0000-0000-000000000000"}},
+		&nbdb.LogicalRouter{UUID: "gr-UUID", Name: "GR_node2", Nat: []string{"aaaaaaa5-0000-0000-0000-000000000000"}},
+	}
+	sbData := []libovsdbtest.TestData{}
+	for lflowUUID, hint := range map[string]string{
+		"11111111-0000-0000-0000-000000000000": "aaaaaaa1",
+		"22222222-0000-0000-0000-000000000000": "aaaaaaa3",
+		"33333333-0000-0000-0000-000000000000": "",
+		"44444444-0000-0000-0000-000000000000": "aaaaaaa2",
+		"55555555-0000-0000-0000-000000000000": "aaaaaaa4",
+		"66666666-0000-0000-0000-000000000000": "aaaaaaa5",
+	} {
+		lflow := &sbdb.LogicalFlow{UUID: lflowUUID, Pipeline: sbdb.LogicalFlowPipelineIngress, ExternalIDs: map[string]string{}}
+		if hint != "" {
+			lflow.ExternalIDs[ovnStageHintKey] = hint
+		}
+		sbData = append(sbData, lflow)
+	}
+	nbClient, sbClient, cleanup, err := libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{NBData: nbData, SBData: sbData})
+	if err != nil {
+		t.Fatalf("failed to set up test harness: %v", err)
+	}
+	t.Cleanup(cleanup.Cleanup)
+	if err := monitorLogicalFlowHints(sbClient); err != nil {
+		t.Fatalf("failed to monitor the logical flows: %v", err)
+	}
+
+	result := parseOvnTrace(ovnTraceToService)
+	annotateTrace(nbClient, sbClient, result)
+	wantOwners := []*TraceOwner{
+		{Kind: "Pod", Name: "ns1/pod1"},
+		{Kind: "Service", Name: "ns2/svc"},
+		nil,
+		{Kind: "Pod", Name: "ns2/pod3"},
+	}
+	for i, hop := range result.Hops {
+		if (hop.Owner == nil) != (wantOwners[i] == nil) || (hop.Owner != nil && *hop.Owner != *wantOwners[i]) {
+			t.Errorf("got hop %+v, want owner %+v", hop, wantOwners[i])
+		}
+	}
+	if result.Hops[1].Table != nbdb.LoadBalancerTable || result.Hops[1].NBUUID != "aaaaaaa3-0000-0000-0000-000000000000" {
+		t.Errorf("got hop %+v, want it resolved to the load balancer of the service", result.Hops[1])
+	}
+	if result.Explanation != noPolicyExplanation {
+		t.Errorf("got explanation %q, want %q", result.Explanation, noPolicyExplanation)
+	}
+
+	result = parseOvnTrace(ovnTraceDenied)
+	annotateTrace(nbClient, sbClient, result)
+	wantExplanation := "dropped by the ingress default deny of namespace ns1: the pod is selected by a NetworkPolicy " +
+		"for ingress and none of its rules allows this traffic"
+	if result.Explanation != wantExplanation {
+		t.Errorf("got explanation %q, want %q", result.Explanation, wantExplanation)
+	}
+
+	result = parseOvnTrace(ovnTraceToEgressIP)
+	annotateTrace(nbClient, sbClient, result)
+	want := TraceEgress{Node: "node2", SNATIP: "172.18.0.100", EgressIP: "egressip1"}
+	if result.Egress == nil || *result.Egress != want {
+		t.Errorf("got egress %+v, want %+v", result.Egress, want)
+	}
+
+	result = parseOvnTrace(ovnTraceToHost)
+	annotateTrace(nbClient, sbClient, result)
+	want = TraceEgress{Node: "node1"}
+	if result.Egress == nil || *result.Egress != want {
+		t.Errorf("got egress %+v, want %+v", result.Egress, want)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	kapi "k8s.io/api/core/v1"
@@ -86,8 +87,11 @@ type PodInfo struct {
 	PodName              string // name of the pod
 	PodNamespace         string // the pod's namespace
 	ContainerName        string // the pod's principal container name (the first container found atm)
-	RtosMAC              string // router to switch mac address, the L2 address of the first hop router of the pod
+	RtosMAC              string // router to switch mac address, the L2 address of the first hop router of the pod, if any
 	HostNetwork          bool   // if this pod is host networked or not
+	NADName              string // the network attachment definition of the traced interface, default for the default network
+	LogicalPort          string // the logical switch port of the traced interface
+	LogicalSwitch        string // the logical switch of the traced interface, the datapath ovn-trace starts from
 }
 
 // String returns a JSON representation of the SvcInfo object, or "" on failure.
@@ -122,6 +126,21 @@ func (pi PodInfo) getL3Ver() string {
 	return "ip6"
 }
 
+// firstHopMAC returns the L2 address the pod sends traffic to dstPodInfo to: its first hop router or, on layer2 and
+// localnet secondary networks that have none, the destination pod on the same switch.
+func (pi PodInfo) firstHopMAC(dstPodInfo *PodInfo) string {
+	if pi.RtosMAC == "" {
+		return dstPodInfo.MAC
+	}
+	return pi.RtosMAC
+}
+
+// isOnLocalnet returns true if the traced interface is on the switch of a localnet secondary network, which spans
+// all nodes and sends traffic between nodes through the physical network instead of the overlay.
+func (pi PodInfo) isOnLocalnet() bool {
+	return strings.HasSuffix(pi.LogicalSwitch, types.OVNLocalnetSwitch)
+}
+
 // FullyQualifiedPodName returns the full name of the pod, <namespace>_<pod>.
 func (si *SvcInfo) FullyQualifiedPodName() string {
 	return fmt.Sprintf("%s_%s", si.PodNamespace, si.PodName)
@@ -425,7 +444,9 @@ func extractSubsetInfo(subsets []kapi.EndpointSubset, svcInfo *SvcInfo) error {
 }
 
 // getPodInfo returns a pointer to a fully populated PodInfo struct, or error on failure.
-func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace string, cmd string) (podInfo *PodInfo, err error) {
+// nadName is the network attachment definition of the pod interface to trace from, types.DefaultNetworkName for the
+// default network.
+func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace string, cmd string, nadName string) (podInfo *PodInfo, err error) {
 	// Create a PodInfo object with the base information already added, such as
 	// IP, PodName, ContainerName, NodeName, HostNetwork, Namespace, PrimaryInterfaceName
 	pod, err := coreclient.Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -439,8 +460,12 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		ContainerName: pod.Spec.Containers[0].Name,
 		HostNetwork:   pod.Spec.HostNetwork,
 		PodNamespace:  pod.Namespace,
+		NADName:       nadName,
 	}
 	podInfo.NodeName = pod.Spec.NodeName
+	if nadName != types.DefaultNetworkName && podInfo.HostNetwork {
+		return nil, fmt.Errorf("pod %s in namespace %s is host networked, it has no interface on network %s", podName, namespace, nadName)
+	}
 
 	// Get the pod's ovnkubePod.
 	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
@@ -455,24 +480,51 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		return nil, err
 	}
 
-	// Get the pod's MAC address.
-	podInfo.MAC, err = getPodMAC(coreclient, pod)
-	if err != nil {
-		klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
-		return nil, err
+	if nadName == types.DefaultNetworkName {
+		// Get the pod's MAC address.
+		podInfo.MAC, err = getPodMAC(coreclient, pod)
+		if err != nil {
+			klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
+			return nil, err
+		}
+		podInfo.LogicalPort = podInfo.FullyQualifiedPodName()
+		if podInfo.HostNetwork {
+			podInfo.LogicalPort = types.K8sPrefix + podInfo.NodeName
+		}
+		podInfo.LogicalSwitch = podInfo.NodeName
+	} else {
+		// Get the addresses of the pod's interface on the secondary network.
+		podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the addresses of pod %s in namespace %s on network %s: %v", podName, namespace, nadName, err)
+		}
+		if len(podAnnotation.IPs) == 0 {
+			return nil, fmt.Errorf("pod %s in namespace %s has no IP address on network %s", podName, namespace, nadName)
+		}
+		podInfo.IP = podAnnotation.IPs[0].IP.String()
+		podInfo.MAC = podAnnotation.MAC.String()
+		podInfo.LogicalPort = util.GetSecondaryNetworkLogicalPortName(podInfo.PodNamespace, podInfo.PodName, nadName)
+		podInfo.LogicalSwitch, err = getLogicalSwitchOfPort(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, cmd, podInfo.LogicalPort)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Find rtos MAC (this is the pod's first hop router).
-	lspCmd := "ovn-sbctl --no-leader-only " + cmd + " --bare --no-heading --column=mac list Port_Binding " + types.RouterToSwitchPrefix + podInfo.NodeName
+	// Find rtos MAC (this is the pod's first hop router). There is none on layer2 and localnet secondary networks.
+	lspCmd := "ovn-sbctl --no-leader-only " + cmd + " --bare --no-heading --column=mac find Port_Binding logical_port=" + types.RouterToSwitchPrefix + podInfo.LogicalSwitch
 	ipOutput, ipError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, "ovnkube-node", lspCmd, "")
 	if err != nil {
 		return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, ipError, ipOutput, podInfo)
 	}
-	macIP := strings.Split(strings.Replace(ipOutput, "\n", "", -1), " ")
-	if len(macIP) != 2 {
-		return nil, fmt.Errorf("invalid output %s", ipOutput)
+	if ipOutput = strings.TrimSpace(ipOutput); ipOutput != "" {
+		macIP := strings.Split(ipOutput, " ")
+		if len(macIP) != 2 {
+			return nil, fmt.Errorf("invalid output %s", ipOutput)
+		}
+		podInfo.RtosMAC = macIP[0]
+	} else if nadName == types.DefaultNetworkName {
+		return nil, fmt.Errorf("port binding %s%s not found", types.RouterToSwitchPrefix, podInfo.LogicalSwitch)
 	}
-	podInfo.RtosMAC = macIP[0]
 
 	// Set information specific to ovn-k8s-mp0. This info is required for routingViaHost gateway mode traffic to an external IP
 	// destination.
@@ -492,11 +544,13 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
 	} else {
 		// Get the pod's interface information
-		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.FullyQualifiedPodName())
+		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.LogicalPort)
 		if err != nil {
 			return nil, err
 		}
-		podInfo.PrimaryInterfaceName = "eth0"
+		if nadName == types.DefaultNetworkName {
+			podInfo.PrimaryInterfaceName = "eth0"
+		}
 		podInfo.VethName = ovsInterfaceInformation.Name
 		podInfo.OfportNum = ovsInterfaceInformation.Ofport
 	}
@@ -509,6 +563,25 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	return podInfo, err
 }
 
+// getLogicalSwitchOfPort returns the name of the logical switch of the logical port, from its port binding.
+func getLogicalSwitchOfPort(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace, podName, sbcmd, logicalPort string) (string, error) {
+	cmd := "ovn-sbctl --no-leader-only " + sbcmd + " --bare --no-heading --column=datapath find Port_Binding logical_port=" + logicalPort
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podName, "ovnkube-node", cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	datapath := strings.TrimSpace(stdout)
+	if datapath == "" {
+		return "", fmt.Errorf("port binding %s not found", logicalPort)
+	}
+	cmd = "ovn-sbctl --no-leader-only " + sbcmd + " get Datapath_Binding " + datapath + " external_ids:name"
+	stdout, stderr, err = execInPod(coreclient, restconfig, ovnNamespace, podName, "ovnkube-node", cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	return strings.Trim(strings.TrimSpace(stdout), "\""), nil
+}
+
 // getNodeExternalBridgeName gets the name of the external bridge of this node, e.g. breth0 or br-ex.
 func getNodeExternalBridgeName(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace, podName, sbcmd, nodeName string) (string, error) {
 	cmd := "ovn-sbctl --no-leader-only " + sbcmd + " --bare --no-heading --column=logical_port find Port_Binding options:network_name=" + types.PhysicalNetworkName
@@ -638,38 +711,36 @@ func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandS
 }
 
 // runOvnTraceToService runs an ovntrace from src pod to dst service. If dstSvcInfo == nil, then skip all steps.
-func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, sbcmd, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
-	}
+func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, sbcmd, ovnNamespace, protocol, dstPort string, nbACLs []*nbdb.ACL) {
+	l4Match := getOvnTraceL4Match(protocol, dstSvcInfo.getL3Ver(), dstPort)
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
-		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888' --lb-dst %[12]s:%[13]s`,
-		sbcmd,                 // 1
-		srcPodInfo.NodeName,   // 2
-		inport,                // 3
-		srcPodInfo.MAC,        // 4
-		srcPodInfo.RtosMAC,    // 5
-		srcPodInfo.getL3Ver(), // 6
-		srcPodInfo.IP,         // 7
-		dstSvcInfo.getL3Ver(), // 8
-		dstSvcInfo.ClusterIP,  // 9
-		protocol,              // 10
-		dstPort,               // 11
-		dstSvcInfo.PodIP,      // 12
-		dstSvcInfo.PodPort,    // 13
+		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s' --lb-dst %[11]s:%[12]s`,
+		sbcmd,                    // 1
+		srcPodInfo.LogicalSwitch, // 2
+		srcPodInfo.LogicalPort,   // 3
+		srcPodInfo.MAC,           // 4
+		srcPodInfo.RtosMAC,       // 5
+		srcPodInfo.getL3Ver(),    // 6
+		srcPodInfo.IP,            // 7
+		dstSvcInfo.getL3Ver(),    // 8
+		dstSvcInfo.ClusterIP,     // 9
+		l4Match,                  // 10
+		dstSvcInfo.PodIP,         // 11
+		dstSvcInfo.PodPort,       // 12
 	)
 	klog.V(4).Infof("ovn-trace command from src to service clusterIP is %s", cmd)
 
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, "ovnkube-node", cmd, "")
+	if err == nil && nbACLs != nil {
+		printPolicyVerdicts("ovn-trace from source pod to service clusterIP", ovnTraceACLVerdicts(ovnSrcDstOut, nbACLs))
+	}
 	successString := fmt.Sprintf(`output to "%s"`, dstSvcInfo.FullyQualifiedPodName())
 	printSuccessOrFailure("ovn-trace from source pod to service clusterIP", srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 // runOvnTraceToIP runs an ovntrace from src pod to dst IP address (should be external to the cluster).
 // Returns the node that the trace will exit on.
-func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, sbcmd, ovnNamespace, protocol, dstPort string) (string, string) {
+func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, sbcmd, nbcmd, ovnNamespace, protocol, dstPort string, nbACLs []*nbdb.ACL) (string, string) {
 	if srcPodInfo.HostNetwork {
 		klog.Exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
 	}
@@ -683,19 +754,19 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 			srcPodInfo.IP, parsedDstIP)
 	}
 
+	l4Match := getOvnTraceL4Match(protocol, l3ver, dstPort)
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
-		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		sbcmd,                              // 1
-		srcPodInfo.NodeName,                // 2
-		srcPodInfo.FullyQualifiedPodName(), // 3
-		srcPodInfo.MAC,                     // 4
-		srcPodInfo.RtosMAC,                 // 5
-		l3ver,                              // 6
-		srcPodInfo.IP,                      // 7
-		l3ver,                              // 8
-		parsedDstIP,                        // 9
-		protocol,                           // 10
-		dstPort,                            // 11
+		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s'`,
+		sbcmd,                    // 1
+		srcPodInfo.LogicalSwitch, // 2
+		srcPodInfo.LogicalPort,   // 3
+		srcPodInfo.MAC,           // 4
+		srcPodInfo.RtosMAC,       // 5
+		l3ver,                    // 6
+		srcPodInfo.IP,            // 7
+		l3ver,                    // 8
+		parsedDstIP,              // 9
+		l4Match,                  // 10
 	)
 	klog.V(4).Infof("ovn-trace command from pod to IP is %s", cmd)
 
@@ -705,6 +776,9 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	successString := fmt.Sprintf(`output to "(.*)_(.*)", type "localnet"|output to "k8s-%s"`, srcPodInfo.NodeName)
 	// Run the command and check if succesString was found.
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, "ovnkube-node", cmd, "")
+	if err == nil && nbACLs != nil {
+		printPolicyVerdicts("ovn-trace from pod to IP", ovnTraceACLVerdicts(ovnSrcDstOut, nbACLs))
+	}
 	printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
 
 	// Print some additional information about the node where this request leaves from as well
@@ -723,7 +797,16 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		}
 		node := subMatches[len(subMatches)-1]
 		bridgeName := subMatches[len(subMatches)-2]
-		klog.V(1).Infof("%sout on node %s via Logical_Switch_Port %s with SNAT %s%s\n", green, node, bridgeName, snat, reset)
+		// Traffic of pods matched by an EgressIP is SNATed to the EgressIP on the egress node.
+		egressIPName, err := getEgressIPName(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, nbcmd, srcPodInfo.IP, string(snat))
+		if err != nil {
+			klog.Warningf("Failed to find the EgressIP SNAT of pod %s to %s: %v", srcPodInfo.PodName, snat, err)
+		}
+		if egressIPName != "" {
+			fmt.Printf("%sout on egress node %s via Logical_Switch_Port %s with SNAT to %s of EgressIP %s%s\n", green, node, bridgeName, snat, egressIPName, reset)
+		} else {
+			fmt.Printf("%sout on node %s via Logical_Switch_Port %s with SNAT to %s%s\n", green, node, bridgeName, snat, reset)
+		}
 
 		return string(node), string(bridgeName)
 	}
@@ -736,30 +819,48 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		klog.Exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
 	}
 	node := subMatches[len(subMatches)-1]
-	klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
+	fmt.Printf("%sout on node %s%s\n", green, node, reset)
 	return string(node), ""
 }
 
-// runOvnTraceToPod runs an ovntrace from src pod to dst pod.
-func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, sbcmd, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
+// getEgressIPName returns the name of the EgressIP whose SNAT translates podIP to snatIP on the egress node, or "" if
+// the SNAT isn't an EgressIP's. The SNATs of EgressIPs are named after them.
+func getEgressIPName(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace, podName, nbcmd, podIP, snatIP string) (string, error) {
+	cmd := fmt.Sprintf(`ovn-nbctl --no-leader-only %s --bare --no-heading --columns=external_ids find NAT type=snat 'logical_ip="%s"' 'external_ip="%s"'`,
+		nbcmd, podIP, snatIP)
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podName, "ovnkube-node", cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
 	}
+	return parseEgressIPName(stdout), nil
+}
+
+// parseEgressIPName returns the EgressIP name of the external IDs of NATs listed with ovn-nbctl --bare, e.g.
+// "name=egressip-1".
+func parseEgressIPName(externalIDs string) string {
+	for _, field := range strings.Fields(externalIDs) {
+		if strings.HasPrefix(field, "name=") {
+			return strings.TrimPrefix(field, "name=")
+		}
+	}
+	return ""
+}
+
+// runOvnTraceToPod runs an ovntrace from src pod to dst pod.
+func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, sbcmd, ovnNamespace, protocol, dstPort string, nbACLs []*nbdb.ACL) {
+	l4Match := getOvnTraceL4Match(protocol, dstPodInfo.getL3Ver(), dstPort)
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
-		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		sbcmd,                 // 1
-		srcPodInfo.NodeName,   // 2
-		inport,                // 3
-		srcPodInfo.MAC,        // 4
-		srcPodInfo.RtosMAC,    // 5
-		srcPodInfo.getL3Ver(), // 6
-		srcPodInfo.IP,         // 7
-		dstPodInfo.getL3Ver(), // 8
-		dstPodInfo.IP,         // 9
-		protocol,              // 10
-		dstPort,               // 11
+		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s'`,
+		sbcmd,                              // 1
+		srcPodInfo.LogicalSwitch,           // 2
+		srcPodInfo.LogicalPort,             // 3
+		srcPodInfo.MAC,                     // 4
+		srcPodInfo.firstHopMAC(dstPodInfo), // 5
+		srcPodInfo.getL3Ver(),              // 6
+		srcPodInfo.IP,                      // 7
+		dstPodInfo.getL3Ver(),              // 8
+		dstPodInfo.IP,                      // 9
+		l4Match,                            // 10
 	)
 	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
 
@@ -774,28 +875,30 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 			successString = fmt.Sprintf(`output to "%s_%s"`, srcPodInfo.NodeExternalBridgeName, srcPodInfo.NodeName)
 		}
 	} else {
-		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
 	}
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, "ovnkube-node", cmd, "")
+	if err == nil && nbACLs != nil {
+		printPolicyVerdicts("ovn-trace "+direction, ovnTraceACLVerdicts(ovnSrcDstOut, nbACLs))
+	}
 	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 // runOfprotoTraceToPod runs an ofproto/trace command from the src to the destination pod.
 func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) string {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(dstPodInfo.IP))
+	l4Args := getOfprotoL4Args(protocol, dstPort, net.ParseIP(dstPodInfo.IP))
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
-		`"in_port=%[1]s, %[9]s, dl_src=%[3]s, dl_dst=%[4]s, %[10]s=%[5]s, %[11]s=%[6]s, nw_ttl=64, %[7]s_dst=%[8]s, %[7]s_src=12345"`,
-		srcPodInfo.VethName, // 1
-		protocol,            // 2
-		srcPodInfo.MAC,      // 3
-		srcPodInfo.RtosMAC,  // 4
-		srcPodInfo.IP,       // 5
-		dstPodInfo.IP,       // 6
-		protocol,            // 7
-		dstPort,             // 8
-		protocolSelector,    // 9
-		nwSrc,               // 10
-		nwDst,               // 11
+		`"in_port=%[1]s, %[7]s, dl_src=%[2]s, dl_dst=%[3]s, %[8]s=%[4]s, %[9]s=%[5]s, nw_ttl=64, %[6]s"`,
+		srcPodInfo.VethName,                // 1
+		srcPodInfo.MAC,                     // 2
+		srcPodInfo.firstHopMAC(dstPodInfo), // 3
+		srcPodInfo.IP,                      // 4
+		dstPodInfo.IP,                      // 5
+		l4Args,                             // 6
+		protocolSelector,                   // 7
+		nwSrc,                              // 8
+		nwDst,                              // 9
 	)
 	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
 
@@ -815,6 +918,10 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		} else {
 			successString = fmt.Sprintf(`output:%s\n\nFinal flow:`, srcPodInfo.OvnK8sMp0OfportNum)
 		}
+	} else if srcPodInfo.isOnLocalnet() {
+		klog.V(5).Infof("Pods are on node: %s and node %s of localnet switch %s", srcPodInfo.NodeName, dstPodInfo.NodeName, srcPodInfo.LogicalSwitch)
+		// Trace will leave br-int to the bridge the localnet network is mapped to.
+		successString = `(?s)bridge\(".*bridge\("`
 	} else {
 		klog.V(5).Infof("Pods are on node: %s and node %s", srcPodInfo.NodeName, dstPodInfo.NodeName)
 		successString = "-> output to kernel tunnel"
@@ -831,18 +938,18 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 // If egressBridgeName == "", then this is routingViaHost Gateway mode without an EgressIP / EgressGW.
 func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) string {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, dstIP)
+	l4Args := getOfprotoL4Args(protocol, dstPort, dstIP)
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
-		`"in_port=%[1]s, %[8]s, dl_src=%[3]s, dl_dst=%[4]s, %[9]s=%[5]s, %[10]s=%[6]s, nw_ttl=64, %[2]s_dst=%[7]s, %[2]s_src=12345"`,
+		`"in_port=%[1]s, %[7]s, dl_src=%[2]s, dl_dst=%[3]s, %[8]s=%[4]s, %[9]s=%[5]s, nw_ttl=64, %[6]s"`,
 		srcPodInfo.VethName, // 1
-		protocol,            // 2
-		srcPodInfo.MAC,      // 3
-		srcPodInfo.RtosMAC,  // 4
-		srcPodInfo.IP,       // 5
-		dstIP.String(),      // 6
-		dstPort,             // 7
-		protocolSelector,    // 8
-		nwSrc,               // 9
-		nwDst,               // 10
+		srcPodInfo.MAC,      // 2
+		srcPodInfo.RtosMAC,  // 3
+		srcPodInfo.IP,       // 4
+		dstIP.String(),      // 5
+		l4Args,              // 6
+		protocolSelector,    // 7
+		nwSrc,               // 8
+		nwDst,               // 9
 	)
 	direction := "pod to IP"
 	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
@@ -882,6 +989,30 @@ func getOfprotoIPFamilyArgs(protocol string, ip net.IP) (string, string, string)
 	return protocolSelector, nwSrc, nwDst
 }
 
+// getOfprotoL4Args generates the L4 fields of an ofproto/trace flow: the ports for tcp, udp and sctp, an echo request
+// for icmp.
+func getOfprotoL4Args(protocol, dstPort string, ip net.IP) string {
+	if protocol == "icmp" {
+		if ip.To4() == nil {
+			return "icmp_type=128, icmp_code=0"
+		}
+		return "icmp_type=8, icmp_code=0"
+	}
+	return fmt.Sprintf("%[1]s_dst=%[2]s, %[1]s_src=12345", protocol, dstPort)
+}
+
+// getOvnTraceL4Match generates the L4 part of an ovn-trace microflow: the ports for tcp, udp and sctp, an echo request
+// for icmp.
+func getOvnTraceL4Match(protocol, l3ver, dstPort string) string {
+	if protocol == "icmp" {
+		if l3ver == "ip6" {
+			return "icmp6.type==128 && icmp6.code==0"
+		}
+		return "icmp4.type==8 && icmp4.code==0"
+	}
+	return fmt.Sprintf("%[1]s.dst==%[2]s && %[1]s.src==52888", protocol, dstPort)
+}
+
 // installOvnDetraceDependencies installs dependencies for ovn-detrace with pip3 in case they are missing (for older images).
 // Returns error if dependencies are missing but cannot be installed.
 func installOvnDetraceDependencies(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName, ovnNamespace string) error {
@@ -1009,8 +1140,23 @@ func main() {
 	dstPort := flag.String("dst-port", "80", "dst-port: destination port")
 	tcp := flag.Bool("tcp", false, "use tcp transport protocol")
 	udp := flag.Bool("udp", false, "use udp transport protocol")
+	sctp := flag.Bool("sctp", false, "use sctp transport protocol")
+	icmp := flag.Bool("icmp", false, "use icmp, or icmpv6 for IPv6, echo requests")
+	network := flag.String("network", "", "network: <namespace>/<name> of the network attachment definition of a secondary network "+
+		"to trace on instead of the default network, the namespace of the source pod if omitted")
 	skipOvnDetrace := flag.Bool("skip-detrace", false, "skip ovn-detrace command")
 	loglevel := flag.String("loglevel", "0", "loglevel: klog level")
+	libovsdbTrace := flag.Bool("libovsdb", false, "run ovn-trace on this host against the southbound database and annotate its result "+
+		"with the objects of the OVN databases read with libovsdb instead of running ovn-trace, ovs-appctl and ovn-detrace "+
+		"in the ovnkube pods, and print the result as JSON")
+	nbAddress := flag.String("nb-address", "", "address of the OVN northbound database for -libovsdb, e.g. ssl:172.18.0.2:6641 "+
+		"(found from the ovnkube pods by default)")
+	sbAddress := flag.String("sb-address", "", "address of the OVN southbound database for -libovsdb, e.g. ssl:172.18.0.2:6642 "+
+		"(found from the ovnkube pods by default)")
+	dbPrivKey := flag.String("db-client-privkey", "", "private key of the client to connect to the OVN databases with ssl for -libovsdb")
+	dbCert := flag.String("db-client-cert", "", "certificate of the client to connect to the OVN databases with ssl for -libovsdb")
+	dbCACert := flag.String("db-client-cacert", "", "CA certificate to verify the OVN databases with ssl for -libovsdb")
+	dbCertCommonName := flag.String("db-cert-common-name", "", "common name of the certificate of the OVN databases with ssl for -libovsdb")
 	flag.Parse()
 
 	// Set the application's log level.
@@ -1020,20 +1166,28 @@ func main() {
 	if *srcPodName == "" {
 		klog.Exitf("Usage: source pod must be specified")
 	}
-	if !*tcp && !*udp {
-		klog.Exitf("Usage: either tcp or udp must be specified")
+	protocols := 0
+	for name, set := range map[string]bool{"tcp": *tcp, "udp": *udp, "sctp": *sctp, "icmp": *icmp} {
+		if set {
+			protocols++
+			protocol = name
+		}
 	}
-	if *udp && *tcp {
-		klog.Exitf("Usage: Both tcp and udp cannot be specified at the same time")
+	if protocols != 1 {
+		klog.Exitf("Usage: exactly one of -tcp, -udp, -sctp or -icmp must be specified")
 	}
-	if *tcp {
-		protocol = "tcp"
+	if (protocol == "udp" || protocol == "icmp") && *dstSvcName != "" {
+		klog.Exitf("Usage: %s option is not compatible with destination service trace", protocol)
 	}
-	if *udp {
-		if *dstSvcName != "" {
-			klog.Exitf("Usage: udp option is not compatible with destination service trace")
+	nadName := types.DefaultNetworkName
+	if *network != "" {
+		if *dstSvcName != "" || *dstIP != "" {
+			klog.Exitf("Usage: -network can only be used to trace from a pod to another pod")
+		}
+		nadName = *network
+		if !strings.Contains(nadName, "/") {
+			nadName = util.GetNADName(*srcNamespace, nadName)
 		}
-		protocol = "udp"
 	}
 	targetOptions := 0
 	if *dstPodName != "" {
@@ -1096,6 +1250,30 @@ func main() {
 		displayNodeInfo(coreclient)
 	}
 
+	if *libovsdbTrace {
+		err = runLibovsdbTrace(coreclient, restconfig, ovnNamespace, &libovsdbTraceOptions{
+			nbAddress:      *nbAddress,
+			sbAddress:      *sbAddress,
+			privKey:        *dbPrivKey,
+			cert:           *dbCert,
+			caCert:         *dbCACert,
+			certCommonName: *dbCertCommonName,
+			srcNamespace:   *srcNamespace,
+			srcPodName:     *srcPodName,
+			dstNamespace:   *dstNamespace,
+			dstPodName:     *dstPodName,
+			dstSvcName:     *dstSvcName,
+			dstIP:          parsedDstIP,
+			protocol:       protocol,
+			dstPort:        *dstPort,
+			nadName:        nadName,
+		})
+		if err != nil {
+			klog.Exitf("Failed to trace with libovsdb: %v", err)
+		}
+		return
+	}
+
 	// Common ssl parameters
 	var sslCertKeys string
 	nbURI, sbURI, useSSL, err := getDatabaseURIs(coreclient, restconfig, ovnNamespace)
@@ -1113,16 +1291,22 @@ func main() {
 	klog.V(5).Infof("The sbcmd is %s", sbcmd)
 
 	// Get info needed for the src Pod
-	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, sbcmd)
+	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, sbcmd, nadName)
 	if err != nil {
 		klog.Exitf("Failed to get information from pod %s: %v", *srcPodName, err)
 	}
 	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
 
+	// Get the ACLs of the NB database to explain why ovn-trace allows or denies traffic
+	nbACLs, err := getNBACLs(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, nbcmd)
+	if err != nil {
+		klog.Warningf("Failed to get the NB ACLs, policy verdicts won't be explained: %v", err)
+	}
+
 	// 1) Either run a trace from source pod to destination IP and return ...
 	if parsedDstIP != nil {
 		klog.V(5).Infof("Running a trace to an IP address")
-		egressNodeName, egressBridgeName := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, sbcmd, ovnNamespace, protocol, *dstPort)
+		egressNodeName, egressBridgeName := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, sbcmd, nbcmd, ovnNamespace, protocol, *dstPort, nbACLs)
 		appSrcDstOut := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
 		if *skipOvnDetrace {
 			return
@@ -1151,7 +1335,7 @@ func main() {
 	}
 
 	// Now get info needed for the dst Pod
-	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, sbcmd)
+	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, sbcmd, nadName)
 	if err != nil {
 		klog.Exitf("Failed to get information from pod %s: %v", *dstPodName, err)
 	}
@@ -1164,10 +1348,10 @@ func main() {
 
 	// ovn-trace commands
 	if dstSvcInfo != nil {
-		runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, sbcmd, ovnNamespace, protocol, *dstPort)
+		runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, sbcmd, ovnNamespace, protocol, *dstPort, nbACLs)
 	}
-	runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, sbcmd, ovnNamespace, protocol, *dstPort)
-	runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, sbcmd, ovnNamespace, protocol, *dstPort)
+	runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, sbcmd, ovnNamespace, protocol, *dstPort, nbACLs)
+	runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, sbcmd, ovnNamespace, protocol, *dstPort, nbACLs)
 
 	// ovs-appctl ofproto/trace commands
 	appSrcDstOut := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
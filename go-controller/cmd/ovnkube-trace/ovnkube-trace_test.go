@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net"
+	"testing"
+)
+
+func TestL4Args(t *testing.T) {
+	tests := []struct {
+		protocol    string
+		ip          string
+		wantMatch   string
+		wantOfproto string
+	}{
+		{protocol: "tcp", ip: "10.128.1.3", wantMatch: "tcp.dst==80 && tcp.src==52888", wantOfproto: "tcp_dst=80, tcp_src=12345"},
+		{protocol: "sctp", ip: "fd00::3", wantMatch: "sctp.dst==80 && sctp.src==52888", wantOfproto: "sctp_dst=80, sctp_src=12345"},
+		{protocol: "icmp", ip: "10.128.1.3", wantMatch: "icmp4.type==8 && icmp4.code==0", wantOfproto: "icmp_type=8, icmp_code=0"},
+		{protocol: "icmp", ip: "fd00::3", wantMatch: "icmp6.type==128 && icmp6.code==0", wantOfproto: "icmp_type=128, icmp_code=0"},
+	}
+	for _, tt := range tests {
+		ip := net.ParseIP(tt.ip)
+		l3ver := "ip4"
+		if ip.To4() == nil {
+			l3ver = "ip6"
+		}
+		if got := getOvnTraceL4Match(tt.protocol, l3ver, "80"); got != tt.wantMatch {
+			t.Errorf("getOvnTraceL4Match(%s, %s) = %q, want %q", tt.protocol, l3ver, got, tt.wantMatch)
+		}
+		if got := getOfprotoL4Args(tt.protocol, "80", ip); got != tt.wantOfproto {
+			t.Errorf("getOfprotoL4Args(%s, %s) = %q, want %q", tt.protocol, tt.ip, got, tt.wantOfproto)
+		}
+	}
+}
+
+func TestParseEgressIPName(t *testing.T) {
+	if got := parseEgressIPName("name=egressip-1\n"); got != "egressip-1" {
+		t.Errorf("got %q, want egressip-1", got)
+	}
+	if got := parseEgressIPName("stale=true\n"); got != "" {
+		t.Errorf("got %q for a SNAT that isn't an EgressIP's", got)
+	}
+}
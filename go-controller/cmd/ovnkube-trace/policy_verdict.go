@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// ovnACLPriorityOffset is added by northd to the priority of the ACLs for the
+// priority of their logical flows
+const ovnACLPriorityOffset = 1000
+
+// noPolicyExplanation explains traffic no ACL matched
+const noPolicyExplanation = "no ACL matched, no NetworkPolicy or EgressFirewall applies to this traffic"
+
+// ovnTraceACLRegex matches the ACL stages of ovn-trace output, e.g.
+// " 4. ls_out_acl (northd.c:6453): reg0[7] == 1 && (outport == @a123 && ip4), priority 2001, uuid 43c9a1b2"
+var ovnTraceACLRegex = regexp.MustCompile(`^\s*\d+\. ls_(?:in|out)_acl\w* \([^)]*\): (.*), priority (\d+), uuid [0-9a-f]+$`)
+
+// PolicyVerdict explains what an ACL did to the traced traffic in terms of the
+// Kubernetes object it was created for
+type PolicyVerdict struct {
+	// Action is allowed, dropped, rejected or passed
+	Action      string `json:"action"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Direction   string `json:"direction,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+	Explanation string `json:"explanation"`
+}
+
+func (v *PolicyVerdict) isDenied() bool {
+	return v.Action == "dropped" || v.Action == "rejected"
+}
+
+// explainACL maps an ACL back to the NetworkPolicy, EgressFirewall or other
+// object it was built for, from the external IDs set by pkg/ovn/acl.go
+func explainACL(acl *nbdb.ACL) *PolicyVerdict {
+	v := &PolicyVerdict{Action: aclActionVerdict(acl.Action)}
+	ids := acl.ExternalIDs
+	name := ids[libovsdbops.ObjectNameKey.String()]
+	direction := strings.ToLower(ids[libovsdbops.PolicyDirectionKey.String()])
+	v.Direction = direction
+	switch ids[libovsdbops.OwnerTypeKey.String()] {
+	case string(libovsdbops.NetworkPolicyOwnerType):
+		// the policy name is <policyNamespace>:<policyName>
+		v.Kind = "NetworkPolicy"
+		v.Namespace, v.Name = splitPolicyKey(name)
+		v.Rule = ids[libovsdbops.GressIdxKey.String()]
+		v.Explanation = fmt.Sprintf("%s by %s rule %s of NetworkPolicy %s/%s", v.Action, direction, v.Rule, v.Namespace, v.Name)
+	case string(libovsdbops.NetpolNamespaceOwnerType):
+		v.Kind = "DefaultDeny"
+		v.Namespace = name
+		if ids[libovsdbops.TypeKey.String()] == "arpAllow" {
+			v.Explanation = fmt.Sprintf("%s by the ARP/ND allow rule of the %s default deny of namespace %s", v.Action, direction, name)
+		} else {
+			v.Explanation = fmt.Sprintf("%s by the %s default deny of namespace %s: the pod is selected by a NetworkPolicy "+
+				"for %s and none of its rules allows this traffic", v.Action, direction, name, direction)
+		}
+	case string(libovsdbops.EgressFirewallOwnerType):
+		v.Kind = "EgressFirewall"
+		v.Namespace, v.Name = name, "default"
+		v.Rule = ids[libovsdbops.RuleIndex.String()]
+		v.Explanation = fmt.Sprintf("%s by rule %s of EgressFirewall %s/default", v.Action, v.Rule, name)
+	case string(libovsdbops.NetpolNodeOwnerType):
+		v.Kind = "Node"
+		v.Name = name
+		v.Explanation = fmt.Sprintf("%s from the management port of node %s, which is always allowed e.g. for health checks", v.Action, name)
+	case string(libovsdbops.NetpolDefaultOwnerType):
+		v.Kind = "Cluster"
+		v.Name = name
+		v.Explanation = fmt.Sprintf("%s as service hairpin traffic, which is always allowed", v.Action)
+	case string(libovsdbops.MulticastNamespaceOwnerType):
+		v.Kind = "Namespace"
+		v.Namespace = name
+		v.Explanation = fmt.Sprintf("%s as %s multicast traffic of namespace %s", v.Action, direction, name)
+	case string(libovsdbops.MulticastClusterOwnerType):
+		v.Kind = "Cluster"
+		v.Rule = ids[libovsdbops.TypeKey.String()]
+		v.Explanation = fmt.Sprintf("%s by the cluster %s multicast rule %s", v.Action, direction, v.Rule)
+	default:
+		v.Kind = "ACL"
+		v.Name = acl.UUID
+		if acl.Name != nil && *acl.Name != "" {
+			v.Name = *acl.Name
+		}
+		v.Direction = acl.Direction
+		v.Explanation = fmt.Sprintf("%s by ACL %s", v.Action, v.Name)
+	}
+	return v
+}
+
+func aclActionVerdict(action string) string {
+	switch action {
+	case nbdb.ACLActionDrop:
+		return "dropped"
+	case nbdb.ACLActionReject:
+		return "rejected"
+	case "pass":
+		return "passed"
+	}
+	return "allowed"
+}
+
+func splitPolicyKey(key string) (string, string) {
+	s := strings.SplitN(key, ":", 2)
+	if len(s) != 2 {
+		return "", key
+	}
+	return s[0], s[1]
+}
+
+// ovnTraceACLVerdicts returns the verdicts of the ACLs the packet went through in
+// ovn-trace output, in order. The logical flows of ACLs are found back by
+// their match, which contains the match of the ACL, and their priority.
+func ovnTraceACLVerdicts(ovnTraceOutput string, acls []*nbdb.ACL) []*PolicyVerdict {
+	verdicts := []*PolicyVerdict{}
+	for _, line := range strings.Split(ovnTraceOutput, "\n") {
+		subMatches := ovnTraceACLRegex.FindStringSubmatch(line)
+		if len(subMatches) != 3 {
+			continue
+		}
+		flowMatch := subMatches[1]
+		priority, err := strconv.Atoi(subMatches[2])
+		if err != nil {
+			continue
+		}
+		var found *nbdb.ACL
+		for _, acl := range acls {
+			if acl.Priority+ovnACLPriorityOffset != priority || !strings.Contains(flowMatch, acl.Match) {
+				continue
+			}
+			if found == nil || len(acl.Match) > len(found.Match) {
+				found = acl
+			}
+		}
+		if found != nil {
+			verdicts = append(verdicts, explainACL(found))
+		}
+	}
+	return verdicts
+}
+
+// explainHops explains the verdict of a trace from the ACLs of its hops: the
+// ACL that denied the traffic, or all the ACLs that allowed it
+func explainHops(hops []TraceHop) string {
+	explanations := []string{}
+	for _, hop := range hops {
+		if hop.Policy == nil {
+			continue
+		}
+		if hop.Policy.isDenied() {
+			return hop.Policy.Explanation
+		}
+		explanations = append(explanations, hop.Policy.Explanation)
+	}
+	if len(explanations) == 0 {
+		return noPolicyExplanation
+	}
+	return strings.Join(explanations, "; ")
+}
+
+// printPolicyVerdicts prints why the traced traffic was allowed or denied
+func printPolicyVerdicts(commandDescription string, verdicts []*PolicyVerdict) {
+	if len(verdicts) == 0 {
+		fmt.Printf("%s%s: %s%s\n", green, commandDescription, noPolicyExplanation, reset)
+		return
+	}
+	for _, v := range verdicts {
+		color := green
+		if v.isDenied() {
+			color = red
+		}
+		fmt.Printf("%s%s: %s%s\n", color, commandDescription, v.Explanation, reset)
+	}
+}
+
+// getNBACLs lists the ACLs of the NB database with ovn-nbctl in an ovnkube pod
+func getNBACLs(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace, podName, nbcmd string) ([]*nbdb.ACL, error) {
+	cmd := "ovn-nbctl --no-leader-only " + nbcmd + " --format=json --columns=_uuid,action,direction,external_ids,match,name,priority list ACL"
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podName, "ovnkube-node", cmd, "")
+	if err != nil {
+		return nil, fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	acls, err := parseNbctlACLs(stdout)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(5).Infof("Found %d ACLs in the NB database", len(acls))
+	return acls, nil
+}
+
+// parseNbctlACLs parses the ACLs listed by ovn-nbctl --format=json, where the
+// columns are in the order of the headings and:
+// - a uuid is ["uuid", "<uuid>"]
+// - a map is ["map", [[key, value], ...]]
+// - an optional value that isn't set is ["set", []]
+func parseNbctlACLs(output string) ([]*nbdb.ACL, error) {
+	table := struct {
+		Headings []string        `json:"headings"`
+		Data     [][]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &table); err != nil {
+		return nil, fmt.Errorf("failed to parse ovn-nbctl output: %v", err)
+	}
+	acls := []*nbdb.ACL{}
+	for _, row := range table.Data {
+		if len(row) != len(table.Headings) {
+			return nil, fmt.Errorf("failed to parse ovn-nbctl output: row %v doesn't match headings %v", row, table.Headings)
+		}
+		acl := &nbdb.ACL{ExternalIDs: map[string]string{}}
+		for i, heading := range table.Headings {
+			switch heading {
+			case "_uuid":
+				acl.UUID = nbctlAtom(row[i])
+			case "action":
+				acl.Action = nbctlAtom(row[i])
+			case "direction":
+				acl.Direction = nbctlAtom(row[i])
+			case "match":
+				acl.Match = nbctlAtom(row[i])
+			case "name":
+				if name := nbctlAtom(row[i]); name != "" {
+					acl.Name = &name
+				}
+			case "priority":
+				if priority, ok := row[i].(float64); ok {
+					acl.Priority = int(priority)
+				}
+			case "external_ids":
+				acl.ExternalIDs = nbctlMap(row[i])
+			}
+		}
+		acls = append(acls, acl)
+	}
+	return acls, nil
+}
+
+// nbctlAtom returns the string value of an atom, or "" for an empty set
+func nbctlAtom(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) == 2 && v[0] == "uuid" {
+			if uuid, ok := v[1].(string); ok {
+				return uuid
+			}
+		}
+	}
+	return ""
+}
+
+func nbctlMap(value interface{}) map[string]string {
+	m := map[string]string{}
+	v, ok := value.([]interface{})
+	if !ok || len(v) != 2 || v[0] != "map" {
+		return m
+	}
+	pairs, ok := v[1].([]interface{})
+	if !ok {
+		return m
+	}
+	for _, pair := range pairs {
+		kv, ok := pair.([]interface{})
+		if !ok || len(kv) != 2 {
+			continue
+		}
+		key, keyOK := kv[0].(string)
+		val, valOK := kv[1].(string)
+		if keyOK && valOK {
+			m[key] = val
+		}
+	}
+	return m
+}
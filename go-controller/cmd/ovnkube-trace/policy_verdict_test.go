@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+// ACLs as built for pkg/ovn/acl.go, as listed by ovn-nbctl --format=json
+const nbctlACLs = `{"data":[
+[["uuid","5b2e8f3c-1d2e-4a5b-9c8d-7e6f5a4b3c2d"],"allow-related","to-lport",
+ ["map",[["direction","Ingress"],["gress-index","0"],["ip-block-index","-1"],["k8s.ovn.org/id","default-network-controller:NetworkPolicy:ns1:allow-web:Ingress:0:-1:-1"],
+  ["k8s.ovn.org/name","ns1:allow-web"],["k8s.ovn.org/owner-controller","default-network-controller"],["k8s.ovn.org/owner-type","NetworkPolicy"],["port-policy-index","-1"]]],
+ "outport == @a15551231271227262553 && ip4.src == {$a10481622940199974102}","NP:ns1:allow-web:Ingress:0",1001],
+[["uuid","8c3d9e4f-2a3b-4c5d-8e9f-0a1b2c3d4e5f"],"drop","to-lport",
+ ["map",[["direction","Ingress"],["k8s.ovn.org/id","default-network-controller:NetpolNamespace:ns1:Ingress:defaultDeny"],["k8s.ovn.org/name","ns1"],
+  ["k8s.ovn.org/owner-controller","default-network-controller"],["k8s.ovn.org/owner-type","NetpolNamespace"],["type","defaultDeny"]]],
+ "outport == @a16982411286042166782_ingressDefaultDeny","NP:ns1:Ingress",1000],
+[["uuid","1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"],"allow","to-lport",["map",[]],"ip4.src==10.128.0.2",["set",[]],1001]
+],"headings":["_uuid","action","direction","external_ids","match","name","priority"]}`
+
+// ovn-trace output going through the ACL stages of the ACLs above
+const ovnTraceOutput = `ingress(dp="ovn-worker", inport="stor-ovn-worker")
+ 8. ls_in_acl_hint (northd.c:6182): !ct.trk, priority 5, uuid b1ae686d
+    reg0[8] = 1;
+    next;
+egress(dp="ovn-worker", inport="stor-ovn-worker", outport="ns1_web")
+ 4. ls_out_acl (northd.c:6453): reg0[7] == 1 && (outport == @a15551231271227262553 && ip4.src == {$a10481622940199974102}), priority 2001, uuid 43c9a1b2
+    reg0[1] = 1;
+    next;
+ 4. ls_out_acl (northd.c:6453): (outport == @a16982411286042166782_ingressDefaultDeny), priority 2000, uuid 9e8d7c6b
+    /* no actions */`
+
+func TestParseNbctlACLs(t *testing.T) {
+	acls, err := parseNbctlACLs(nbctlACLs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(acls) != 3 {
+		t.Fatalf("got %d ACLs, want 3", len(acls))
+	}
+	acl := acls[0]
+	if acl.UUID != "5b2e8f3c-1d2e-4a5b-9c8d-7e6f5a4b3c2d" || acl.Action != nbdb.ACLActionAllowRelated || acl.Priority != 1001 ||
+		acl.Name == nil || *acl.Name != "NP:ns1:allow-web:Ingress:0" || acl.ExternalIDs[libovsdbops.ObjectNameKey.String()] != "ns1:allow-web" {
+		t.Errorf("unexpected first ACL %+v", acl)
+	}
+	if acls[2].Name != nil || len(acls[2].ExternalIDs) != 0 {
+		t.Errorf("unexpected last ACL %+v", acls[2])
+	}
+	if _, err := parseNbctlACLs(`{"data":[["a"]],"headings":["_uuid","match"]}`); err == nil {
+		t.Errorf("expected an error for a row not matching the headings")
+	}
+}
+
+func TestOvnTraceACLVerdicts(t *testing.T) {
+	acls, err := parseNbctlACLs(nbctlACLs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verdicts := ovnTraceACLVerdicts(ovnTraceOutput, acls)
+	want := []PolicyVerdict{
+		{Action: "allowed", Kind: "NetworkPolicy", Namespace: "ns1", Name: "allow-web", Direction: "ingress", Rule: "0",
+			Explanation: "allowed by ingress rule 0 of NetworkPolicy ns1/allow-web"},
+		{Action: "dropped", Kind: "DefaultDeny", Namespace: "ns1", Direction: "ingress",
+			Explanation: "dropped by the ingress default deny of namespace ns1: the pod is selected by a NetworkPolicy " +
+				"for ingress and none of its rules allows this traffic"},
+	}
+	if len(verdicts) != len(want) {
+		t.Fatalf("got %d verdicts, want %d: %+v", len(verdicts), len(want), verdicts)
+	}
+	for i := range want {
+		if *verdicts[i] != want[i] {
+			t.Errorf("got verdict %+v, want %+v", *verdicts[i], want[i])
+		}
+	}
+}
+
+func TestExplainACL(t *testing.T) {
+	name := "EF:ns2:1"
+	tests := []struct {
+		acl  *nbdb.ACL
+		want string
+	}{
+		{
+			acl: &nbdb.ACL{Action: nbdb.ACLActionDrop, Name: &name, ExternalIDs: map[string]string{
+				libovsdbops.OwnerTypeKey.String():  string(libovsdbops.EgressFirewallOwnerType),
+				libovsdbops.ObjectNameKey.String(): "ns2",
+				libovsdbops.RuleIndex.String():     "1",
+			}},
+			want: "dropped by rule 1 of EgressFirewall ns2/default",
+		},
+		{
+			acl: &nbdb.ACL{Action: nbdb.ACLActionAllowRelated, ExternalIDs: map[string]string{
+				libovsdbops.OwnerTypeKey.String():  string(libovsdbops.NetpolNodeOwnerType),
+				libovsdbops.ObjectNameKey.String(): "node1",
+			}},
+			want: "allowed from the management port of node node1, which is always allowed e.g. for health checks",
+		},
+		{
+			acl:  &nbdb.ACL{UUID: "acl-UUID", Action: nbdb.ACLActionReject, Direction: nbdb.ACLDirectionToLport},
+			want: "rejected by ACL acl-UUID",
+		},
+	}
+	for _, tt := range tests {
+		if got := explainACL(tt.acl).Explanation; got != tt.want {
+			t.Errorf("got explanation %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestExplainHops(t *testing.T) {
+	allow := &PolicyVerdict{Action: "allowed", Explanation: "allowed by egress rule 0 of NetworkPolicy ns1/a"}
+	deny := &PolicyVerdict{Action: "dropped", Explanation: "dropped by the ingress default deny of namespace ns2"}
+	if got := explainHops([]TraceHop{{Stage: "ls_in_port_sec"}}); got != noPolicyExplanation {
+		t.Errorf("got %q without ACL hops", got)
+	}
+	if got := explainHops([]TraceHop{{Policy: allow}, {Stage: "lr_in_ip_routing"}, {Policy: deny}}); got != deny.Explanation {
+		t.Errorf("got %q, want the explanation of the dropping ACL", got)
+	}
+	if got := explainHops([]TraceHop{{Policy: allow}, {Policy: allow}}); got != allow.Explanation+"; "+allow.Explanation {
+		t.Errorf("got %q, want the explanations of the allowing ACLs", got)
+	}
+}
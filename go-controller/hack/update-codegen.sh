@@ -81,3 +81,5 @@ echo "Copying egressIP CRD"
 cp _output/crds/k8s.ovn.org_egressips.yaml ../dist/templates/k8s.ovn.org_egressips.yaml.j2
 echo "Copying egressQoS CRD"
 cp _output/crds/k8s.ovn.org_egressqoses.yaml ../dist/templates/k8s.ovn.org_egressqoses.yaml.j2
+echo "Copying hybridOverlayPeer CRD"
+cp _output/crds/k8s.ovn.org_hybridoverlaypeers.yaml ../dist/templates/k8s.ovn.org_hybridoverlaypeers.yaml.j2
@@ -9,6 +9,8 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	houtil "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	hoopv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/informer"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -27,6 +29,8 @@ type nodeController interface {
 	DeletePod(*kapi.Pod) error
 	AddNode(*kapi.Node) error
 	DeleteNode(*kapi.Node) error
+	AddPeer(*hoopv1.HybridOverlayPeer) error
+	DeletePeer(*hoopv1.HybridOverlayPeer) error
 	RunFlowSync(<-chan struct{})
 	EnsureHybridOverlayBridge(node *kapi.Node) error
 }
@@ -37,6 +41,8 @@ type Node struct {
 	controller       nodeController
 	nodeEventHandler informer.EventHandler
 	podEventHandler  informer.EventHandler
+	// peerEventHandler is nil when the HybridOverlayPeers are not watched
+	peerEventHandler informer.EventHandler
 	sync.Mutex
 }
 
@@ -82,12 +88,21 @@ func podChanged(old, new interface{}) bool {
 	return false
 }
 
-// NewNode Returns a new Node
+// peerChanged returns true if the spec of the peer changed
+func peerChanged(old, new interface{}) bool {
+	oldPeer := old.(*hoopv1.HybridOverlayPeer)
+	newPeer := new.(*hoopv1.HybridOverlayPeer)
+	return !reflect.DeepEqual(oldPeer.Spec, newPeer.Spec)
+}
+
+// NewNode Returns a new Node. peerInformer is optional, the
+// HybridOverlayPeers are not handled without it.
 func NewNode(
 	kube kube.Interface,
 	nodeName string,
 	nodeInformer cache.SharedIndexInformer,
 	podInformer cache.SharedIndexInformer,
+	peerInformer cache.SharedIndexInformer,
 	eventHandlerCreateFunction informer.EventHandlerCreateFunction,
 ) (*Node, error) {
 
@@ -144,6 +159,28 @@ func NewNode(
 	if err != nil {
 		return nil, err
 	}
+	if peerInformer != nil {
+		n.peerEventHandler, err = eventHandlerCreateFunction("hybridoverlaypeer", peerInformer,
+			func(obj interface{}) error {
+				peer, ok := obj.(*hoopv1.HybridOverlayPeer)
+				if !ok {
+					return fmt.Errorf("object is not a hybrid overlay peer")
+				}
+				return n.controller.AddPeer(peer)
+			},
+			func(obj interface{}) error {
+				peer, ok := obj.(*hoopv1.HybridOverlayPeer)
+				if !ok {
+					return fmt.Errorf("object is not a hybrid overlay peer")
+				}
+				return n.controller.DeletePeer(peer)
+			},
+			peerChanged,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return n, nil
 
 }
@@ -173,6 +210,16 @@ func (n *Node) Run(stopCh <-chan struct{}) {
 			klog.Error(err)
 		}
 	}()
+	if n.peerEventHandler != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := n.peerEventHandler.Run(informer.DefaultInformerThreadiness, stopCh)
+			if err != nil {
+				klog.Error(err)
+			}
+		}()
+	}
 
 	wg.Add(1)
 	go func() {
@@ -251,3 +298,48 @@ func getPodDetails(pod *kapi.Pod) ([]*net.IPNet, net.HardwareAddr, error) {
 	}
 	return podInfo.IPs, podInfo.MAC, nil
 }
+
+// getPeerDetails returns the subnets, VTEP, destination MAC and VNI of the
+// peer, or an error if any of them is invalid. The subnets must be within the
+// hybrid overlay cluster subnets, the cluster router only reroutes these to
+// the hybrid overlay.
+func getPeerDetails(peer *hoopv1.HybridOverlayPeer) ([]*net.IPNet, net.IP, net.HardwareAddr, uint32, error) {
+	vtep := net.ParseIP(peer.Spec.VTEP)
+	if vtep == nil || vtep.To4() == nil {
+		return nil, nil, nil, 0, fmt.Errorf("invalid VTEP %q", peer.Spec.VTEP)
+	}
+	mac, err := net.ParseMAC(peer.Spec.MAC)
+	if err != nil {
+		return nil, nil, nil, 0, fmt.Errorf("invalid MAC %q: %v", peer.Spec.MAC, err)
+	}
+	vni := uint32(types.HybridOverlayVNI)
+	if peer.Spec.VNI != nil {
+		vni = *peer.Spec.VNI
+	}
+	if len(peer.Spec.Subnets) == 0 {
+		return nil, nil, nil, 0, fmt.Errorf("no subnets")
+	}
+	subnets := make([]*net.IPNet, 0, len(peer.Spec.Subnets))
+	for _, subnet := range peer.Spec.Subnets {
+		_, cidr, err := net.ParseCIDR(subnet)
+		if err != nil || cidr.IP.To4() == nil {
+			return nil, nil, nil, 0, fmt.Errorf("invalid subnet %q", subnet)
+		}
+		if !isHybridOverlayClusterSubnet(cidr) {
+			return nil, nil, nil, 0, fmt.Errorf("subnet %s is not within the hybrid overlay cluster subnets", subnet)
+		}
+		subnets = append(subnets, cidr)
+	}
+	return subnets, vtep, mac, vni, nil
+}
+
+func isHybridOverlayClusterSubnet(subnet *net.IPNet) bool {
+	ones, _ := subnet.Mask.Size()
+	for _, clusterSubnet := range config.HybridOverlay.ClusterSubnets {
+		clusterOnes, _ := clusterSubnet.CIDR.Mask.Size()
+		if clusterSubnet.CIDR.Contains(subnet.IP) && clusterOnes <= ones {
+			return true
+		}
+	}
+	return false
+}
@@ -13,6 +13,7 @@ import (
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	houtil "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	hoopv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -272,6 +273,92 @@ func (n *NodeController) AddNode(node *kapi.Node) error {
 	return err
 }
 
+// peerToCookie returns the cookie of the flows of a peer, distinct from the
+// cookies of the nodes
+func peerToCookie(peerName string) string {
+	return nameToCookie("hybridoverlaypeer/" + peerName)
+}
+
+// AddPeer handles HybridOverlayPeer additions and updates, setting up the
+// VXLAN tunnel to the subnets of the peer
+func (n *NodeController) AddPeer(peer *hoopv1.HybridOverlayPeer) error {
+	subnets, vtep, mac, vni, err := getPeerDetails(peer)
+	if err != nil {
+		klog.Warningf("Cleaning up hybrid overlay resources for peer %q because: %v", peer.Name, err)
+		return n.DeletePeer(peer)
+	}
+
+	if atomic.LoadUint32(&n.initialized) == 0 {
+		node, err := n.nodeLister.Get(n.nodeName)
+		if err != nil {
+			return fmt.Errorf("hybrid overlay not initialized on %s, and failed to get node data: %v",
+				n.nodeName, err)
+		}
+		if err = n.EnsureHybridOverlayBridge(node); err != nil {
+			return fmt.Errorf("failed to ensure hybrid overlay in peer handler: %v", err)
+		}
+	}
+	if n.drMAC == nil || n.drIP == nil || n.gwLRPIP == nil {
+		return fmt.Errorf("empty values for DR MAC: %s, DR IP: %s or gateway router IP: %s on node %s",
+			n.drMAC, n.drIP, n.gwLRPIP, n.nodeName)
+	}
+
+	klog.Infof("Setting up hybrid overlay tunnel to peer %s", peer.Name)
+
+	cookie := peerToCookie(peer.Name)
+	macRaw := strings.Replace(mac.String(), ":", "", -1)
+
+	var flows []string
+	for _, subnet := range subnets {
+		// ARP responder for any IP address within the subnet of the peer
+		flows = append(flows,
+			fmt.Sprintf("cookie=0x%s,table=0,priority=100,arp,in_port=ext,arp_tpa=%s,"+
+				"actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],"+
+				"mod_dl_src:%s,"+
+				"load:0x2->NXM_OF_ARP_OP[],"+
+				"move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],"+
+				"load:0x%s->NXM_NX_ARP_SHA[],"+
+				"move:NXM_OF_ARP_TPA[]->NXM_NX_REG0[],"+
+				"move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],"+
+				"move:NXM_NX_REG0[]->NXM_OF_ARP_SPA[],"+
+				"IN_PORT",
+				cookie, subnet.String(), mac.String(), macRaw))
+		// Send the traffic of the subnet to the VTEP of the peer, with the
+		// MAC address of its VXLAN device as destination
+		flows = append(flows,
+			fmt.Sprintf("cookie=0x%s,table=0,priority=100,ip,nw_dst=%s,"+
+				"actions=load:%d->NXM_NX_TUN_ID[0..31],"+
+				"set_field:%s->tun_dst,"+
+				"set_field:%s->eth_dst,"+
+				"output:"+extVXLANName,
+				cookie, subnet.String(), vni, vtep.String(), mac.String()))
+		flows = append(flows,
+			fmt.Sprintf("cookie=0x%s,table=0,priority=101,ip,nw_dst=%s,nw_src=%s,"+
+				"actions=load:%d->NXM_NX_TUN_ID[0..31],"+
+				"set_field:%s->nw_src,"+
+				"set_field:%s->tun_dst,"+
+				"set_field:%s->eth_dst,"+
+				"output:"+extVXLANName,
+				cookie, subnet.String(), n.gwLRPIP.String(), vni, n.drIP, vtep.String(), mac.String()))
+	}
+	// Send the incoming traffic of the peer to the pod dispatch table, the
+	// peer does not know the distributed router MAC
+	flows = append(flows,
+		fmt.Sprintf("cookie=0x%s,table=0,priority=100,in_port="+extVXLANName+",tun_src=%s,ip,actions=goto_table:10",
+			cookie, vtep.String()))
+
+	n.updateFlowCacheEntry(cookie, flows, false)
+	n.requestFlowSync()
+	return nil
+}
+
+// DeletePeer handles HybridOverlayPeer deletions
+func (n *NodeController) DeletePeer(peer *hoopv1.HybridOverlayPeer) error {
+	n.deleteFlowsByCookie(peerToCookie(peer.Name))
+	n.requestFlowSync()
+	return nil
+}
+
 func (n *NodeController) deleteFlowsByCookie(cookie string) {
 	n.flowMutex.Lock()
 	defer n.flowMutex.Unlock()
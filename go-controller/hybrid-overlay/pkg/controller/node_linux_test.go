@@ -16,6 +16,9 @@ import (
 
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	hoopv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1"
+	hoopfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1/apis/clientset/versioned/fake"
+	hoopinformers "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1/apis/informers/externalversions"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/informer"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -325,6 +328,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -366,6 +370,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -405,6 +410,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -464,6 +470,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -550,6 +557,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -609,6 +617,119 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 		}
 		appRun(app)
 	})
+	ovntest.OnSupportedPlatformsIt("sets up tunnels for hybrid overlay peers", func() {
+		app.Action = func(ctx *cli.Context) error {
+			const (
+				peerName   string = "flannel-node1"
+				peerSubnet string = "10.0.128.0/24"
+				peerVTEP   string = "192.168.50.10"
+				peerMAC    string = "0a:58:0a:00:80:01"
+			)
+
+			annotations := createNodeAnnotationsForSubnet(thisNodeSubnet)
+			annotations[hotypes.HybridOverlayDRMAC] = thisNodeDRMAC
+			annotations["k8s.ovn.org/node-gateway-router-lrp-ifaddr"] = "{\"ipv4\":\"100.64.0.3/16\"}"
+			annotations[hotypes.HybridOverlayDRIP] = thisNodeDRIP
+			node := createNode(thisNode, "linux", thisNodeIP, annotations)
+			fakeClient := fake.NewSimpleClientset(&v1.NodeList{
+				Items: []v1.Node{
+					*node,
+				},
+			})
+			fakePeerClient := hoopfake.NewSimpleClientset()
+
+			// Node setup from initial node sync
+			addNodeSetupCmds(fexec, thisNode)
+			_, err := config.InitConfig(ctx, fexec, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			f := informers.NewSharedInformerFactory(fakeClient, informer.DefaultResyncInterval)
+			peerFactory := hoopinformers.NewSharedInformerFactory(fakePeerClient, informer.DefaultResyncInterval)
+
+			n, err := NewNode(
+				&kube.Kube{KClient: fakeClient},
+				thisNode,
+				f.Core().V1().Nodes().Informer(),
+				f.Core().V1().Pods().Informer(),
+				peerFactory.K8s().V1().HybridOverlayPeers().Informer(),
+				informer.NewTestEventHandler,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			addEnsureHybridOverlayBridgeMocks(nlMock, thisNodeDRIP, "")
+			// initial flowSync
+			addSyncFlows(fexec)
+			// flowsync after EnsureHybridOverlayBridge()
+			addSyncFlows(fexec)
+
+			f.Start(stopChan)
+			peerFactory.Start(stopChan)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n.Run(stopChan)
+			}()
+
+			linuxNode, okay := n.controller.(*NodeController)
+			Expect(okay).To(BeTrue())
+			Eventually(func() bool {
+				return atomic.LoadUint32(&linuxNode.initialized) == 1
+			}, 2).Should(BeTrue())
+			Eventually(fexec.CalledMatchesExpected, 2).Should(BeTrue(), fexec.ErrorDesc)
+
+			vni := uint32(1)
+			_, err = fakePeerClient.K8sV1().HybridOverlayPeers().Create(context.TODO(), &hoopv1.HybridOverlayPeer{
+				ObjectMeta: metav1.ObjectMeta{Name: peerName},
+				Spec: hoopv1.HybridOverlayPeerSpec{
+					VTEP:    peerVTEP,
+					Subnets: []string{peerSubnet},
+					MAC:     peerMAC,
+					VNI:     &vni,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			// peers with subnets outside of the hybrid overlay cluster subnets are ignored
+			_, err = fakePeerClient.K8sV1().HybridOverlayPeers().Create(context.TODO(), &hoopv1.HybridOverlayPeer{
+				ObjectMeta: metav1.ObjectMeta{Name: "outside"},
+				Spec: hoopv1.HybridOverlayPeerSpec{
+					VTEP:    "192.168.50.11",
+					Subnets: []string{"172.16.0.0/24"},
+					MAC:     peerMAC,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			initialFlowCache := map[string]*flowCacheEntry{
+				"0x0": generateInitialFlowCacheEntry(mgmtIfAddr.IP.String(), thisNodeDRIP, thisNodeDRMAC),
+			}
+			peerCookie := peerToCookie(peerName)
+			initialFlowCache[peerCookie] = &flowCacheEntry{
+				flows: []string{
+					"cookie=0x" + peerCookie + ",table=0,priority=100,arp,in_port=ext,arp_tpa=" + peerSubnet + ",actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],mod_dl_src:" + peerMAC + ",load:0x2->NXM_OF_ARP_OP[],move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],load:0x" + strings.ReplaceAll(peerMAC, ":", "") + "->NXM_NX_ARP_SHA[],move:NXM_OF_ARP_TPA[]->NXM_NX_REG0[],move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],move:NXM_NX_REG0[]->NXM_OF_ARP_SPA[],IN_PORT",
+					"cookie=0x" + peerCookie + ",table=0,priority=100,ip,nw_dst=" + peerSubnet + ",actions=load:1->NXM_NX_TUN_ID[0..31],set_field:" + peerVTEP + "->tun_dst,set_field:" + peerMAC + "->eth_dst,output:ext-vxlan",
+					"cookie=0x" + peerCookie + ",table=0,priority=101,ip,nw_dst=" + peerSubnet + ",nw_src=100.64.0.3,actions=load:1->NXM_NX_TUN_ID[0..31],set_field:" + thisNodeDRIP + "->nw_src,set_field:" + peerVTEP + "->tun_dst,set_field:" + peerMAC + "->eth_dst,output:ext-vxlan",
+					"cookie=0x" + peerCookie + ",table=0,priority=100,in_port=ext-vxlan,tun_src=" + peerVTEP + ",ip,actions=goto_table:10",
+				},
+			}
+			Eventually(func() error {
+				linuxNode.flowMutex.Lock()
+				defer linuxNode.flowMutex.Unlock()
+				return compareFlowCache(linuxNode.flowCache, initialFlowCache)
+			}, 2).Should(BeNil())
+
+			err = fakePeerClient.K8sV1().HybridOverlayPeers().Delete(context.TODO(), peerName, metav1.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			delete(initialFlowCache, peerCookie)
+			Eventually(func() error {
+				linuxNode.flowMutex.Lock()
+				defer linuxNode.flowMutex.Unlock()
+				return compareFlowCache(linuxNode.flowCache, initialFlowCache)
+			}, 2).Should(BeNil())
+			return nil
+		}
+		appRun(app)
+	})
+
 	ovntest.OnSupportedPlatformsIt("node updates itself, windows tunnel and pod flows when distributed router IP is updated", func() {
 		app.Action = func(ctx *cli.Context) error {
 			const (
@@ -647,6 +768,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -784,6 +906,7 @@ var _ = Describe("Hybrid Overlay Node Linux Operations", func() {
 				thisNode,
 				f.Core().V1().Nodes().Informer(),
 				f.Core().V1().Pods().Informer(),
+				nil,
 				informer.NewTestEventHandler,
 			)
 			Expect(err).NotTo(HaveOccurred())
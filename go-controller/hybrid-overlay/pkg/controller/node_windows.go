@@ -8,6 +8,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	houtil "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	hoopv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	kapi "k8s.io/api/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
@@ -386,6 +387,16 @@ func (n *NodeController) DeletePod(pod *kapi.Pod) error {
 	return nil
 }
 
+// AddPeer is not supported on Windows, the peers are only reachable from
+// the Linux nodes
+func (n *NodeController) AddPeer(peer *hoopv1.HybridOverlayPeer) error {
+	return nil
+}
+
+func (n *NodeController) DeletePeer(peer *hoopv1.HybridOverlayPeer) error {
+	return nil
+}
+
 func (n *NodeController) RunFlowSync(stopCh <-chan struct{}) {}
 
 func (n *NodeController) EnsureHybridOverlayBridge(node *kapi.Node) error {
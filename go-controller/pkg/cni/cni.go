@@ -3,6 +3,7 @@ package cni
 import (
 	"fmt"
 	"net"
+	"time"
 
 	kapi "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -128,9 +129,14 @@ func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet, useOVS
 	}
 	// Get the IP address and MAC address of the pod
 	// for DPU, ensure connection-details is present
+	annotationWaitStart := time.Now()
 	pod, annotations, podNADAnnotation, err := GetPodWithAnnotations(pr.ctx, clientset, namespace, podName,
 		pr.nadName, annotCondFn)
+	observePhase(pr.ctx, phaseAnnotationWait, annotationWaitStart)
 	if err != nil {
+		if config.OvnKubeNode.Mode == types.NodeModeDPUHost && pr.CNIConf.DeviceID != "" {
+			err = pr.getDPUNotAckError(clientset, err)
+		}
 		return nil, fmt.Errorf("failed to get pod annotation: %v", err)
 	}
 	if err = pr.checkOrUpdatePodUID(pod); err != nil {
@@ -223,11 +229,39 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	return response, nil
 }
 
-func (pr *PodRequest) cmdCheck() error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
+// cmdCheck validates the pod datapath configured by cmdAdd. Unlike cmdAdd it
+// never waits: CRIO calls CHECK right after ADD while bringing the container
+// up, so the check only inspects the current state and reports what is wrong.
+func (pr *PodRequest) cmdCheck(clientset *ClientSet, useOVSExternalIDs bool) (*Response, error) {
+	namespace := pr.PodNamespace
+	podName := pr.PodName
+	if namespace == "" || podName == "" {
+		return nil, fmt.Errorf("required CNI variable missing")
+	}
+
+	pod, err := clientset.getPod(namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %v", err)
+	}
+	if err = pr.checkOrUpdatePodUID(pod); err != nil {
+		return nil, err
+	}
+	podInterfaceInfo, err := PodAnnotation2PodInfo(pod.Annotations, nil, useOVSExternalIDs, pr.PodUID, "",
+		pr.nadName, pr.netName, pr.CNIConf.MTU)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{}
+	if !config.UnprivilegedMode {
+		if err = pr.CheckInterface(podInterfaceInfo); err != nil {
+			return nil, err
+		}
+	} else {
+		// the shim owns the pod interface, let it do the check
+		response.PodIFInfo = podInterfaceInfo
+	}
+	return response, nil
 }
 
 // HandlePodRequest is the callback for all the requests
@@ -247,7 +281,7 @@ func HandlePodRequest(request *PodRequest, clientset *ClientSet, useOVSExternalI
 	case CNIDel:
 		response, err = request.cmdDel(clientset)
 	case CNICheck:
-		err = request.cmdCheck()
+		response, err = request.cmdCheck(clientset, useOVSExternalIDs)
 	default:
 	}
 
@@ -1,11 +1,16 @@
 package cni
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -38,16 +43,31 @@ func (pr *PodRequest) addDPUConnectionDetailsAnnot(k kube.Interface, podLister c
 	if pr.CNIConf.DeviceID == "" {
 		return fmt.Errorf("DeviceID must be set for Pod request with DPU")
 	}
-	pciAddress := pr.CNIConf.DeviceID
+	deviceID := pr.CNIConf.DeviceID
 
-	// 2. Get the PF index and VF index
-	pfPciAddress, err := util.GetSriovnetOps().GetPfPciFromVfPci(pciAddress)
-	if err != nil {
-		return err
-	}
-	vfindex, err := util.GetSriovnetOps().GetVfIndexByPciAddress(pciAddress)
-	if err != nil {
-		return err
+	// 2. Get the PF PCI address and the VF index, or the SF number for an auxiliary device
+	var pfPciAddress string
+	var vfindex, sfindex int
+	var err error
+	isSF := util.IsAuxDeviceName(deviceID)
+	if isSF {
+		pfPciAddress, err = util.GetSriovnetOps().GetPfPciFromAux(deviceID)
+		if err != nil {
+			return err
+		}
+		sfindex, err = util.GetSriovnetOps().GetSfIndexByAuxDev(deviceID)
+		if err != nil {
+			return err
+		}
+	} else {
+		pfPciAddress, err = util.GetSriovnetOps().GetPfPciFromVfPci(deviceID)
+		if err != nil {
+			return err
+		}
+		vfindex, err = util.GetSriovnetOps().GetVfIndexByPciAddress(deviceID)
+		if err != nil {
+			return err
+		}
 	}
 
 	// 3. Set dpu connection-details pod annotation
@@ -62,10 +82,49 @@ func (pr *PodRequest) addDPUConnectionDetailsAnnot(k kube.Interface, podLister c
 
 	dpuConnDetails := util.DPUConnectionDetails{
 		PfId:         fmt.Sprint(fn),
-		VfId:         fmt.Sprint(vfindex),
 		SandboxId:    pr.SandboxID,
 		VfNetdevName: vfNetdevName,
 	}
+	if isSF {
+		dpuConnDetails.SfId = fmt.Sprint(sfindex)
+	} else {
+		dpuConnDetails.VfId = fmt.Sprint(vfindex)
+	}
 
 	return pr.updatePodDPUConnDetailsWithRetry(k, podLister, &dpuConnDetails)
 }
+
+// getDPUNotAckError returns the error to surface to kubelet when ovnkube-node running on the DPU did not acknowledge
+// the pod while waiting for its connection-status annotation: the failure the DPU reported in the annotation, or why
+// the DPU of the node is not ready
+func (pr *PodRequest) getDPUNotAckError(clientset *ClientSet, waitErr error) error {
+	pod, err := clientset.getPod(pr.PodNamespace, pr.PodName)
+	if err != nil || pod == nil {
+		return waitErr
+	}
+	if _, err := util.UnmarshalPodAnnotation(pod.Annotations, pr.nadName); err != nil {
+		// still waiting for the pod-networks annotation rather than for the DPU
+		return waitErr
+	}
+	status, err := util.UnmarshalPodDPUConnStatus(pod.Annotations, pr.nadName)
+	if err == nil && status.Status == util.DPUConnectionStatusError {
+		return fmt.Errorf("%v: DPU failed to connect pod %s/%s at %s: %s", waitErr, pr.PodNamespace, pr.PodName,
+			status.Timestamp, status.Reason)
+	}
+	notAckErr := fmt.Errorf("%v: DPU did not acknowledge pod %s/%s", waitErr, pr.PodNamespace, pr.PodName)
+	node, err := clientset.kclient.CoreV1().Nodes().Get(context.TODO(), pod.Spec.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return notAckErr
+	}
+	lease, err := clientset.kclient.CoordinationV1().Leases(config.Kubernetes.OVNConfigNamespace).Get(context.TODO(),
+		util.DPUStatusLeaseName(node.Name), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = nil
+	} else if err != nil {
+		return notAckErr
+	}
+	if err := util.CheckNodeDPUReady(node, lease, time.Now()); err != nil {
+		return fmt.Errorf("%v: %v", notAckErr, err)
+	}
+	return notAckErr
+}
@@ -8,13 +8,16 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	kubeMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 var _ = Describe("cni_dpu tests", func() {
@@ -76,6 +79,36 @@ var _ = Describe("cni_dpu tests", func() {
 
 		})
 
+		It("Sets dpu.connection-details pod annotation for a SF", func() {
+			var err error
+			pr.CNIConf.DeviceID = "mlx5_core.sf.7"
+			fakeSriovnetOps.On("GetPfPciFromAux", pr.CNIConf.DeviceID).Return("0000:05:00.1", nil)
+			fakeSriovnetOps.On("GetSfIndexByAuxDev", pr.CNIConf.DeviceID).Return(88, nil)
+			dpuCd := util.DPUConnectionDetails{
+				PfId:      "1",
+				SfId:      "88",
+				SandboxId: pr.SandboxID,
+			}
+			podLister.On("Pods", pr.PodNamespace).Return(&podNamespaceLister)
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+			cpod := pod.DeepCopy()
+			cpod.Annotations, err = util.MarshalPodDPUConnDetails(cpod.Annotations, &dpuCd, ovntypes.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpod.Annotations[util.DPUConnectionDetailsAnnot]).ToNot(ContainSubstring("vfId"))
+			fakeKubeInterface.On("UpdatePod", cpod).Return(nil)
+			err = pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Fails if srionvet fails to get SF index from auxiliary device", func() {
+			pr.CNIConf.DeviceID = "mlx5_core.sf.7"
+			fakeSriovnetOps.On("GetPfPciFromAux", pr.CNIConf.DeviceID).Return("0000:05:00.1", nil)
+			fakeSriovnetOps.On("GetSfIndexByAuxDev", pr.CNIConf.DeviceID).Return(
+				-1, fmt.Errorf("failed to get SF index"))
+			err := pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Fails if DeviceID is not present in CNI config", func() {
 			err := pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
 			Expect(err).To(HaveOccurred())
@@ -128,4 +161,56 @@ var _ = Describe("cni_dpu tests", func() {
 			Expect(err.Error()).To(ContainSubstring("failed to set annotation"))
 		})
 	})
+
+	Context("getDPUNotAckError", func() {
+		var waitErr error
+		var node *v1.Node
+
+		BeforeEach(func() {
+			waitErr = fmt.Errorf("timed out waiting for annotations")
+			pod.Spec.NodeName = "node1"
+			pod.Annotations[util.OvnPodAnnotationName] = `{"default":{"ip_addresses":["10.244.0.5/24"],` +
+				`"mac_address":"0a:58:0a:f4:00:05","gateway_ips":["10.244.0.1"]}}`
+			node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			podLister.On("Pods", pr.PodNamespace).Return(&podNamespaceLister)
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+		})
+
+		It("Returns the wait error while the pod has no pod-networks annotation", func() {
+			delete(pod.Annotations, util.OvnPodAnnotationName)
+			clientset := NewClientSet(fake.NewSimpleClientset(node), &podLister)
+			Expect(pr.getDPUNotAckError(clientset, waitErr)).To(Equal(waitErr))
+		})
+
+		It("Surfaces the error reported by the DPU", func() {
+			var err error
+			pod.Annotations, err = util.MarshalPodDPUConnStatus(pod.Annotations,
+				util.NewDPUConnectionStatus(util.DPUConnectionStatusError, "representor not found"),
+				ovntypes.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			clientset := NewClientSet(fake.NewSimpleClientset(node), &podLister)
+			err = pr.getDPUNotAckError(clientset, waitErr)
+			Expect(err.Error()).To(ContainSubstring("timed out waiting for annotations"))
+			Expect(err.Error()).To(ContainSubstring("representor not found"))
+		})
+
+		It("Surfaces why the DPU of the node is not ready", func() {
+			node.Status.Conditions = []v1.NodeCondition{{
+				Type:   util.DPUReadyCondition,
+				Status: v1.ConditionTrue,
+			}}
+			renewTime := metav1.NewMicroTime(time.Now().Add(-10 * util.DPUStatusHeartbeatInterval))
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      util.DPUStatusLeaseName(node.Name),
+					Namespace: config.Kubernetes.OVNConfigNamespace,
+				},
+				Spec: coordinationv1.LeaseSpec{RenewTime: &renewTime},
+			}
+			clientset := NewClientSet(fake.NewSimpleClientset(node, lease), &podLister)
+			err := pr.getDPUNotAckError(clientset, waitErr)
+			Expect(err.Error()).To(ContainSubstring("DPU did not acknowledge pod foo-ns/bar-pod"))
+			Expect(err.Error()).To(ContainSubstring(util.DPUReadyReasonHeartbeatMissed))
+		})
+	})
 })
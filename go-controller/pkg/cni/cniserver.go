@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	kapi "k8s.io/api/core/v1"
+	kapitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -48,7 +51,8 @@ import (
 // started.
 
 // NewCNIServer creates and returns a new Server object which will listen on a socket in the given path
-func NewCNIServer(useOVSExternalIDs bool, factory factory.NodeWatchFactory, kclient kubernetes.Interface) (*Server, error) {
+func NewCNIServer(useOVSExternalIDs bool, factory factory.NodeWatchFactory, kclient kubernetes.Interface,
+	recorder record.EventRecorder) (*Server, error) {
 	if config.OvnKubeNode.Mode == types.NodeModeDPU {
 		return nil, fmt.Errorf("unsupported ovnkube-node mode for CNI server: %s", config.OvnKubeNode.Mode)
 	}
@@ -76,6 +80,7 @@ func NewCNIServer(useOVSExternalIDs bool, factory factory.NodeWatchFactory, kcli
 			KubeAPITokenFile: config.Kubernetes.TokenFile,
 		},
 		handlePodRequestFunc: HandlePodRequest,
+		recorder:             recorder,
 	}
 
 	if len(config.Kubernetes.CAData) > 0 {
@@ -186,7 +191,9 @@ func cniRequestToPodRequest(cr *Request) (*PodRequest, error) {
 	req.CNIConf = conf
 	req.timestamp = time.Now()
 	// Match the Kubelet default CRI operation timeout of 2m
-	req.ctx, req.cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, trace := withRequestTrace(context.Background())
+	req.ctx, req.cancel = context.WithTimeout(ctx, 2*time.Minute)
+	req.trace = trace
 	return req, nil
 }
 
@@ -198,6 +205,13 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	if err := json.Unmarshal(b, &cr); err != nil {
 		return nil, err
 	}
+	// STATUS and GC are not about a single pod
+	switch command(cr.Env["CNI_COMMAND"]) {
+	case CNIStatus:
+		return nil, s.status()
+	case CNIGC:
+		return nil, s.gc(&cr)
+	}
 	req, err := cniRequestToPodRequest(&cr)
 	if err != nil {
 		return nil, err
@@ -209,6 +223,9 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 		useOVSExternalIDs = true
 	}
 	result, err := s.handlePodRequestFunc(req, s.clientSet, useOVSExternalIDs, s.kubeAuth)
+	if req.Command == CNIAdd {
+		s.reportSlowRequest(req, err)
+	}
 	if err != nil {
 		// Prefix error with request information for easier debugging
 		return nil, fmt.Errorf("%s %v", req, err)
@@ -216,6 +233,58 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	return result, nil
 }
 
+// reportSlowRequest adds an event with the per-phase durations to the pod of a
+// request that took longer than slowRequestThreshold
+func (s *Server) reportSlowRequest(req *PodRequest, err error) {
+	elapsed := time.Since(req.timestamp)
+	if s.recorder == nil || elapsed < slowRequestThreshold {
+		return
+	}
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed"
+	}
+	podRef := &kapi.ObjectReference{
+		Kind:      "Pod",
+		Namespace: req.PodNamespace,
+		Name:      req.PodName,
+		UID:       kapitypes.UID(req.PodUID),
+	}
+	s.recorder.Eventf(podRef, kapi.EventTypeWarning, "SlowNetworkSetup",
+		"Network setup for NAD %s %s after %v: %s", req.nadName, outcome, elapsed.Round(time.Millisecond), req.trace)
+}
+
+// status reports whether pods can be set up, for the CNI STATUS verb. The
+// server only listens once ovnkube-node finished its startup, so what is left
+// to check is the OVN port binding support pod setup waits on.
+func (s *Server) status() error {
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost || config.OvnKubeNode.DisableOVNIfaceIdVer {
+		return nil
+	}
+	if atomic.LoadInt32(&s.useOVSExternalIDs) == 0 {
+		return fmt.Errorf("OVN port binding support is not enabled yet")
+	}
+	return nil
+}
+
+// gc removes the pod interfaces of the network in the request whose sandbox
+// the container runtime no longer knows about, for the CNI GC verb.
+func (s *Server) gc(cr *Request) error {
+	conf, err := config.ReadCNIConfig(cr.Config)
+	if err != nil {
+		return fmt.Errorf("broken stdin args")
+	}
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// pod OVS ports live on the DPU
+		return nil
+	}
+	nadName := types.DefaultNetworkName
+	if conf.Name != types.DefaultNetworkName {
+		nadName = conf.NADName
+	}
+	return gcPodInterfaces(conf.Name, nadName, conf.ValidAttachments)
+}
+
 func (s *Server) handleCNIMetrics(w http.ResponseWriter, r *http.Request) {
 	var cm CNIRequestMetrics
 
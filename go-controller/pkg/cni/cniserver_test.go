@@ -21,6 +21,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	utiltesting "k8s.io/client-go/util/testing"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -61,15 +62,19 @@ func makeCNIArgs(namespace, name string) string {
 }
 
 const (
-	sandboxID    string = "adsfadsfasfdasdfasf"
-	namespace    string = "awesome-namespace"
-	name         string = "awesome-name"
-	cniConfig    string = "{\"cniVersion\": \"0.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
-	cniConfig_40 string = "{\"cniVersion\": \"0.4.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
-	nodeName     string = "mynode"
+	sandboxID     string = "adsfadsfasfdasdfasf"
+	namespace     string = "awesome-namespace"
+	name          string = "awesome-name"
+	cniConfig     string = "{\"cniVersion\": \"0.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
+	cniConfig_40  string = "{\"cniVersion\": \"0.4.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
+	cniConfig_110 string = "{\"cniVersion\": \"1.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"}"
+	nodeName      string = "mynode"
 )
 
 func TestCNIServer(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatalf("failed to prepare test config: %v", err)
+	}
 	tmpDir, err := utiltesting.MkTmpdir("cniserver")
 	if err != nil {
 		t.Fatalf("failed to create temp directory: %v", err)
@@ -89,7 +94,7 @@ func TestCNIServer(t *testing.T) {
 		t.Fatalf("failed to start watch factory: %v", err)
 	}
 
-	s, err := NewCNIServer(false, wf, fakeClient)
+	s, err := NewCNIServer(false, wf, fakeClient, nil)
 	if err != nil {
 		t.Fatalf("error creating CNI server: %v", err)
 	}
@@ -181,6 +186,18 @@ func TestCNIServer(t *testing.T) {
 			},
 			result: nil,
 		},
+		// STATUS request before OVN port binding support is enabled
+		{
+			name: "STATUS",
+			request: &Request{
+				Env: map[string]string{
+					"CNI_COMMAND": string(CNIStatus),
+				},
+				Config: []byte(cniConfig_110),
+			},
+			result:      nil,
+			errorPrefix: "OVN port binding support is not enabled yet",
+		},
 		// Missing CNI_ARGS
 		{
 			name: "ARGS1",
@@ -249,4 +266,13 @@ func TestCNIServer(t *testing.T) {
 			}
 		}
 	}
+
+	s.EnableOVNPortUpSupport()
+	statusRequest := &Request{
+		Env:    map[string]string{"CNI_COMMAND": string(CNIStatus)},
+		Config: []byte(cniConfig_110),
+	}
+	if body, code := clientDoCNI(t, client, statusRequest); code != http.StatusOK {
+		t.Fatalf("[STATUS] expected status %v but got %v: %s", http.StatusOK, code, string(body))
+	}
 }
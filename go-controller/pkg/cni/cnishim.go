@@ -21,6 +21,7 @@ import (
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,13 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// SupportedVersions are the CNI spec versions the plugin supports
+var SupportedVersions = version.PluginSupports(append(version.All.SupportedVersions(), config.CNISpecVersion110)...)
+
+// errPluginNotAvailable is the CNI 1.1 error code for a plugin that cannot
+// handle ADD requests yet
+const errPluginNotAvailable uint = 50
+
 // Plugin is the structure to hold the endpoint information and the corresponding
 // functions to use it
 type Plugin struct {
@@ -250,7 +258,17 @@ func (p *Plugin) CmdAdd(args *skel.CmdArgs) error {
 		}
 	}
 
-	return types.PrintResult(result, conf.CNIVersion)
+	return printResult(result, conf.CNIVersion)
+}
+
+// printResult prints the result in the given CNI spec version
+func printResult(result *current.Result, cniVersion string) error {
+	if cniVersion == config.CNISpecVersion110 {
+		// a 1.1.0 result is a 1.0.0 result the vendored CNI library doesn't know
+		result.CNIVersion = cniVersion
+		return result.Print()
+	}
+	return types.PrintResult(result, cniVersion)
 }
 
 // CmdDel is the callback for 'teardown' cni calls from skel
@@ -313,8 +331,98 @@ func (p *Plugin) CmdDel(args *skel.CmdArgs) error {
 
 // CmdCheck is the callback for 'checking' container's networking is as expected.
 func (p *Plugin) CmdCheck(args *skel.CmdArgs) error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
+	var err error
+	var body []byte
+	var pr *PodRequest
+	var conf *ovntypes.NetConf
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNICheck, err)
+		if err != nil {
+			klog.Errorf(err.Error())
+		}
+	}()
+
+	// read the config stdin args
+	conf, err = config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	req := newCNIRequest(args)
+	body, err = p.doCNI("http://dummy/", req)
+	if err != nil {
+		err = types.NewError(types.ErrInternal, "pod datapath check failed", err.Error())
+		return err
+	}
+
+	response := &Response{}
+	if err = json.Unmarshal(body, response); err != nil {
+		err = fmt.Errorf("cmdCheck: failed to unmarshal response '%s': %v", string(body), err)
+		return err
+	}
+
+	// if PodIFInfo is set, then ovnkube-node is running in unprivileged mode so check the Interface from here.
+	if response.PodIFInfo != nil {
+		pr, err = cniRequestToPodRequest(req)
+		if err != nil {
+			err = fmt.Errorf("failed to create pod request: %v", err)
+			return err
+		}
+		defer pr.cancel()
+
+		if !response.PodIFInfo.IsDPUHostMode {
+			// Initialize OVS exec runner; find OVS binaries that the CNI code uses.
+			if err = SetExec(kexec.New()); err != nil {
+				err = fmt.Errorf("failed to initialize OVS exec runner: %v", err)
+				return err
+			}
+		}
+
+		if err = pr.CheckInterface(response.PodIFInfo); err != nil {
+			err = types.NewError(types.ErrInternal, "pod datapath check failed", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// CmdStatus is the callback for the CNI 1.1 'status' calls, reporting whether
+// ovnkube-node is ready to set up pods
+func (p *Plugin) CmdStatus(args *skel.CmdArgs) error {
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return types.NewError(types.ErrDecodingFailure, "invalid stdin args", err.Error())
+	}
+	setupLogging(conf)
+
+	if _, err = p.doCNI("http://dummy/", newCNIRequest(args)); err != nil {
+		return types.NewError(errPluginNotAvailable, "ovnkube-node is not ready", err.Error())
+	}
 	return nil
 }
+
+// CmdGC is the callback for the CNI 1.1 'garbage collection' calls, removing
+// the pod interfaces of sandboxes the container runtime no longer knows about
+func (p *Plugin) CmdGC(args *skel.CmdArgs) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNIGC, err)
+		if err != nil {
+			klog.Errorf(err.Error())
+		}
+	}()
+
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	_, err = p.doCNI("http://dummy/", newCNIRequest(args))
+	return err
+}
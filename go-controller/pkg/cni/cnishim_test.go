@@ -0,0 +1,72 @@
+//go:build linux
+// +build linux
+
+package cni
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	utiltesting "k8s.io/client-go/util/testing"
+)
+
+func TestCmdCheckFailureMetrics(t *testing.T) {
+	tmpDir, err := utiltesting.MkTmpdir("cnishim")
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	socketPath := filepath.Join(tmpDir, "cni-server.sock")
+
+	var lock sync.Mutex
+	var metrics []CNIRequestMetrics
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		m := CNIRequestMetrics{}
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			t.Errorf("failed to decode CNI request metrics: %v", err)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		metrics = append(metrics, m)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "pod interface not found", http.StatusBadRequest)
+	})
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socketPath, err)
+	}
+	server := &http.Server{Handler: mux}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	p := NewCNIPlugin(socketPath)
+	err = p.CmdCheck(&skel.CmdArgs{
+		ContainerID: sandboxID,
+		Netns:       "/path/to/something",
+		IfName:      "eth0",
+		Args:        makeCNIArgs(namespace, name),
+		StdinData:   []byte(cniConfig_40),
+	})
+	if err == nil {
+		t.Fatalf("expected CNI CHECK to fail")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(metrics) != 1 {
+		t.Fatalf("expected a single CNI request metric, got %v", metrics)
+	}
+	if metrics[0].Command != CNICheck || !metrics[0].HasErr {
+		t.Fatalf("expected a failed CNI CHECK metric, got %+v", metrics[0])
+	}
+}
@@ -14,8 +14,11 @@ import (
 	"strings"
 	"time"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	cnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -407,7 +410,10 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		ovsArgs = append(ovsArgs, []string{"--", "--if-exists", "remove", "interface", hostIfaceName, "external_ids", types.NADExternalID}...)
 	}
 
-	if out, err := ovsExec(ovsArgs...); err != nil {
+	ovsPortAddStart := time.Now()
+	out, err := ovsExec(ovsArgs...)
+	observePhase(ctx, phaseOVSPortAdd, ovsPortAddStart)
+	if err != nil {
 		return fmt.Errorf("failure in plugging pod interface: %v\n  %q", err, out)
 	}
 
@@ -437,6 +443,7 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		klog.Warningf("[%s/%s %s] pod uid %s: %v", namespace, podName, sandboxID, initialPodUID, err)
 		return err
 	}
+	observePodNetworkReady(ctx, hostIfaceName, ifaceID, ifInfo.PodUID)
 	return nil
 }
 
@@ -451,6 +458,7 @@ func (pr *PodRequest) ConfigureInterface(getter PodInfoGetter, ifInfo *PodInterf
 	var hostIface, contIface *current.Interface
 
 	klog.V(5).Infof("CNI Conf %v", pr.CNIConf)
+	interfaceSetupStart := time.Now()
 	if pr.CNIConf.DeviceID != "" {
 		// SR-IOV Case
 		hostIface, contIface, err = setupSriovInterface(netns, pr.SandboxID, pr.IfName, ifInfo, pr.CNIConf.DeviceID)
@@ -462,6 +470,7 @@ func (pr *PodRequest) ConfigureInterface(getter PodInfoGetter, ifInfo *PodInterf
 		// General case
 		hostIface, contIface, err = setupInterface(netns, pr.SandboxID, pr.IfName, ifInfo)
 	}
+	observePhase(pr.ctx, phaseInterfaceSetup, interfaceSetupStart)
 	if err != nil {
 		return nil, err
 	}
@@ -596,6 +605,119 @@ func (pr *PodRequest) UnconfigureInterface(ifInfo *PodInterfaceInfo) error {
 	return nil
 }
 
+// CheckInterface verifies the pod datapath set up by ConfigureInterface: the
+// container interface with its addresses, routes and MTU, the host side OVS
+// interface and the OpenFlow flows of the pod. All detected problems are
+// returned together.
+func (pr *PodRequest) CheckInterface(ifInfo *PodInterfaceInfo) error {
+	podDesc := fmt.Sprintf("for pod %s/%s NAD %s", pr.PodNamespace, pr.PodName, pr.nadName)
+	netns, err := ns.GetNS(pr.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q %s: %v", pr.Netns, podDesc, err)
+	}
+	defer netns.Close()
+
+	var errs []error
+	ifnameSuffix := ""
+	isSecondary := pr.netName != types.DefaultNetworkName
+	err = netns.Do(func(_ ns.NetNS) error {
+		link, err := util.GetNetLinkOps().LinkByName(pr.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to get container interface %s %s: %v", pr.IfName, podDesc, err)
+		}
+		errs = append(errs, checkPodLink(link, ifInfo)...)
+		if isSecondary {
+			ifnameSuffix = fmt.Sprintf("_%d", link.Attrs().Index)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ifInfo.IsDPUHostMode {
+		ifaceID := util.GetIfaceId(pr.PodNamespace, pr.PodName)
+		if isSecondary {
+			ifaceID = util.GetSecondaryNetworkIfaceId(pr.PodNamespace, pr.PodName, pr.nadName)
+		}
+		hostIfaceName := pr.SandboxID[:(15-len(ifnameSuffix))] + ifnameSuffix
+		errs = append(errs, checkPodOVSInterface(hostIfaceName, ifaceID, pr.SandboxID, ifInfo)...)
+	}
+
+	if err = utilerrors.NewAggregate(errs); err != nil {
+		return fmt.Errorf("pod datapath check failed %s: %v", podDesc, err)
+	}
+	return nil
+}
+
+// checkPodLink verifies that the container interface is up and has the MAC,
+// MTU, addresses and routes of the pod interface info.
+func checkPodLink(link netlink.Link, ifInfo *PodInterfaceInfo) []error {
+	var errs []error
+	attrs := link.Attrs()
+	if attrs.Flags&net.FlagUp == 0 {
+		errs = append(errs, fmt.Errorf("interface %s is down", attrs.Name))
+	}
+	if ifInfo.MAC != nil && attrs.HardwareAddr.String() != ifInfo.MAC.String() {
+		errs = append(errs, fmt.Errorf("interface %s has MAC %s, expected %s", attrs.Name, attrs.HardwareAddr, ifInfo.MAC))
+	}
+	if ifInfo.MTU > 0 && attrs.MTU != ifInfo.MTU {
+		errs = append(errs, fmt.Errorf("interface %s has MTU %d, expected %d", attrs.Name, attrs.MTU, ifInfo.MTU))
+	}
+
+	addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return append(errs, fmt.Errorf("failed to list addresses of interface %s: %v", attrs.Name, err))
+	}
+	for _, ip := range ifInfo.IPs {
+		found := false
+		for _, addr := range addrs {
+			if addr.IPNet != nil && addr.IPNet.String() == ip.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("interface %s is missing address %s", attrs.Name, ip))
+		}
+	}
+
+	routes, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return append(errs, fmt.Errorf("failed to list routes of interface %s: %v", attrs.Name, err))
+	}
+	hasRoute := func(dst *net.IPNet, gw net.IP) bool {
+		for _, route := range routes {
+			if !route.Gw.Equal(gw) {
+				continue
+			}
+			if dst == nil {
+				// default route
+				if route.Dst == nil {
+					return true
+				}
+				if ones, _ := route.Dst.Mask.Size(); ones == 0 {
+					return true
+				}
+			} else if route.Dst != nil && route.Dst.String() == dst.String() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, gw := range ifInfo.Gateways {
+		if !hasRoute(nil, gw) {
+			errs = append(errs, fmt.Errorf("interface %s is missing default route via %s", attrs.Name, gw))
+		}
+	}
+	for _, route := range ifInfo.Routes {
+		if !hasRoute(route.Dest, route.NextHop) {
+			errs = append(errs, fmt.Errorf("interface %s is missing route %s via %s", attrs.Name, route.Dest, route.NextHop))
+		}
+	}
+	return errs
+}
+
 func (pr *PodRequest) deletePodConntrack() {
 	if pr.CNIConf.PrevResult == nil {
 		return
@@ -638,3 +760,47 @@ func (pr *PodRequest) deletePorts(ifaceName, podNamespace, podName string) {
 		}
 	}
 }
+
+// gcPodInterfaces removes the OVS ports, host interfaces and conntrack
+// entries of the pod interfaces of the given network whose sandbox is not in
+// the valid attachments. The conntrack entries of an address are kept if the
+// interface of a valid attachment has it.
+func gcPodInterfaces(netName, nadName string, validAttachments []cnitypes.GCAttachment) error {
+	validSandboxes := sets.New[string]()
+	for _, attachment := range validAttachments {
+		validSandboxes.Insert(attachment.ContainerID)
+	}
+	stale, err := findStalePodInterfaces(netName, nadName, validSandboxes)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, iface := range stale {
+		klog.Infof("GC: removing stale OVS interface %s of sandbox %s on network %s", iface.name, iface.sandboxID, netName)
+		out, err := ovsExec("--if-exists", "del-port", "br-int", iface.name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete OVS port %s: %v\n  %q", iface.name, err, out))
+			continue
+		}
+		// representors are not ours to delete
+		if !iface.isRepresentor {
+			if err = util.LinkDelete(iface.name); err != nil {
+				klog.Warningf("GC: failed to delete interface %s: %v", iface.name, err)
+			}
+		}
+		if err = clearPodBandwidth(iface.sandboxID); err != nil {
+			klog.Warningf("GC: failed to clear bandwidth of sandbox %s: %v", iface.sandboxID, err)
+		}
+		for _, ipStr := range iface.ipAddresses {
+			ip, _, err := net.ParseCIDR(ipStr)
+			if err != nil {
+				continue
+			}
+			if err = util.DeleteConntrack(ip.String(), 0, "", netlink.ConntrackReplyAnyIP, nil); err != nil {
+				klog.Warningf("GC: failed to delete conntrack entries for %s: %v", ip, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
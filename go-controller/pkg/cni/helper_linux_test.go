@@ -1244,3 +1244,87 @@ func TestPodRequest_deletePodConntrack(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPodLink(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+
+	ifInfo := &PodInterfaceInfo{
+		PodAnnotation: util.PodAnnotation{
+			IPs:      ovntest.MustParseIPNets("192.168.0.5/24"),
+			MAC:      ovntest.MustParseMAC("0a:58:c0:a8:00:05"),
+			Gateways: ovntest.MustParseIPs("192.168.0.1"),
+			Routes: []util.PodRoute{
+				{
+					Dest:    ovntest.MustParseIPNet("10.96.0.0/16"),
+					NextHop: ovntest.MustParseIP("192.168.0.1"),
+				},
+			},
+		},
+		MTU: 1400,
+	}
+	goodAddrs := []netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.0.5/24")}}
+	goodRoutes := []netlink.Route{
+		{Gw: ovntest.MustParseIP("192.168.0.1")},
+		{Dst: ovntest.MustParseIPNet("10.96.0.0/16"), Gw: ovntest.MustParseIP("192.168.0.1")},
+	}
+
+	tests := []struct {
+		desc                 string
+		inpLinkAttrs         netlink.LinkAttrs
+		errMatch             []string
+		netLinkOpsMockHelper []ovntest.TestifyMockHelper
+	}{
+		{
+			desc: "test code path when the link matches the pod interface info",
+			inpLinkAttrs: netlink.LinkAttrs{Name: "eth0", Flags: net.FlagUp, MTU: 1400,
+				HardwareAddr: ovntest.MustParseMAC("0a:58:c0:a8:00:05")},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{goodAddrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{goodRoutes, nil}},
+			},
+		},
+		{
+			desc: "test code path when the link is down with a wrong MTU and MAC",
+			inpLinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: 1500,
+				HardwareAddr: ovntest.MustParseMAC("0a:58:c0:a8:00:06")},
+			errMatch: []string{"is down", "has MAC", "has MTU"},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{goodAddrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{goodRoutes, nil}},
+			},
+		},
+		{
+			desc: "test code path when the address and routes are missing",
+			inpLinkAttrs: netlink.LinkAttrs{Name: "eth0", Flags: net.FlagUp, MTU: 1400,
+				HardwareAddr: ovntest.MustParseMAC("0a:58:c0:a8:00:05")},
+			errMatch: []string{"missing address", "missing default route", "missing route"},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Route{}, nil}},
+			},
+		},
+		{
+			desc: "test code path when AddrList returns error",
+			inpLinkAttrs: netlink.LinkAttrs{Name: "eth0", Flags: net.FlagUp, MTU: 1400,
+				HardwareAddr: ovntest.MustParseMAC("0a:58:c0:a8:00:05")},
+			errMatch: []string{"failed to list addresses"},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkOpsMockHelper)
+
+			errs := checkPodLink(&netlink.Dummy{LinkAttrs: tc.inpLinkAttrs}, ifInfo)
+			assert.Len(t, errs, len(tc.errMatch))
+			for j, err := range errs {
+				assert.Contains(t, err.Error(), tc.errMatch[j])
+			}
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	kexec "k8s.io/utils/exec"
 	utilnet "k8s.io/utils/net"
@@ -220,6 +222,125 @@ func doPodFlowsExist(mac string, ifAddrs []*net.IPNet, ofPort int) bool {
 	return true
 }
 
+// checkPodOVSInterface verifies that the OVS interface of a pod sandbox still
+// belongs to the sandbox and pod, that it has a valid OpenFlow port and that
+// OVN installed the flows for the pod. It does not wait for any of them.
+func checkPodOVSInterface(ifaceName, ifaceID, sandboxID string, ifInfo *PodInterfaceInfo) []error {
+	columns := []string{
+		"ofport",
+		"external-ids:iface-id",
+		"external-ids:iface-id-ver",
+		"external-ids:attached_mac",
+		"external-ids:sandbox",
+		"external-ids:ovn-installed",
+	}
+	output, err := ovsGetMultiOutput("Interface", ifaceName, columns)
+	if err != nil {
+		return []error{fmt.Errorf("failed to get OVS interface %s: %v", ifaceName, err)}
+	}
+	if len(output) != len(columns) || output[0] == "" {
+		return []error{fmt.Errorf("OVS interface %s not found", ifaceName)}
+	}
+
+	var errs []error
+	mac := ifInfo.MAC.String()
+	if output[1] != ifaceID {
+		errs = append(errs, fmt.Errorf("OVS interface %s has iface-id %q, expected %q", ifaceName, output[1], ifaceID))
+	}
+	if ifInfo.PodUID != "" && output[2] != ifInfo.PodUID {
+		errs = append(errs, fmt.Errorf("OVS interface %s has iface-id-ver %q, expected %q", ifaceName, output[2], ifInfo.PodUID))
+	}
+	if !strings.EqualFold(output[3], mac) {
+		errs = append(errs, fmt.Errorf("OVS interface %s has attached_mac %q, expected %q", ifaceName, output[3], mac))
+	}
+	if output[4] != sandboxID {
+		errs = append(errs, fmt.Errorf("OVS interface %s has sandbox %q, expected %q", ifaceName, output[4], sandboxID))
+	}
+
+	ofPort, err := strconv.Atoi(output[0])
+	if err != nil || ofPort <= 0 {
+		// without an OpenFlow port there is no point in looking for flows
+		return append(errs, fmt.Errorf("OVS interface %s has invalid OpenFlow port %q", ifaceName, output[0]))
+	}
+
+	if ifInfo.CheckExtIDs {
+		if output[5] != "true" {
+			errs = append(errs, fmt.Errorf("OVS interface %s does not have ovn-installed=true", ifaceName))
+		}
+	} else if !doPodFlowsExist(mac, ifInfo.IPs, ofPort) {
+		errs = append(errs, fmt.Errorf("OpenFlow flows for OVS interface %s (port %d) %s %v are missing",
+			ifaceName, ofPort, mac, ifInfo.IPs))
+	}
+	return errs
+}
+
+// stalePodInterface is an OVS pod interface whose sandbox is no longer known
+// to the container runtime
+type stalePodInterface struct {
+	name      string
+	sandboxID string
+	// ipAddresses are the addresses of the interface that no interface of a
+	// valid sandbox has been given since
+	ipAddresses   []string
+	isRepresentor bool
+}
+
+// findStalePodInterfaces returns the OVS pod interfaces of the given network
+// whose sandbox is not one of the valid sandboxes
+func findStalePodInterfaces(netName, nadName string, validSandboxes sets.Set[string]) ([]stalePodInterface, error) {
+	condition := fmt.Sprintf("external_ids:%s{=}[]", types.NADExternalID)
+	if netName != types.DefaultNetworkName {
+		condition = fmt.Sprintf("external_ids:%s=%s", types.NADExternalID, nadName)
+	}
+	names, err := ovsFind("Interface", "name", condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVS interfaces of network %s: %v", netName, err)
+	}
+
+	var stale []stalePodInterface
+	inUseIPs := sets.New[string]()
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		output, err := ovsGetMultiOutput("Interface", name,
+			[]string{"external-ids:sandbox", "external-ids:ip_addresses", "external-ids:vf-netdev-name"})
+		if err != nil || len(output) != 3 {
+			klog.Warningf("Failed to get external-ids of OVS interface %s: %v", name, err)
+			continue
+		}
+		// only pod interfaces have a sandbox
+		if output[0] == "" {
+			continue
+		}
+		var ipAddresses []string
+		if output[1] != "" {
+			ipAddresses = strings.Split(output[1], ",")
+		}
+		if validSandboxes.Has(output[0]) {
+			inUseIPs.Insert(ipAddresses...)
+			continue
+		}
+		stale = append(stale, stalePodInterface{
+			name:          name,
+			sandboxID:     output[0],
+			ipAddresses:   ipAddresses,
+			isRepresentor: output[2] != "",
+		})
+	}
+	// the addresses of stale interfaces may have been given to new pods
+	for i := range stale {
+		var ipAddresses []string
+		for _, ipAddress := range stale[i].ipAddresses {
+			if !inUseIPs.Has(ipAddress) {
+				ipAddresses = append(ipAddresses, ipAddress)
+			}
+		}
+		stale[i].ipAddresses = ipAddresses
+	}
+	return stale, nil
+}
+
 // checkCancelSandbox checks that this sandbox is still valid for the current
 // instance of the pod in the apiserver. Sandbox requests and pod instances
 // have a 1:1 relationship determined by pod UID. If we detect that the pod
@@ -270,9 +391,13 @@ func waitForPodInterface(ctx context.Context, ifInfo *PodInterfaceInfo,
 	mac := ifInfo.MAC.String()
 	ifAddrs := ifInfo.IPs
 	checkExternalIDs := ifInfo.CheckExtIDs
+	phase := phaseFlowWait
 	if checkExternalIDs {
 		detail = " (ovn-installed)"
-	} else {
+		phase = phaseOVNPortUp
+	}
+	defer observePhase(ctx, phase, time.Now())
+	if !checkExternalIDs {
 		ofPort, err = getIfaceOFPort(ifaceName)
 		if err != nil {
 			return err
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -87,4 +89,107 @@ d9af11aa-37c3-4ea9-8ba3-a74843cc0f47
 		Expect(err).NotTo(HaveOccurred())
 		Expect(ifaceID).To(Equal(`1234`))
 	})
+
+	Context("checkPodOVSInterface", func() {
+		const getCmd = "ovs-vsctl --timeout=30 --if-exists get Interface 1234567890abcde ofport " +
+			"external-ids:iface-id external-ids:iface-id-ver external-ids:attached_mac " +
+			"external-ids:sandbox external-ids:ovn-installed"
+
+		var ifInfo *PodInterfaceInfo
+
+		BeforeEach(func() {
+			ifInfo = &PodInterfaceInfo{
+				PodAnnotation: util.PodAnnotation{
+					IPs: ovntest.MustParseIPNets("10.0.0.5/24"),
+					MAC: ovntest.MustParseMAC("0a:58:0a:00:00:05"),
+				},
+				CheckExtIDs: true,
+				PodUID:      "pod-uid",
+			}
+		})
+
+		It("succeeds when the OVS interface matches the pod", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    getCmd,
+				Output: "5\nns_pod\npod-uid\n\"0a:58:0a:00:00:05\"\n1234567890abcdef\n\"true\"\n",
+			})
+
+			errs := checkPodOVSInterface("1234567890abcde", "ns_pod", "1234567890abcdef", ifInfo)
+			Expect(errs).To(BeEmpty())
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		})
+
+		It("reports a missing OVS interface", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    getCmd,
+				Output: "",
+			})
+
+			errs := checkPodOVSInterface("1234567890abcde", "ns_pod", "1234567890abcdef", ifInfo)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("not found"))
+		})
+
+		It("reports stale external-ids and an invalid ofport", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    getCmd,
+				Output: "-1\nns_other\npod-uid\n\"0a:58:0a:00:00:05\"\n1234567890abcdef\n\"true\"\n",
+			})
+
+			errs := checkPodOVSInterface("1234567890abcde", "ns_pod", "1234567890abcdef", ifInfo)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Error()).To(ContainSubstring("iface-id"))
+			Expect(errs[1].Error()).To(ContainSubstring("invalid OpenFlow port"))
+		})
+
+		It("reports an interface without ovn-installed", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    getCmd,
+				Output: "5\nns_pod\npod-uid\n\"0a:58:0a:00:00:05\"\n1234567890abcdef\n\n",
+			})
+
+			errs := checkPodOVSInterface("1234567890abcde", "ns_pod", "1234567890abcdef", ifInfo)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("ovn-installed"))
+		})
+	})
+
+	It("finds the pod interfaces of sandboxes that are not valid anymore and the addresses they don't share with valid ones", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --no-heading --format=csv --data=bare --columns=name find Interface external_ids:k8s.ovn.org/nad{=}[]",
+			Output: "ovn-k8s-mp0\nvalidsandbox123\nstalesandbox123\nreusedsandbox12\n",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface ovn-k8s-mp0 external-ids:sandbox external-ids:ip_addresses external-ids:vf-netdev-name",
+			Output: "\n\n\n",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface validsandbox123 external-ids:sandbox external-ids:ip_addresses external-ids:vf-netdev-name",
+			Output: "validsandbox1234567\n\"10.0.0.4/24\"\n\n",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface stalesandbox123 external-ids:sandbox external-ids:ip_addresses external-ids:vf-netdev-name",
+			Output: "stalesandbox1234567\n\"10.0.0.5/24,fd00::5/64\"\n\n",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface reusedsandbox12 external-ids:sandbox external-ids:ip_addresses external-ids:vf-netdev-name",
+			Output: "reusedsandbox1234567\n\"10.0.0.4/24\"\n\n",
+		})
+
+		stale, err := findStalePodInterfaces("default", "default", sets.New("validsandbox1234567"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stale).To(Equal([]stalePodInterface{
+			{
+				name:        "stalesandbox123",
+				sandboxID:   "stalesandbox1234567",
+				ipAddresses: []string{"10.0.0.5/24", "fd00::5/64"},
+			},
+			// the address is the one of the interface of a valid sandbox now
+			{
+				name:      "reusedsandbox12",
+				sandboxID: "reusedsandbox1234567",
+			},
+		}))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
 })
@@ -0,0 +1,218 @@
+package cni
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// Phases of a CNI ADD request that are timed separately
+const (
+	phaseAnnotationWait = "annotation-wait"
+	phaseInterfaceSetup = "interface-setup"
+	phaseOVSPortAdd     = "ovs-port-add"
+	phaseFlowWait       = "flow-wait"
+	phaseOVNPortUp      = "ovn-port-up"
+)
+
+// Milestones the pod network ready duration is measured from
+const (
+	readyPhaseCNIAdd     = "cni-add"
+	readyPhaseOVSPortAdd = "ovs-port-add"
+	readyPhaseLSPCreate  = "lsp-create"
+)
+
+// slowRequestThreshold is the duration after which a CNI ADD request is
+// reported in a pod event with the duration of each of its phases
+const slowRequestThreshold = 10 * time.Second
+
+type phaseDuration struct {
+	phase    string
+	duration time.Duration
+}
+
+// requestTrace collects the phase durations of a single CNI request. It is
+// carried in the request context so that helpers shared with the DPU code
+// path don't need to know about it.
+type requestTrace struct {
+	sync.Mutex
+	phases []phaseDuration
+	// start is when the request arrived
+	start time.Time
+	// ovsPortAdded is when the OVS port of the pod was added
+	ovsPortAdded time.Time
+}
+
+type requestTraceKey struct{}
+
+// withRequestTrace returns a context carrying a new request trace
+func withRequestTrace(ctx context.Context) (context.Context, *requestTrace) {
+	trace := &requestTrace{start: time.Now()}
+	return context.WithValue(ctx, requestTraceKey{}, trace), trace
+}
+
+// observePhase records the duration of a phase started at start in the phase
+// histogram and, if the context carries one, in the request trace
+func observePhase(ctx context.Context, phase string, start time.Time) {
+	duration := time.Since(start)
+	metrics.MetricCNIRequestPhaseDuration.WithLabelValues(phase).Observe(duration.Seconds())
+	if ctx == nil {
+		return
+	}
+	if trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace); ok {
+		trace.Lock()
+		defer trace.Unlock()
+		trace.phases = append(trace.phases, phaseDuration{phase: phase, duration: duration})
+		if phase == phaseOVSPortAdd {
+			trace.ovsPortAdded = start.Add(duration)
+		}
+	}
+}
+
+// podNetworkReadyTimeout bounds how long the OVS port of a pod is watched for
+// its first packet
+const podNetworkReadyTimeout = 2 * time.Minute
+
+// podNetworkReadyInterval is how often the packet counters of the OVS port of
+// a pod are read while waiting for its first packet
+const podNetworkReadyInterval = 100 * time.Millisecond
+
+// maxPodNetworkReadyWatchers bounds the number of OVS ports watched for their
+// first packet at once; pods added while the bound is reached aren't measured
+const maxPodNetworkReadyWatchers = 16
+
+var podNetworkReadyWatchers = make(chan struct{}, maxPodNetworkReadyWatchers)
+
+// txPacketsRegex matches the transmitted packets counter of ovs-ofctl dump-ports
+var txPacketsRegex = regexp.MustCompile(`tx pkts=(\d+)`)
+
+// observePodNetworkReady records the duration for the network of a pod to be
+// ready, that is for a first packet to be sent to the pod through its OVS
+// port, from the CNI ADD request and the OVS port added if the context carries
+// a request trace, and from the creation of its logical switch port by the
+// master. The creation time is found in the port binding of the pod UID. The
+// port is watched in the background not to delay the request.
+func observePodNetworkReady(ctx context.Context, hostIfaceName, ifaceID, podUID string) {
+	if !config.Metrics.EnablePodNetworkReady {
+		return
+	}
+	var start, ovsPortAdded time.Time
+	if trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace); ok {
+		trace.Lock()
+		start, ovsPortAdded = trace.start, trace.ovsPortAdded
+		trace.Unlock()
+	}
+	select {
+	case podNetworkReadyWatchers <- struct{}{}:
+	default:
+		klog.V(5).Infof("Already watching %d OVS ports, not measuring the network ready duration of %s",
+			maxPodNetworkReadyWatchers, ifaceID)
+		return
+	}
+	go func() {
+		defer func() { <-podNetworkReadyWatchers }()
+		ready, err := waitForFirstPacket(hostIfaceName)
+		if err != nil {
+			klog.V(5).Infof("Failed to measure the network ready duration of %s: %v", ifaceID, err)
+			return
+		}
+		if !start.IsZero() {
+			metrics.ObservePodNetworkReady(readyPhaseCNIAdd, ready.Sub(start))
+		}
+		if !ovsPortAdded.IsZero() {
+			metrics.ObservePodNetworkReady(readyPhaseOVSPortAdd, ready.Sub(ovsPortAdded))
+		}
+		if podUID == "" {
+			return
+		}
+		stdout, stderr, err := util.RunOVNSbctl("--data=bare", "--no-heading", "--columns=external_ids",
+			"find", "Port_Binding", "logical_port="+ifaceID, "options:iface-id-ver="+podUID)
+		if err != nil {
+			klog.Warningf("Failed to get the port binding of %s for pod UID %s, stderr: %q, error: %v",
+				ifaceID, podUID, stderr, err)
+			return
+		}
+		created, ok := getLSPCreatedTime(stdout)
+		if !ok {
+			klog.V(5).Infof("Port binding of %s for pod UID %s has no creation time", ifaceID, podUID)
+			return
+		}
+		metrics.ObservePodNetworkReady(readyPhaseLSPCreate, ready.Sub(created))
+	}()
+}
+
+// waitForFirstPacket returns when OVS first sent a packet to the OVS interface,
+// from the packet counters of its OpenFlow port
+func waitForFirstPacket(ifaceName string) (time.Time, error) {
+	ofPort, err := getIfaceOFPort(ifaceName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var ready time.Time
+	err = wait.PollImmediate(podNetworkReadyInterval, podNetworkReadyTimeout, func() (bool, error) {
+		out, err := ofctlExec("dump-ports", "br-int", strconv.Itoa(ofPort))
+		if err != nil {
+			// the port is gone with the pod
+			return false, err
+		}
+		if txPackets, ok := getTxPackets(out); !ok || txPackets == 0 {
+			return false, nil
+		}
+		ready = time.Now()
+		return true, nil
+	})
+	return ready, err
+}
+
+// getTxPackets returns the number of packets transmitted by a port from the
+// output of ovs-ofctl dump-ports
+func getTxPackets(dumpPorts string) (uint64, bool) {
+	subMatches := txPacketsRegex.FindStringSubmatch(dumpPorts)
+	if len(subMatches) != 2 {
+		return 0, false
+	}
+	txPackets, err := strconv.ParseUint(subMatches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return txPackets, true
+}
+
+// getLSPCreatedTime returns the creation time of a logical switch port from
+// the bare external_ids of its port binding
+func getLSPCreatedTime(externalIDs string) (time.Time, bool) {
+	for _, field := range strings.Fields(externalIDs) {
+		key, value, found := strings.Cut(field, "=")
+		if !found || key != types.LSPCreatedExternalID {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return created, true
+	}
+	return time.Time{}, false
+}
+
+func (t *requestTrace) String() string {
+	t.Lock()
+	defer t.Unlock()
+	phases := make([]string, 0, len(t.phases))
+	for _, p := range t.phases {
+		phases = append(phases, fmt.Sprintf("%s %v", p.phase, p.duration.Round(time.Millisecond)))
+	}
+	return strings.Join(phases, ", ")
+}
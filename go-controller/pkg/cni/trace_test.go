@@ -0,0 +1,46 @@
+package cni
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTrace(t *testing.T) {
+	ctx, trace := withRequestTrace(context.Background())
+	observePhase(ctx, phaseAnnotationWait, time.Now().Add(-1500*time.Millisecond))
+	observePhase(ctx, phaseOVNPortUp, time.Now().Add(-20*time.Millisecond))
+	// contexts without a trace only feed the metrics
+	observePhase(context.TODO(), phaseOVSPortAdd, time.Now())
+
+	assert.Len(t, trace.phases, 2)
+	assert.Equal(t, phaseAnnotationWait, trace.phases[0].phase)
+	assert.GreaterOrEqual(t, trace.phases[0].duration, 1500*time.Millisecond)
+	assert.Equal(t, phaseOVNPortUp, trace.phases[1].phase)
+	assert.Regexp(t, `^annotation-wait 1\.5\d*s, ovn-port-up \d+ms$`, trace.String())
+}
+
+func TestGetLSPCreatedTime(t *testing.T) {
+	created, ok := getLSPCreatedTime("k8s.ovn.org/lsp-created=2023-05-02T10:11:12.345678Z namespace=ns1 pod=true")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 5, 2, 10, 11, 12, 345678000, time.UTC), created)
+
+	_, ok = getLSPCreatedTime("namespace=ns1 pod=true")
+	assert.False(t, ok)
+	_, ok = getLSPCreatedTime("k8s.ovn.org/lsp-created=yesterday")
+	assert.False(t, ok)
+}
+
+func TestGetTxPackets(t *testing.T) {
+	txPackets, ok := getTxPackets(`OFPST_PORT reply (xid=0x2): 1 ports
+  port  5: rx pkts=12, bytes=1016, drop=0, errs=0, frame=0, over=0, crc=0
+           tx pkts=8, bytes=648, drop=0, errs=0, coll=0
+`)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8), txPackets)
+
+	_, ok = getTxPackets("OFPST_PORT reply (xid=0x2): 0 ports")
+	assert.False(t, ok)
+}
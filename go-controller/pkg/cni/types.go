@@ -9,6 +9,7 @@ import (
 	current "github.com/containernetworking/cni/pkg/types/100"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
@@ -73,6 +74,12 @@ const CNIDel command = "DEL"
 // CNICheck is the command representing check operation on a pod
 const CNICheck command = "CHECK"
 
+// CNIStatus is the command representing a plugin readiness query
+const CNIStatus command = "STATUS"
+
+// CNIGC is the command representing garbage collection of stale pod attachments
+const CNIGC command = "GC"
+
 // Request sent to the Server by the OVN CNI plugin
 type Request struct {
 	// CNI environment variables, like CNI_COMMAND and CNI_NETNS
@@ -149,6 +156,8 @@ type PodRequest struct {
 	ctx context.Context
 	// cancel should be called to cancel this request
 	cancel context.CancelFunc
+	// trace collects the durations of the request phases
+	trace *requestTrace
 
 	// network name, for default network, this will be types.DefaultNetworkName
 	netName string
@@ -186,4 +195,5 @@ type Server struct {
 	useOVSExternalIDs    int32
 	clientSet            *ClientSet
 	kubeAuth             *KubeAPIAuth
+	recorder             record.EventRecorder
 }
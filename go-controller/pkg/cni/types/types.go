@@ -42,6 +42,16 @@ type NetConf struct {
 	// LogFileMaxAge represents the maximum number
 	// of days to retain old log files
 	LogFileMaxAge int `json:"logfile-maxage"`
+
+	// ValidAttachments lists the attachments the container runtime still
+	// knows about, passed with the CNI 1.1 GC verb
+	ValidAttachments []GCAttachment `json:"cni.dev/valid-attachments,omitempty"`
+}
+
+// GCAttachment identifies an attachment of a container to this network
+type GCAttachment struct {
+	ContainerID string `json:"containerID"`
+	IfName      string `json:"ifname"`
 }
 
 // NetworkSelectionElement represents one element of the JSON format
@@ -18,13 +18,22 @@ import (
 
 var ErrorAttachDefNotOvnManaged = errors.New("net-attach-def not managed by OVN")
 
+// CNISpecVersion110 is the CNI spec version that introduced the STATUS and GC
+// verbs. The vendored CNI library predates it; as the 1.1.0 result format is
+// the 1.0.0 one, results of that version are handled as 1.0.0 results.
+const CNISpecVersion110 = "1.1.0"
+
 // WriteCNIConfig writes a CNI JSON config file to directory given by global config
 // if the file doesn't already exist, or is different than the content that would
 // be written.
 func WriteCNIConfig() error {
+	cniVersion := "0.4.0"
+	if CNI.EnableStatusGC {
+		cniVersion = CNISpecVersion110
+	}
 	netConf := &ovncnitypes.NetConf{
 		NetConf: types.NetConf{
-			CNIVersion: "0.4.0",
+			CNIVersion: cniVersion,
 			Name:       "ovn-kubernetes",
 			Type:       CNI.Plugin,
 		},
@@ -104,7 +113,21 @@ func ReadCNIConfig(bytes []byte) (*ovncnitypes.NetConf, error) {
 		return nil, err
 	}
 	if conf.RawPrevResult != nil {
-		if err := version.ParsePrevResult(&conf.NetConf); err != nil {
+		if conf.CNIVersion == CNISpecVersion110 {
+			// parse a copy of the raw previous result, which may be shared
+			netConf := conf.NetConf
+			netConf.CNIVersion = "1.0.0"
+			netConf.RawPrevResult = make(map[string]interface{}, len(conf.RawPrevResult))
+			for k, v := range conf.RawPrevResult {
+				netConf.RawPrevResult[k] = v
+			}
+			netConf.RawPrevResult["cniVersion"] = netConf.CNIVersion
+			if err := version.ParsePrevResult(&netConf); err != nil {
+				return nil, err
+			}
+			conf.RawPrevResult = nil
+			conf.PrevResult = netConf.PrevResult
+		} else if err := version.ParsePrevResult(&conf.NetConf); err != nil {
 			return nil, err
 		}
 	}
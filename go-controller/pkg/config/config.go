@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/url"
 	"os"
@@ -145,11 +146,30 @@ var (
 		ElectionRetryPeriod:   20,
 	}
 
+	// OvnDBBackup holds the OVN database backup config options.
+	OvnDBBackup = OvnDBBackupConfig{
+		Interval:  60,
+		Retention: 24,
+	}
+
+	// NBRebuild holds the NB database rebuild config options.
+	NBRebuild NBRebuildConfig
+
+	// NBAudit holds the NB and SB database audit config options.
+	NBAudit = NBAuditConfig{
+		Interval: 30,
+	}
+
 	// HybridOverlay holds hybrid overlay feature config options.
 	HybridOverlay = HybridOverlayConfig{
 		VXLANPort: DefaultVXLANPort,
 	}
 
+	// BGP holds the BGP advertisement config options.
+	BGP = BGPConfig{
+		FRRConfigFile: "/etc/frr/frr.conf",
+	}
+
 	// UnprivilegedMode allows ovnkube-node to run without SYS_ADMIN capability, by performing interface setup in the CNI plugin
 	UnprivilegedMode bool
 
@@ -164,7 +184,9 @@ var (
 
 	// OvnKubeNode holds ovnkube-node parsed config file parameters and command-line overrides
 	OvnKubeNode = OvnKubeNodeConfig{
-		Mode: types.NodeModeFull,
+		Mode:         types.NodeModeFull,
+		IPsecCertDir: "/var/lib/ovn-kubernetes/ipsec",
+		IPsecCACert:  "/signer-ca/ca-bundle.crt",
 	}
 )
 
@@ -195,6 +217,10 @@ type DefaultConfig struct {
 	// The UDP Port of the encapsulation endpoint. If not specified, the IP default port
 	// of 6081 will be used
 	EncapPort uint `gcfg:"encap-port"`
+	// NoOverlay routes the pod traffic between nodes through the node network
+	// instead of the encapsulation tunnels, which requires the node network to
+	// route the pod subnets of the nodes
+	NoOverlay bool `gcfg:"no-overlay"`
 	// Maximum number of milliseconds of idle time on connection that
 	// ovn-controller waits before it will send a connection health probe.
 	InactivityProbe int `gcfg:"inactivity-probe"`
@@ -252,6 +278,15 @@ type LoggingConfig struct {
 	LogFileMaxAge int `gcfg:"logfile-maxage"`
 	// Logging rate-limiting meter
 	ACLLoggingRateLimit int `gcfg:"acl-logging-rate-limit"`
+	// ACLLogCollectorSource is the path of the ovn-controller log file the
+	// node tails for ACL log messages, empty disables the collector
+	ACLLogCollectorSource string `gcfg:"acl-log-collector-source"`
+	// ACLLogCollectorFile is the path of a file the collected ACL log
+	// messages are written to as JSON lines
+	ACLLogCollectorFile string `gcfg:"acl-log-collector-file"`
+	// ACLLogCollectorSyslog is the syslog server the collected ACL log
+	// messages are sent to, as <network>:<address>
+	ACLLogCollectorSyslog string `gcfg:"acl-log-collector-syslog"`
 }
 
 // MonitoringConfig holds monitoring-related parsed config file parameters and command-line overrides
@@ -268,6 +303,12 @@ type MonitoringConfig struct {
 	SFlowTargets []HostPort
 	// IPFIXTargets holds the parsed IPFIX targets and may be used outside the config module.
 	IPFIXTargets []HostPort
+	// EnableACLSampling sets the label of NetworkPolicy, namespace default deny and EgressFirewall ACLs
+	// to an observation point ID, so that the connections they allow can be attributed to them.
+	EnableACLSampling bool `gcfg:"enable-acl-sampling"`
+	// FlowExportAddress is the loopback address of the node API that exports the connections attributed
+	// to ACLs and the ACL drops collected from the ovn-controller log. Empty disables it.
+	FlowExportAddress string `gcfg:"flow-export-address"`
 }
 
 // IPFIXConfig holds IPFIX-related performance configuration options. It requires that the ipfix-targets
@@ -293,6 +334,9 @@ type CNIConfig struct {
 	ConfDir string `gcfg:"conf-dir"`
 	// Plugin specifies the name of the CNI plugin
 	Plugin string `gcfg:"plugin"`
+	// EnableStatusGC writes the CNI config with the CNI 1.1 spec version so
+	// that container runtimes use the STATUS and GC verbs
+	EnableStatusGC bool `gcfg:"enable-status-gc"`
 }
 
 // KubernetesConfig holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -335,6 +379,12 @@ type MetricsConfig struct {
 	// configuration duration and optionally, its application to all nodes
 	EnableConfigDuration bool `gcfg:"enable-config-duration"`
 	EnableScaleMetrics   bool `gcfg:"enable-scale-metrics"`
+	// EnableTrafficMetrics holds the boolean flag to enable the per pod and namespace traffic metrics of
+	// OVN-Kubernetes node, and the EgressIP traffic metrics of egress nodes
+	EnableTrafficMetrics bool `gcfg:"enable-traffic-metrics"`
+	// EnablePodNetworkReady holds the boolean flag to enable OVN-Kubernetes master to record the creation time of
+	// the logical switch ports of pods, and OVN-Kubernetes node to measure the time it takes pod networks to be ready
+	EnablePodNetworkReady bool `gcfg:"enable-pod-network-ready"`
 }
 
 // OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
@@ -347,7 +397,10 @@ type OVNKubernetesFeatureConfig struct {
 	EnableEgressQoS                 bool `gcfg:"enable-egress-qos"`
 	EgressIPNodeHealthCheckPort     int  `gcfg:"egressip-node-healthcheck-port"`
 	EnableMultiNetwork              bool `gcfg:"enable-multi-network"`
+	EnableMultiNetworkServices      bool `gcfg:"enable-multi-network-services"`
 	EnableStatelessNetPol           bool `gcfg:"enable-stateless-netpol"`
+	// EnableIPsec makes OVN-Kubernetes encrypt the Geneve traffic between nodes with IPsec
+	EnableIPsec bool `gcfg:"enable-ipsec"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -394,6 +447,40 @@ type GatewayConfig struct {
 	SingleNode bool `gcfg:"single-node"`
 	// DisableForwarding (enabled by default) controls if forwarding is allowed on OVNK controlled interfaces
 	DisableForwarding bool `gcfg:"disable-forwarding"`
+	// RawBridges holds the unparsed additional gateway bridges.
+	// Should only be used inside config module.
+	RawBridges string `gcfg:"bridges"`
+	// RawBridgeRoutes holds the unparsed routes of the additional gateway
+	// bridges. Should only be used inside config module.
+	RawBridgeRoutes string `gcfg:"bridge-routes"`
+	// Bridges holds the parsed additional gateway bridges, with their routes,
+	// and may be used outside the config module.
+	Bridges []GatewayBridge
+	// MgmtPortOVNRouting routes the traffic of the host to the pods through
+	// the management port with the management port IP as source, instead of
+	// masquerading it to the management port IP with iptables. The traffic
+	// that the host forwards is not masqueraded. Shared gateway mode only.
+	MgmtPortOVNRouting bool `gcfg:"mgmt-port-ovn-routing"`
+}
+
+// GatewayBridge is an additional named gateway bridge, connecting the gateway
+// router to another physical network than the one of the gateway interface
+type GatewayBridge struct {
+	// Name identifies the bridge in the bridge routes
+	Name string
+	// Interface is the network interface, or the OVS bridge, of the bridge
+	Interface string
+	// VLANID is the optional VLAN tag of the traffic of the bridge
+	VLANID uint
+	// Routes are the destinations reached through the bridge
+	Routes []GatewayBridgeRoute
+}
+
+// GatewayBridgeRoute is a destination reached through an additional gateway
+// bridge
+type GatewayBridgeRoute struct {
+	Destination *net.IPNet
+	NextHop     net.IP
 }
 
 // OvnAuthConfig holds client authentication and location details for
@@ -420,6 +507,45 @@ type HAConfig struct {
 	ElectionRetryPeriod   int `gcfg:"election-retry-period"`
 }
 
+// OvnDBBackupConfig holds the configuration of the OVN NB/SB database
+// backups taken by ovndbchecker
+type OvnDBBackupConfig struct {
+	// Dir is the directory the backups are written to, typically a mounted
+	// persistent volume. Backups are disabled if empty.
+	Dir string `gcfg:"dir"`
+	// Interval is the time between two backups of a database, in minutes
+	Interval uint `gcfg:"interval"`
+	// Retention is the number of backups kept for each database
+	Retention uint `gcfg:"retention"`
+}
+
+// NBRebuildConfig holds the configuration of the rebuild of the NB database
+// from the Kubernetes objects, to validate disaster recovery
+type NBRebuildConfig struct {
+	// Enabled requires the NB database to be empty on startup and, once all
+	// the objects are synced, compares it with the snapshot
+	Enabled bool `gcfg:"enabled"`
+	// SnapshotAddress is the address of the ovsdb-server serving the NB
+	// database snapshot the rebuilt database is compared with
+	SnapshotAddress string `gcfg:"snapshot-address"`
+	// Report is the file the comparison is written to, as JSON. The
+	// comparison is only logged if empty.
+	Report string `gcfg:"report"`
+
+	// SnapshotAuth is the connection configuration built from SnapshotAddress
+	SnapshotAuth OvnAuthConfig
+}
+
+// NBAuditConfig holds the configuration of the periodic audit of the NB and
+// SB database objects owned by ovnkube
+type NBAuditConfig struct {
+	// Interval is the number of minutes between audits, 0 disables them
+	Interval uint `gcfg:"interval"`
+	// DeleteOrphans deletes the objects whose Kubernetes owner no longer
+	// exists instead of only reporting them
+	DeleteOrphans bool `gcfg:"delete-orphans"`
+}
+
 // HybridOverlayConfig holds configuration for hybrid overlay
 // configuration.
 type HybridOverlayConfig struct {
@@ -435,6 +561,29 @@ type HybridOverlayConfig struct {
 	VXLANPort uint `gcfg:"hybrid-overlay-vxlan-port"`
 }
 
+// BGPConfig holds the configuration of the BGP advertisement of the host
+// subnet, egress IPs and load balancer VIPs of the nodes
+type BGPConfig struct {
+	// Enabled indicates whether the BGP advertisement is enabled or not.
+	Enabled bool `gcfg:"enabled"`
+	// ASN is the autonomous system number of the nodes.
+	ASN uint `gcfg:"asn"`
+	// RawPeers holds the unparsed BGP peers.
+	// Should only be used inside config module.
+	RawPeers string `gcfg:"peers"`
+	// Peers holds the parsed BGP peers and may be used outside the config
+	// module.
+	Peers []BGPPeer
+	// FRRConfigFile is the FRR configuration file generated by ovnkube-node.
+	FRRConfigFile string `gcfg:"frr-config-file"`
+}
+
+// BGPPeer is a BGP peer of the nodes
+type BGPPeer struct {
+	Address net.IP
+	ASN     uint32
+}
+
 // OvnKubeNodeConfig holds ovnkube-node configurations
 type OvnKubeNodeConfig struct {
 	Mode                   string `gcfg:"mode"`
@@ -443,6 +592,27 @@ type OvnKubeNodeConfig struct {
 	MgmtPortDPResourceName string `gcfg:"mgmt-port-dp-resource-name"`
 	MgmtPortRepresentor    string
 	DisableOVNIfaceIdVer   bool `gcfg:"disable-ovn-iface-id-ver"`
+	// IPsecCertDir is the directory of the IPsec private key and certificates of the node
+	IPsecCertDir string `gcfg:"ipsec-cert-dir"`
+	// IPsecSignerName is the signer of the certificate signing requests of the IPsec certificates,
+	// required when IPsec is enabled
+	IPsecSignerName string `gcfg:"ipsec-signer-name"`
+	// IPsecCACert is the CA certificate bundle of the IPsec certificate signer
+	IPsecCACert string `gcfg:"ipsec-ca-cert"`
+	// RawNodeIPCIDRs holds the unparsed CIDRs the host addresses of the node
+	// must be in to be used as encap IP and published as host addresses.
+	// Should only be used inside config module.
+	RawNodeIPCIDRs string `gcfg:"node-ip-cidrs"`
+	// NodeIPCIDRs holds the parsed CIDRs of RawNodeIPCIDRs and may be used
+	// outside the config module.
+	NodeIPCIDRs []*net.IPNet
+	// NodeIPInterface is the interface the host addresses of the node must be
+	// on to be used as encap IP and published as host addresses
+	NodeIPInterface string `gcfg:"node-ip-interface"`
+	// NodeIPChangeDelay is the time in seconds a change of the host addresses
+	// of the node must last before the encap IP and the published host
+	// addresses follow it
+	NodeIPChangeDelay int `gcfg:"node-ip-change-delay"`
 }
 
 // OvnDBScheme describes the OVN database connection transport method
@@ -473,7 +643,11 @@ type config struct {
 	MasterHA             HAConfig
 	ClusterMgrHA         HAConfig
 	HybridOverlay        HybridOverlayConfig
+	BGP                  BGPConfig
 	OvnKubeNode          OvnKubeNodeConfig
+	OvnDBBackup          OvnDBBackupConfig
+	NBRebuild            NBRebuildConfig
+	NBAudit              NBAuditConfig
 }
 
 var (
@@ -491,7 +665,11 @@ var (
 	savedMasterHA             HAConfig
 	savedClusterMgrHA         HAConfig
 	savedHybridOverlay        HybridOverlayConfig
+	savedBGP                  BGPConfig
 	savedOvnKubeNode          OvnKubeNodeConfig
+	savedOvnDBBackup          OvnDBBackupConfig
+	savedNBRebuild            NBRebuildConfig
+	savedNBAudit              NBAuditConfig
 	// legacy service-cluster-ip-range CLI option
 	serviceClusterIPRange string
 	// legacy cluster-subnet CLI option
@@ -517,7 +695,11 @@ func init() {
 	savedGateway = Gateway
 	savedMasterHA = MasterHA
 	savedHybridOverlay = HybridOverlay
+	savedBGP = BGP
 	savedOvnKubeNode = OvnKubeNode
+	savedOvnDBBackup = OvnDBBackup
+	savedNBRebuild = NBRebuild
+	savedNBAudit = NBAudit
 	cli.VersionPrinter = func(c *cli.Context) {
 		fmt.Printf("Version: %s\n", Version)
 		fmt.Printf("Git commit: %s\n", Commit)
@@ -546,7 +728,11 @@ func PrepareTestConfig() error {
 	Gateway = savedGateway
 	MasterHA = savedMasterHA
 	HybridOverlay = savedHybridOverlay
+	BGP = savedBGP
 	OvnKubeNode = savedOvnKubeNode
+	OvnDBBackup = savedOvnDBBackup
+	NBRebuild = savedNBRebuild
+	NBAudit = savedNBAudit
 
 	if err := completeConfig(); err != nil {
 		return err
@@ -686,6 +872,12 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Default.EncapPort,
 		Value:       Default.EncapPort,
 	},
+	&cli.BoolFlag{
+		Name: "no-overlay",
+		Usage: "Route the pod traffic between nodes through the node network instead of " +
+			"the encapsulation tunnels, which keep carrying the NodePort and egress IP traffic",
+		Destination: &cliConfig.Default.NoOverlay,
+	},
 	&cli.IntFlag{
 		Name: "inactivity-probe",
 		Usage: "Maximum number of milliseconds of idle time on " +
@@ -812,6 +1004,24 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Logging.ACLLoggingRateLimit,
 		Value:       20,
 	},
+	&cli.StringFlag{
+		Name: "acl-log-collector-source",
+		Usage: "path of the ovn-controller log file ovnkube-node tails to collect the ACL log messages, enriched with " +
+			"the namespaces, network policies and pods they are about, e.g. /var/log/ovn/ovn-controller.log. " +
+			"Empty disables the collector.",
+		Destination: &cliConfig.Logging.ACLLogCollectorSource,
+	},
+	&cli.StringFlag{
+		Name:        "acl-log-collector-file",
+		Usage:       "path of a file the collected ACL log messages are written to as JSON lines",
+		Destination: &cliConfig.Logging.ACLLogCollectorFile,
+	},
+	&cli.StringFlag{
+		Name: "acl-log-collector-syslog",
+		Usage: "syslog server the collected ACL log messages are sent to as JSON, as <network>:<address>, " +
+			"e.g. udp:10.0.0.1:514 or unixgram:/dev/log",
+		Destination: &cliConfig.Logging.ACLLogCollectorSyslog,
+	},
 }
 
 // MonitoringFlags capture monitoring-related options
@@ -838,6 +1048,18 @@ var MonitoringFlags = []cli.Flag{
 			"Each entry is given in the form [IP address:port] or [:port]. If only port is provided, it uses the Node IP",
 		Destination: &cliConfig.Monitoring.RawIPFIXTargets,
 	},
+	&cli.BoolFlag{
+		Name: "enable-acl-sampling",
+		Usage: "Set the label of NetworkPolicy and EgressFirewall ACLs to an observation point ID, " +
+			"to attribute the connections they allow to them",
+		Destination: &cliConfig.Monitoring.EnableACLSampling,
+	},
+	&cli.StringFlag{
+		Name: "flow-export-address",
+		Usage: "The loopback address (eg, \"127.0.0.1:9108\") of the node API exporting the connections and drops " +
+			"attributed to NetworkPolicies and EgressFirewalls. Empty disables it",
+		Destination: &cliConfig.Monitoring.FlowExportAddress,
+	},
 }
 
 // IPFIXFlags capture IPFIX-related options
@@ -861,6 +1083,69 @@ var IPFIXFlags = []cli.Flag{
 	},
 }
 
+// OvnDBBackupFlags capture the OVN database backup options
+var OvnDBBackupFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "db-backup-dir",
+		Usage:       "Directory, typically a persistent volume, to write periodic standalone backups of the OVN NB and SB databases to. Backups are disabled if not set.",
+		Destination: &cliConfig.OvnDBBackup.Dir,
+		Value:       OvnDBBackup.Dir,
+	},
+	&cli.UintFlag{
+		Name:        "db-backup-interval",
+		Usage:       "Time in minutes between two backups of an OVN database (default: 60)",
+		Destination: &cliConfig.OvnDBBackup.Interval,
+		Value:       OvnDBBackup.Interval,
+	},
+	&cli.UintFlag{
+		Name:        "db-backup-retention",
+		Usage:       "Number of backups to keep for each OVN database (default: 24)",
+		Destination: &cliConfig.OvnDBBackup.Retention,
+		Value:       OvnDBBackup.Retention,
+	},
+}
+
+// NBRebuildFlags capture the NB database rebuild options
+var NBRebuildFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name: "nb-rebuild",
+		Usage: "Rebuild the NB database from the Kubernetes objects: require it to be empty on startup and, " +
+			"once all the objects are synced, report how it differs from the snapshot at --nb-rebuild-snapshot-address",
+		Destination: &cliConfig.NBRebuild.Enabled,
+		Value:       NBRebuild.Enabled,
+	},
+	&cli.StringFlag{
+		Name: "nb-rebuild-snapshot-address",
+		Usage: "Address of the ovsdb-server serving the NB database snapshot (e.g. a backup) to compare the rebuilt " +
+			"NB database with (eg, unix:/var/run/ovn/ovnnb_snapshot.sock)",
+		Destination: &cliConfig.NBRebuild.SnapshotAddress,
+		Value:       NBRebuild.SnapshotAddress,
+	},
+	&cli.StringFlag{
+		Name:        "nb-rebuild-report",
+		Usage:       "File to write the comparison of the rebuilt NB database with the snapshot to, as JSON. Only logged if not set.",
+		Destination: &cliConfig.NBRebuild.Report,
+		Value:       NBRebuild.Report,
+	},
+}
+
+// NBAuditFlags capture the NB and SB database audit options
+var NBAuditFlags = []cli.Flag{
+	&cli.UintFlag{
+		Name: "nb-audit-interval",
+		Usage: "Minutes between audits of the NB and SB database objects owned by ovnkube for objects whose " +
+			"Kubernetes owner no longer exists (default: 30). 0 disables the audit.",
+		Destination: &cliConfig.NBAudit.Interval,
+		Value:       NBAudit.Interval,
+	},
+	&cli.BoolFlag{
+		Name:        "nb-audit-delete-orphans",
+		Usage:       "Delete the orphaned NB and SB database objects found by the audit instead of only reporting them",
+		Destination: &cliConfig.NBAudit.DeleteOrphans,
+		Value:       NBAudit.DeleteOrphans,
+	},
+}
+
 // CNIFlags capture CNI-related options
 var CNIFlags = []cli.Flag{
 	// CNI options
@@ -876,6 +1161,12 @@ var CNIFlags = []cli.Flag{
 		Destination: &cliConfig.CNI.Plugin,
 		Value:       CNI.Plugin,
 	},
+	&cli.BoolFlag{
+		Name:        "cni-enable-status-gc",
+		Usage:       "write the CNI config with cniVersion 1.1.0 so that the container runtime uses the STATUS and GC verbs; requires a runtime with CNI 1.1 support",
+		Destination: &cliConfig.CNI.EnableStatusGC,
+		Value:       CNI.EnableStatusGC,
+	},
 }
 
 // OVNK8sFeatureFlags capture OVN-Kubernetes feature related options
@@ -915,12 +1206,24 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiNetwork,
 		Value:       OVNKubernetesFeature.EnableMultiNetwork,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-multi-network-services",
+		Usage:       "Configure to load balance services annotated with a NetworkAttachmentDefinition on that secondary network. Requires enable-multi-network.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiNetworkServices,
+		Value:       OVNKubernetesFeature.EnableMultiNetworkServices,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-stateless-netpol",
 		Usage:       "Configure to use stateless network policy feature with ovn-kubernetes.",
 		Destination: &cliConfig.OVNKubernetesFeature.EnableStatelessNetPol,
 		Value:       OVNKubernetesFeature.EnableStatelessNetPol,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-ipsec",
+		Usage:       "Configure to use IPsec to encrypt the Geneve traffic between nodes with ovn-kubernetes.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableIPsec,
+		Value:       OVNKubernetesFeature.EnableIPsec,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1056,6 +1359,16 @@ var MetricsFlags = []cli.Flag{
 		Usage:       "Enables metrics related to scaling",
 		Destination: &cliConfig.Metrics.EnableScaleMetrics,
 	},
+	&cli.BoolFlag{
+		Name:        "metrics-enable-traffic",
+		Usage:       "Enables per pod, namespace and EgressIP traffic metrics",
+		Destination: &cliConfig.Metrics.EnableTrafficMetrics,
+	},
+	&cli.BoolFlag{
+		Name:        "metrics-enable-pod-network-ready",
+		Usage:       "Enables the pod network ready duration metric, from the CNI ADD request and the logical switch port creation",
+		Destination: &cliConfig.Metrics.EnablePodNetworkReady,
+	},
 }
 
 // OvnNBFlags capture OVN northbound database options
@@ -1224,6 +1537,26 @@ var OVNGatewayFlags = []cli.Flag{
 			"Single node indicates a one node cluster and allows to simplify ovn-kubernetes gateway logic",
 		Destination: &cliConfig.Gateway.SingleNode,
 	},
+	&cli.StringFlag{
+		Name: "gateway-bridges",
+		Usage: "A comma separated set of additional named gateway bridges in the form " +
+			"name=interface[@vlanid], connecting the gateway router to other physical networks. " +
+			"The interface may be a network interface or an OVS bridge.",
+		Destination: &cliConfig.Gateway.RawBridges,
+	},
+	&cli.StringFlag{
+		Name: "gateway-bridge-routes",
+		Usage: "A comma separated set of routes through the additional gateway bridges " +
+			"in the form name=destination@nexthop, e.g. storage=10.50.0.0/16@192.168.10.1",
+		Destination: &cliConfig.Gateway.RawBridgeRoutes,
+	},
+	&cli.BoolFlag{
+		Name: "mgmt-port-ovn-routing",
+		Usage: "Route the traffic of the host to the pods through the management port with the management " +
+			"port IP as source instead of masquerading it to the management port IP with iptables " +
+			"(shared gateway mode only)",
+		Destination: &cliConfig.Gateway.MgmtPortOVNRouting,
+	},
 	// Deprecated CLI options
 	&cli.BoolFlag{
 		Name:        "init-gateways",
@@ -1307,6 +1640,32 @@ var HybridOverlayFlags = []cli.Flag{
 	},
 }
 
+// BGPFlags capture BGP advertisement options
+var BGPFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:        "enable-bgp",
+		Usage:       "Advertise the host subnet, egress IPs and load balancer VIPs of the node with BGP",
+		Destination: &cliConfig.BGP.Enabled,
+	},
+	&cli.UintFlag{
+		Name:        "bgp-asn",
+		Usage:       "The autonomous system number of the nodes",
+		Destination: &cliConfig.BGP.ASN,
+	},
+	&cli.StringFlag{
+		Name: "bgp-peers",
+		Usage: "A comma separated set of BGP peers in the form address:ASN, with IPv6 addresses " +
+			"in brackets (eg, \"172.18.0.1:64512,[fc00:f853:ccd:e793::1]:64512\")",
+		Destination: &cliConfig.BGP.RawPeers,
+	},
+	&cli.StringFlag{
+		Name:        "bgp-frr-config-file",
+		Usage:       "The FRR configuration file generated by ovnkube-node to advertise the routes of the node",
+		Value:       BGP.FRRConfigFile,
+		Destination: &cliConfig.BGP.FRRConfigFile,
+	},
+}
+
 // OvnKubeNodeFlags captures ovnkube-node specific configurations
 var OvnKubeNodeFlags = []cli.Flag{
 	&cli.StringFlag{
@@ -1337,6 +1696,43 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.DisableOVNIfaceIdVer,
 		Destination: &cliConfig.OvnKubeNode.DisableOVNIfaceIdVer,
 	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ipsec-cert-dir",
+		Usage:       "The directory of the IPsec private key and certificates of the node, when IPsec is enabled",
+		Value:       OvnKubeNode.IPsecCertDir,
+		Destination: &cliConfig.OvnKubeNode.IPsecCertDir,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ipsec-signer-name",
+		Usage:       "The signer of the certificate signing requests of the IPsec certificates of the node, required when IPsec is enabled",
+		Value:       OvnKubeNode.IPsecSignerName,
+		Destination: &cliConfig.OvnKubeNode.IPsecSignerName,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ipsec-ca-cert",
+		Usage:       "The CA certificate bundle of the IPsec certificate signer, used to authenticate the other nodes",
+		Value:       OvnKubeNode.IPsecCACert,
+		Destination: &cliConfig.OvnKubeNode.IPsecCACert,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-ip-cidrs",
+		Usage: "A comma separated set of CIDRs the host addresses of the node must be in to be used as encap IP " +
+			"and published as host addresses",
+		Destination: &cliConfig.OvnKubeNode.RawNodeIPCIDRs,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-ip-interface",
+		Usage: "The interface the host addresses of the node must be on to be used as encap IP and published as " +
+			"host addresses",
+		Destination: &cliConfig.OvnKubeNode.NodeIPInterface,
+	},
+	&cli.IntFlag{
+		Name: "ovnkube-node-ip-change-delay",
+		Usage: "The time in seconds a change of the host addresses of the node must last before the encap IP and " +
+			"the published host addresses follow it (default: 0, changes are followed immediately)",
+		Value:       OvnKubeNode.NodeIPChangeDelay,
+		Destination: &cliConfig.OvnKubeNode.NodeIPChangeDelay,
+	},
 }
 
 // Flags are general command-line flags. Apps should add these flags to their
@@ -1357,9 +1753,13 @@ func GetFlags(customFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, MasterHAFlags...)
 	flags = append(flags, ClusterMgrHAFlags...)
 	flags = append(flags, HybridOverlayFlags...)
+	flags = append(flags, BGPFlags...)
 	flags = append(flags, MonitoringFlags...)
 	flags = append(flags, IPFIXFlags...)
 	flags = append(flags, OvnKubeNodeFlags...)
+	flags = append(flags, OvnDBBackupFlags...)
+	flags = append(flags, NBRebuildFlags...)
+	flags = append(flags, NBAuditFlags...)
 	flags = append(flags, customFlags...)
 	return flags
 }
@@ -1627,9 +2027,100 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("gateway VLAN ID option: %d is supported only in shared gateway mode", Gateway.VLANID)
 	}
 
+	var err error
+	Gateway.Bridges, err = parseGatewayBridges(Gateway.RawBridges, Gateway.RawBridgeRoutes)
+	if err != nil {
+		return err
+	}
+	if len(Gateway.Bridges) > 0 {
+		if Gateway.Mode == GatewayModeDisabled {
+			return fmt.Errorf("gateway bridges option %q not allowed when gateway is disabled", Gateway.RawBridges)
+		}
+		if Gateway.DisableSNATMultipleGWs {
+			return fmt.Errorf("gateway bridges are not supported with disable-snat-multiple-gws")
+		}
+	}
+
+	if Gateway.MgmtPortOVNRouting && Gateway.Mode != GatewayModeShared {
+		return fmt.Errorf("management port OVN routing is supported only in shared gateway mode")
+	}
+
 	return nil
 }
 
+// parseGatewayBridges parses a comma separated set of gateway bridges in the
+// form name=interface[@vlanid], and a comma separated set of routes through
+// these bridges in the form name=destination@nexthop
+func parseGatewayBridges(rawBridges, rawRoutes string) ([]GatewayBridge, error) {
+	var bridges []GatewayBridge
+	bridgeIndexes := map[string]int{}
+	for _, rawBridge := range strings.Split(rawBridges, ",") {
+		rawBridge = strings.TrimSpace(rawBridge)
+		if rawBridge == "" {
+			continue
+		}
+		name, intf, found := strings.Cut(rawBridge, "=")
+		if !found || !isValidGatewayBridgeName(name) {
+			return nil, fmt.Errorf("invalid gateway bridge %q: expected name=interface[@vlanid] "+
+				"with a name of at most 15 lowercase alphanumeric characters", rawBridge)
+		}
+		if _, ok := bridgeIndexes[name]; ok {
+			return nil, fmt.Errorf("duplicate gateway bridge %q", name)
+		}
+		bridge := GatewayBridge{Name: name, Interface: intf}
+		if intf, rawVLANID, found := strings.Cut(intf, "@"); found {
+			vlanID, err := strconv.ParseUint(rawVLANID, 10, 12)
+			if err != nil || vlanID == 0 {
+				return nil, fmt.Errorf("invalid gateway bridge %q: invalid VLAN ID", rawBridge)
+			}
+			bridge.Interface = intf
+			bridge.VLANID = uint(vlanID)
+		}
+		if bridge.Interface == "" {
+			return nil, fmt.Errorf("invalid gateway bridge %q: missing interface", rawBridge)
+		}
+		bridgeIndexes[name] = len(bridges)
+		bridges = append(bridges, bridge)
+	}
+
+	for _, rawRoute := range strings.Split(rawRoutes, ",") {
+		rawRoute = strings.TrimSpace(rawRoute)
+		if rawRoute == "" {
+			continue
+		}
+		name, rawDestination, _ := strings.Cut(rawRoute, "=")
+		index, ok := bridgeIndexes[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid gateway bridge route %q: unknown gateway bridge %q", rawRoute, name)
+		}
+		rawDestination, rawNextHop, _ := strings.Cut(rawDestination, "@")
+		_, destination, err := net.ParseCIDR(rawDestination)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway bridge route %q: %v", rawRoute, err)
+		}
+		nextHop := net.ParseIP(rawNextHop)
+		if nextHop == nil || utilnet.IsIPv6(nextHop) != utilnet.IsIPv6CIDR(destination) {
+			return nil, fmt.Errorf("invalid gateway bridge route %q: invalid next hop", rawRoute)
+		}
+		bridges[index].Routes = append(bridges[index].Routes, GatewayBridgeRoute{Destination: destination, NextHop: nextHop})
+	}
+	return bridges, nil
+}
+
+// isValidGatewayBridgeName returns whether name may be used in the names of
+// the OVN objects of a gateway bridge
+func isValidGatewayBridgeName(name string) bool {
+	if name == "" || len(name) > 15 {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 func completeGatewayConfig(allSubnets *configSubnets) error {
 	// Validate v4 and v6 join subnets
 	v4IP, v4JoinCIDR, err := net.ParseCIDR(Gateway.V4JoinSubnet)
@@ -1743,6 +2234,17 @@ func completeMonitoringConfig() error {
 			return fmt.Errorf("ipfix targets invalid: %v", err)
 		}
 	}
+	if Monitoring.FlowExportAddress != "" {
+		// the flow export API has no authentication, it must only be
+		// reachable from the node
+		host, _, err := net.SplitHostPort(Monitoring.FlowExportAddress)
+		if err != nil {
+			return fmt.Errorf("flow export address invalid: %v", err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("flow export address %s must be a loopback address", Monitoring.FlowExportAddress)
+		}
+	}
 	return nil
 }
 
@@ -1753,6 +2255,50 @@ func buildIPFIXConfig(cli, file *config) error {
 	return overrideFields(&IPFIX, &cli.IPFIX, &savedIPFIX)
 }
 
+func buildOvnDBBackupConfig(cli, file *config) error {
+	if err := overrideFields(&OvnDBBackup, &file.OvnDBBackup, &savedOvnDBBackup); err != nil {
+		return err
+	}
+	if err := overrideFields(&OvnDBBackup, &cli.OvnDBBackup, &savedOvnDBBackup); err != nil {
+		return err
+	}
+	if OvnDBBackup.Dir != "" && (OvnDBBackup.Interval == 0 || OvnDBBackup.Retention == 0) {
+		return fmt.Errorf("invalid OVN DB backup config: interval and retention must be greater than 0")
+	}
+	return nil
+}
+
+func buildNBRebuildConfig(cli, file *config) error {
+	if err := overrideFields(&NBRebuild, &file.NBRebuild, &savedNBRebuild); err != nil {
+		return err
+	}
+	if err := overrideFields(&NBRebuild, &cli.NBRebuild, &savedNBRebuild); err != nil {
+		return err
+	}
+	if !NBRebuild.Enabled {
+		return nil
+	}
+	if NBRebuild.SnapshotAddress == "" {
+		return fmt.Errorf("invalid NB rebuild config: a snapshot address is required")
+	}
+	address, scheme, err := parseAddress(NBRebuild.SnapshotAddress)
+	if err != nil {
+		return fmt.Errorf("invalid NB rebuild config: %v", err)
+	}
+	if scheme == OvnDBSchemeSSL {
+		return fmt.Errorf("invalid NB rebuild config: the snapshot address must use the 'unix' or 'tcp' scheme")
+	}
+	NBRebuild.SnapshotAuth = OvnAuthConfig{Address: address, Scheme: scheme, northbound: true}
+	return nil
+}
+
+func buildNBAuditConfig(cli, file *config) error {
+	if err := overrideFields(&NBAudit, &file.NBAudit, &savedNBAudit); err != nil {
+		return err
+	}
+	return overrideFields(&NBAudit, &cli.NBAudit, &savedNBAudit)
+}
+
 func buildHybridOverlayConfig(ctx *cli.Context, cli, file *config) error {
 	// Copy config file values over default values
 	if err := overrideFields(&HybridOverlay, &file.HybridOverlay, &savedHybridOverlay); err != nil {
@@ -1768,6 +2314,10 @@ func buildHybridOverlayConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("hybrid overlay vxlan port is invalid. The port cannot be larger than 65535")
 	}
 
+	if HybridOverlay.Enabled && Default.NoOverlay {
+		return fmt.Errorf("hybrid overlay is not supported with no-overlay")
+	}
+
 	return nil
 }
 
@@ -1790,6 +2340,57 @@ func completeHybridOverlayConfig(allSubnets *configSubnets) error {
 	return nil
 }
 
+func buildBGPConfig(cli, file *config) error {
+	if err := overrideFields(&BGP, &file.BGP, &savedBGP); err != nil {
+		return err
+	}
+	if err := overrideFields(&BGP, &cli.BGP, &savedBGP); err != nil {
+		return err
+	}
+
+	if !BGP.Enabled {
+		return nil
+	}
+	if BGP.ASN == 0 || BGP.ASN > math.MaxUint32 {
+		return fmt.Errorf("invalid BGP ASN %d", BGP.ASN)
+	}
+	var err error
+	BGP.Peers, err = parseBGPPeers(BGP.RawPeers)
+	if err != nil {
+		return err
+	}
+	if len(BGP.Peers) == 0 {
+		return fmt.Errorf("BGP is enabled but no BGP peer is configured")
+	}
+	return nil
+}
+
+// parseBGPPeers parses a comma separated set of BGP peers in the form
+// address:ASN
+func parseBGPPeers(rawPeers string) ([]BGPPeer, error) {
+	var peers []BGPPeer
+	for _, rawPeer := range strings.Split(rawPeers, ",") {
+		rawPeer = strings.TrimSpace(rawPeer)
+		if rawPeer == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(rawPeer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BGP peer %q: %v", rawPeer, err)
+		}
+		address := net.ParseIP(host)
+		if address == nil {
+			return nil, fmt.Errorf("invalid BGP peer %q: invalid address", rawPeer)
+		}
+		asn, err := strconv.ParseUint(port, 10, 32)
+		if err != nil || asn == 0 {
+			return nil, fmt.Errorf("invalid BGP peer %q: invalid ASN", rawPeer)
+		}
+		peers = append(peers, BGPPeer{Address: address, ASN: uint32(asn)})
+	}
+	return peers, nil
+}
+
 func buildDefaultConfig(cli, file *config) error {
 	if err := overrideFields(&Default, &file.Default, &savedDefault); err != nil {
 		return err
@@ -1883,7 +2484,11 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		Gateway:              savedGateway,
 		MasterHA:             savedMasterHA,
 		HybridOverlay:        savedHybridOverlay,
+		BGP:                  savedBGP,
 		OvnKubeNode:          savedOvnKubeNode,
+		OvnDBBackup:          savedOvnDBBackup,
+		NBRebuild:            savedNBRebuild,
+		NBAudit:              savedNBAudit,
 	}
 
 	configFile, configFileIsDefault = getConfigFilePath(ctx)
@@ -1997,10 +2602,26 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
+	if err = buildBGPConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	if err = buildOvnKubeNodeConfig(ctx, &cliConfig, &cfg); err != nil {
 		return "", err
 	}
 
+	if err = buildOvnDBBackupConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
+	if err = buildNBRebuildConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
+	if err = buildNBAuditConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	tmpAuth, err := buildOvnAuth(exec, true, &cliConfig.OvnNorth, &cfg.OvnNorth, defaults.OvnNorthAddress)
 	if err != nil {
 		return "", err
@@ -2027,7 +2648,11 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	klog.V(5).Infof("OVN North config: %+v", OvnNorth)
 	klog.V(5).Infof("OVN South config: %+v", OvnSouth)
 	klog.V(5).Infof("Hybrid Overlay config: %+v", HybridOverlay)
+	klog.V(5).Infof("BGP config: %+v", BGP)
 	klog.V(5).Infof("Ovnkube Node config: %+v", OvnKubeNode)
+	klog.V(5).Infof("OVN DB backup config: %+v", OvnDBBackup)
+	klog.V(5).Infof("NB rebuild config: %+v", NBRebuild)
+	klog.V(5).Infof("NB audit config: %+v", NBAudit)
 
 	return retConfigFile, nil
 }
@@ -2335,6 +2960,11 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("hybrid overlay is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
 	}
 
+	// ovnkube-node-mode dpu/dpu-host does not support no-overlay
+	if OvnKubeNode.Mode != types.NodeModeFull && Default.NoOverlay {
+		return fmt.Errorf("no-overlay is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
+	}
+
 	// Warn the user if both MgmtPortNetdev and MgmtPortDPResourceName are specified since they
 	// configure the management port.
 	if OvnKubeNode.MgmtPortNetdev != "" && OvnKubeNode.MgmtPortDPResourceName != "" {
@@ -2344,11 +2974,31 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 			OvnKubeNode.MgmtPortNetdev, OvnKubeNode.MgmtPortDPResourceName)
 	}
 
-	// when DPU is used, management port is backed by a VF. get management port VF information
+	// when DPU is used, management port is backed by a VF or a SF. get management port VF or SF information
 	if OvnKubeNode.Mode == types.NodeModeDPU || OvnKubeNode.Mode == types.NodeModeDPUHost {
 		if OvnKubeNode.MgmtPortNetdev == "" && OvnKubeNode.MgmtPortDPResourceName == "" {
 			return fmt.Errorf("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided")
 		}
 	}
+
+	OvnKubeNode.NodeIPCIDRs = nil
+	if OvnKubeNode.RawNodeIPCIDRs != "" {
+		for _, cidrString := range strings.Split(OvnKubeNode.RawNodeIPCIDRs, ",") {
+			_, cidr, err := net.ParseCIDR(strings.TrimSpace(cidrString))
+			if err != nil {
+				return fmt.Errorf("ovnkube-node-ip-cidrs CIDR %q invalid: %v", cidrString, err)
+			}
+			OvnKubeNode.NodeIPCIDRs = append(OvnKubeNode.NodeIPCIDRs, cidr)
+		}
+	}
+	if OvnKubeNode.NodeIPChangeDelay < 0 {
+		return fmt.Errorf("ovnkube-node-ip-change-delay %d must not be negative", OvnKubeNode.NodeIPChangeDelay)
+	}
+
+	// there is no signer of IPsec certificates common to all clusters, the
+	// certificate signing requests of the node would never be signed
+	if OVNKubernetesFeature.EnableIPsec && ctx.String("init-node") != "" && OvnKubeNode.IPsecSignerName == "" {
+		return fmt.Errorf("ovnkube-node-ipsec-signer-name must be provided when IPsec is enabled")
+	}
 	return nil
 }
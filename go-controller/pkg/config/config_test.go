@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -1017,6 +1018,92 @@ enable-pprof=true
 		err := app.Run(cliArgs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
+	It("returns an error when management port OVN routing is enabled for mode other than shared gateway mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("management port OVN routing is supported only in shared gateway mode"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=local",
+			"-mgmt-port-ovn-routing",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when IPsec is enabled on a node without a signer name", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-ipsec-signer-name must be provided when IPsec is enabled"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-init-node=node1",
+			"-enable-ipsec",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("enables IPsec on a node with a signer name", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.IPsecSignerName).To(gomega.Equal("example.com/ipsec-signer"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-init-node=node1",
+			"-enable-ipsec",
+			"-ovnkube-node-ipsec-signer-name=example.com/ipsec-signer",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the flow export address is not a loopback address", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("flow export address 0.0.0.0:9108 must be a loopback address"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-flow-export-address=0.0.0.0:9108",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("accepts a loopback flow export address", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Monitoring.FlowExportAddress).To(gomega.Equal("[::1]:9108"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-flow-export-address=[::1]:9108",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when gateway bridges are set with disable-snat-multiple-gws", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway bridges are not supported with disable-snat-multiple-gws"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-bridges=storage=eth1",
+			"-disable-snat-multiple-gws",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
 	It("returns an error when the v4 join subnet specified is invalid", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1661,6 +1748,98 @@ foo=bar
 		})
 	})
 
+	Describe("BGP config", func() {
+		It("parses the BGP peers", func() {
+			cliConfig := config{
+				BGP: BGPConfig{
+					Enabled:  true,
+					ASN:      64512,
+					RawPeers: "172.18.0.1:64512, [fc00:f853:ccd:e793::1]:64513",
+				},
+			}
+			err := buildBGPConfig(&cliConfig, &config{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(BGP.ASN).To(gomega.Equal(uint(64512)))
+			gomega.Expect(BGP.Peers).To(gomega.Equal([]BGPPeer{
+				{Address: net.ParseIP("172.18.0.1"), ASN: 64512},
+				{Address: net.ParseIP("fc00:f853:ccd:e793::1"), ASN: 64513},
+			}))
+		})
+
+		It("Fails with an invalid BGP peer", func() {
+			cliConfig := config{
+				BGP: BGPConfig{
+					Enabled:  true,
+					ASN:      64512,
+					RawPeers: "fc00:f853:ccd:e793::1:64512",
+				},
+			}
+			err := buildBGPConfig(&cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("invalid BGP peer"))
+		})
+
+		It("Fails without BGP peers", func() {
+			cliConfig := config{
+				BGP: BGPConfig{
+					Enabled: true,
+					ASN:     64512,
+				},
+			}
+			err := buildBGPConfig(&cliConfig, &config{})
+			gomega.Expect(err).To(gomega.MatchError("BGP is enabled but no BGP peer is configured"))
+		})
+	})
+
+	Describe("Gateway bridges config", func() {
+		It("parses the gateway bridges and their routes", func() {
+			bridges, err := parseGatewayBridges("storage=eth1, tenant=breth2@100",
+				"storage=10.50.0.0/16@192.168.10.1,storage=fd00:50::/64@fd00:10::1,tenant=10.60.0.0/16@192.168.20.1")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(bridges).To(gomega.Equal([]GatewayBridge{
+				{
+					Name:      "storage",
+					Interface: "eth1",
+					Routes: []GatewayBridgeRoute{
+						{Destination: ovntest.MustParseIPNet("10.50.0.0/16"), NextHop: net.ParseIP("192.168.10.1")},
+						{Destination: ovntest.MustParseIPNet("fd00:50::/64"), NextHop: net.ParseIP("fd00:10::1")},
+					},
+				},
+				{
+					Name:      "tenant",
+					Interface: "breth2",
+					VLANID:    100,
+					Routes: []GatewayBridgeRoute{
+						{Destination: ovntest.MustParseIPNet("10.60.0.0/16"), NextHop: net.ParseIP("192.168.20.1")},
+					},
+				},
+			}))
+		})
+
+		It("Fails with an invalid gateway bridge name", func() {
+			_, err := parseGatewayBridges("Storage_1=eth1", "")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("invalid gateway bridge"))
+		})
+
+		It("Fails with a duplicate gateway bridge", func() {
+			_, err := parseGatewayBridges("storage=eth1,storage=eth2", "")
+			gomega.Expect(err).To(gomega.MatchError("duplicate gateway bridge \"storage\""))
+		})
+
+		It("Fails with a route through an unknown gateway bridge", func() {
+			_, err := parseGatewayBridges("storage=eth1", "tenant=10.60.0.0/16@192.168.20.1")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("unknown gateway bridge"))
+		})
+
+		It("Fails with a next hop of another IP family than the destination", func() {
+			_, err := parseGatewayBridges("storage=eth1", "storage=10.50.0.0/16@fd00:10::1")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("invalid next hop"))
+		})
+	})
+
 	Describe("OVN Kube Node config", func() {
 		// NOTE: We test this here as the test that overrides values also sets hybridOverlay to true
 		// which yields an invalid configuration.
@@ -1723,6 +1902,19 @@ foo=bar
 				"hybrid overlay is not supported with ovnkube-node mode"))
 		})
 
+		It("Fails if no-overlay is enabled and ovnkube node mode is not full", func() {
+			Default.NoOverlay = true
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeDPU,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring(
+				"no-overlay is not supported with ovnkube-node mode"))
+		})
+
 		It("Fails if management port is not provided and ovnkube node mode is dpu", func() {
 			cliConfig := config{
 				OvnKubeNode: OvnKubeNodeConfig{
@@ -1734,6 +1926,61 @@ foo=bar
 			gomega.Expect(err.Error()).To(gomega.ContainSubstring("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided"))
 		})
 
+		It("Parses the node IP policy", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:              types.NodeModeFull,
+					RawNodeIPCIDRs:    "192.168.10.0/24,fd00:10::/64",
+					NodeIPInterface:   "eth1",
+					NodeIPChangeDelay: 60,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.NodeIPCIDRs).To(gomega.Equal(ovntest.MustParseIPNets("192.168.10.0/24", "fd00:10::/64")))
+			gomega.Expect(OvnKubeNode.NodeIPInterface).To(gomega.Equal("eth1"))
+			gomega.Expect(OvnKubeNode.NodeIPChangeDelay).To(gomega.Equal(60))
+		})
+
+		It("Fails with an invalid node IP CIDR", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeFull,
+					RawNodeIPCIDRs: "192.168.10.0/24,eth1",
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("ovnkube-node-ip-cidrs CIDR \"eth1\" invalid"))
+		})
+
+		It("Fails with a negative node IP change delay", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:              types.NodeModeFull,
+					NodeIPChangeDelay: -1,
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("must not be negative"))
+		})
+
 		It("Fails if management port is not provided and ovnkube node mode is dpu-host", func() {
 			cliConfig := config{
 				OvnKubeNode: OvnKubeNodeConfig{
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +resource:path=hybridoverlaypeer
+// +kubebuilder:resource:shortName=hop,scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="VTEP",type=string,JSONPath=".spec.vtep"
+// +kubebuilder:printcolumn:name="Subnets",type=string,JSONPath=".spec.subnets[*]"
+// HybridOverlayPeer is a CRD declaring a VXLAN peer outside of the cluster,
+// such as a node of another cluster or a VM fabric, that the hybrid overlay
+// tunnels the traffic of the pods to its subnets to.
+type HybridOverlayPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of HybridOverlayPeer.
+	Spec HybridOverlayPeerSpec `json:"spec"`
+}
+
+// HybridOverlayPeerSpec is a desired state description of HybridOverlayPeer.
+type HybridOverlayPeerSpec struct {
+	// VTEP is the IPv4 address of the VXLAN tunnel endpoint of the peer.
+	// This field is mandatory.
+	VTEP string `json:"vtep"`
+	// Subnets is the list of IPv4 subnets of the pods behind the peer. They
+	// must be within the hybrid overlay cluster subnets. This field is mandatory.
+	// +kubebuilder:validation:MinItems=1
+	Subnets []string `json:"subnets"`
+	// MAC is the destination MAC address of the traffic tunneled to the peer,
+	// the MAC address of its VXLAN device. This field is mandatory.
+	MAC string `json:"mac"`
+	// VNI is the VXLAN network identifier of the traffic tunneled to the peer.
+	// This field is optional, and defaults to 4097, the VNI of the hybrid overlay.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777215
+	// +optional
+	VNI *uint32 `json:"vni,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=hybridoverlaypeer
+// HybridOverlayPeerList is the list of HybridOverlayPeerList.
+type HybridOverlayPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of HybridOverlayPeer.
+	Items []HybridOverlayPeer `json:"items"`
+}
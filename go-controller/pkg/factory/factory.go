@@ -11,6 +11,7 @@ import (
 	egressfirewallscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/scheme"
 	egressfirewallinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/informers/externalversions"
 	egressfirewalllister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	egressipapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
@@ -26,6 +27,9 @@ import (
 	egressqosscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/scheme"
 	egressqosinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/informers/externalversions"
 	egressqosinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/informers/externalversions/egressqos/v1"
+	hybridoverlaypeerapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1"
+	hybridoverlaypeerscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1/apis/clientset/versioned/scheme"
+	hybridoverlaypeerinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1/apis/informers/externalversions"
 
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	nadscheme "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/scheme"
@@ -59,6 +63,7 @@ type WatchFactory struct {
 	efFactory        egressfirewallinformerfactory.SharedInformerFactory
 	cpipcFactory     ocpcloudnetworkinformerfactory.SharedInformerFactory
 	egressQoSFactory egressqosinformerfactory.SharedInformerFactory
+	hopFactory       hybridoverlaypeerinformerfactory.SharedInformerFactory
 	informers        map[reflect.Type]*informer
 
 	stopChan chan struct{}
@@ -288,6 +293,14 @@ func (wf *WatchFactory) Start() error {
 			}
 		}
 	}
+	if config.HybridOverlay.Enabled && wf.hopFactory != nil {
+		wf.hopFactory.Start(wf.stopChan)
+		for oType, synced := range wf.hopFactory.WaitForCacheSync(wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
 
 	return nil
 }
@@ -369,6 +382,26 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 		return nil, err
 	}
 
+	// EgressIPs are only needed on nodes for their traffic metrics and BGP advertisement
+	if config.OVNKubernetesFeature.EnableEgressIP && (config.Metrics.EnableTrafficMetrics || config.BGP.Enabled) &&
+		ovnClientset.EgressIPClient != nil {
+		wf.eipFactory = egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval)
+		wf.informers[EgressIPType], err = newInformer(EgressIPType, wf.eipFactory.K8s().V1().EgressIPs().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// HybridOverlayPeers are handled by the hybrid overlay node controller
+	// through their informer
+	if config.HybridOverlay.Enabled && ovnClientset.HybridOverlayPeerClient != nil {
+		if err := hybridoverlaypeerapi.AddToScheme(hybridoverlaypeerscheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.hopFactory = hybridoverlaypeerinformerfactory.NewSharedInformerFactory(ovnClientset.HybridOverlayPeerClient, resyncInterval)
+		wf.hopFactory.K8s().V1().HybridOverlayPeers().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -791,6 +824,12 @@ func (wf *WatchFactory) GetService(namespace, name string) (*kapi.Service, error
 	return serviceLister.Services(namespace).Get(name)
 }
 
+// GetServices returns all services
+func (wf *WatchFactory) GetServices() ([]*kapi.Service, error) {
+	serviceLister := wf.informers[ServiceType].lister.(listers.ServiceLister)
+	return serviceLister.List(labels.Everything())
+}
+
 func (wf *WatchFactory) GetCloudPrivateIPConfig(name string) (*ocpcloudnetworkapi.CloudPrivateIPConfig, error) {
 	cloudPrivateIPConfigLister := wf.informers[CloudPrivateIPConfigType].lister.(ocpcloudnetworklister.CloudPrivateIPConfigLister)
 	return cloudPrivateIPConfigLister.Get(name)
@@ -888,10 +927,19 @@ func (wf *WatchFactory) EgressQoSInformer() egressqosinformer.EgressQoSInformer
 	return wf.egressQoSFactory.K8s().V1().EgressQoSes()
 }
 
+// HybridOverlayPeerInformer returns the shared Informer of the
+// HybridOverlayPeers, or nil when they are not watched.
+func (wf *WatchFactory) HybridOverlayPeerInformer() cache.SharedIndexInformer {
+	if wf.hopFactory == nil {
+		return nil
+	}
+	return wf.hopFactory.K8s().V1().HybridOverlayPeers().Informer()
+}
+
 // withServiceNameAndNoHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices with a non-empty
 // "kubernetes.io/service-name" label and without "service.kubernetes.io/headless"
-// label.
+// label. EndpointSlices mirrored for secondary networks are excluded as well.
 func withServiceNameAndNoHeadlessServiceSelector() func(options *metav1.ListOptions) {
 	// LabelServiceName must exist
 	svcNameLabel, err := labels.NewRequirement(discovery.LabelServiceName, selection.Exists, nil)
@@ -912,7 +960,15 @@ func withServiceNameAndNoHeadlessServiceSelector() func(options *metav1.ListOpti
 		panic(err)
 	}
 
-	selector := labels.NewSelector().Add(*svcNameLabel, *notEmptySvcName, *noHeadlessService)
+	// secondary network mirrored EndpointSlices must not be there
+	notMirrored, err := labels.NewRequirement(discovery.LabelManagedBy, selection.NotEquals,
+		[]string{types.EndpointSliceMirrorControllerName})
+	if err != nil {
+		// cannot occur
+		panic(err)
+	}
+
+	selector := labels.NewSelector().Add(*svcNameLabel, *notEmptySvcName, *noHeadlessService, *notMirrored)
 
 	return func(options *metav1.ListOptions) {
 		options.LabelSelector = selector.String()
@@ -342,6 +342,22 @@ func (_m *NodeWatchFactory) GetService(namespace string, name string) (*corev1.S
 	return r0, r1
 }
 
+// HybridOverlayPeerInformer provides a mock function with given fields:
+func (_m *NodeWatchFactory) HybridOverlayPeerInformer() cache.SharedIndexInformer {
+	ret := _m.Called()
+
+	var r0 cache.SharedIndexInformer
+	if rf, ok := ret.Get(0).(func() cache.SharedIndexInformer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.SharedIndexInformer)
+		}
+	}
+
+	return r0
+}
+
 // ListNodes provides a mock function with given fields: selector
 func (_m *NodeWatchFactory) ListNodes(selector labels.Selector) ([]*corev1.Node, error) {
 	ret := _m.Called(selector)
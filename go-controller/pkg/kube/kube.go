@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
 	ocpcloudnetworkclientset "github.com/openshift/client-go/cloudnetwork/clientset/versioned"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
@@ -33,6 +34,8 @@ type InterfaceOVN interface {
 	CreateCloudPrivateIPConfig(cloudPrivateIPConfig *ocpcloudnetworkapi.CloudPrivateIPConfig) (*ocpcloudnetworkapi.CloudPrivateIPConfig, error)
 	UpdateCloudPrivateIPConfig(cloudPrivateIPConfig *ocpcloudnetworkapi.CloudPrivateIPConfig) (*ocpcloudnetworkapi.CloudPrivateIPConfig, error)
 	DeleteCloudPrivateIPConfig(name string) error
+	SetAnnotationsOnNAD(namespace, name string, annotations map[string]interface{}) error
+	GetAnnotationsOnNAD(namespace, name string) (map[string]string, error)
 }
 
 // Interface represents the exported methods for dealing with getting/setting
@@ -70,6 +73,7 @@ type KubeOVN struct {
 	EIPClient            egressipclientset.Interface
 	EgressFirewallClient egressfirewallclientset.Interface
 	CloudNetworkClient   ocpcloudnetworkclientset.Interface
+	NADClient            nadclientset.Interface
 }
 
 // SetAnnotationsOnPod takes the pod object and map of key/value string pairs to set as annotations
@@ -387,3 +391,39 @@ func (k *KubeOVN) UpdateCloudPrivateIPConfig(cloudPrivateIPConfig *ocpcloudnetwo
 func (k *KubeOVN) DeleteCloudPrivateIPConfig(name string) error {
 	return k.CloudNetworkClient.CloudV1().CloudPrivateIPConfigs().Delete(context.TODO(), name, metav1.DeleteOptions{})
 }
+
+// SetAnnotationsOnNAD takes a net-attach-def namespace and name and a map of key/value string pairs to set as annotations
+func (k *KubeOVN) SetAnnotationsOnNAD(namespace, name string, annotations map[string]interface{}) error {
+	var err error
+	var patchData []byte
+	patch := struct {
+		Metadata map[string]interface{} `json:"metadata"`
+	}{
+		Metadata: map[string]interface{}{
+			"annotations": annotations,
+		},
+	}
+
+	nadDesc := namespace + "/" + name
+	klog.V(5).Infof("Setting annotations %v on net-attach-def %s", annotations, nadDesc)
+	patchData, err = json.Marshal(&patch)
+	if err != nil {
+		klog.Errorf("Error in setting annotations on net-attach-def %s: %v", nadDesc, err)
+		return err
+	}
+
+	_, err = k.NADClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Patch(context.TODO(), name, types.MergePatchType, patchData, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("Error in setting annotation on net-attach-def %s: %v", nadDesc, err)
+	}
+	return err
+}
+
+// GetAnnotationsOnNAD obtains the net-attach-def annotations from kubernetes apiserver, given the namespace and name
+func (k *KubeOVN) GetAnnotationsOnNAD(namespace, name string) (map[string]string, error) {
+	nad, err := k.NADClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return nad.ObjectMeta.Annotations, nil
+}
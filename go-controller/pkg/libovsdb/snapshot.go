@@ -0,0 +1,471 @@
+package libovsdb
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DBRows holds the rows of a database by table and by UUID
+type DBRows map[string]map[string]model.Model
+
+// CacheRows returns a copy of the rows in the cache of the client
+func CacheRows(c client.Client) DBRows {
+	rows := DBRows{}
+	for _, table := range c.Cache().Tables() {
+		rows[table] = c.Cache().Table(table).Rows()
+	}
+	return rows
+}
+
+// SnapshotDiff holds the differences between the rows of a snapshot of a
+// database and the rows of a database
+type SnapshotDiff struct {
+	Tables []TableDiff `json:"tables,omitempty"`
+}
+
+// TableDiff holds the differences between the rows of a table. Rows are
+// identified by a key made of their indexed columns, name and external IDs
+// or, if they have none, of their content and the rows referencing them.
+type TableDiff struct {
+	Table string `json:"table"`
+	// Missing are the rows of the snapshot not found in the database
+	Missing []string `json:"missing,omitempty"`
+	// Unexpected are the rows of the database not found in the snapshot
+	Unexpected []string `json:"unexpected,omitempty"`
+	// Differing are the rows found in both with different columns
+	Differing []RowDiff `json:"differing,omitempty"`
+}
+
+// RowDiff holds the columns of a row that differ
+type RowDiff struct {
+	Row     string       `json:"row"`
+	Columns []ColumnDiff `json:"columns"`
+}
+
+// ColumnDiff holds the values of a column in the snapshot and in the database
+type ColumnDiff struct {
+	Column   string `json:"column"`
+	Snapshot string `json:"snapshot"`
+	Database string `json:"database"`
+}
+
+// CompareOptions tune what CompareWithSnapshot compares
+type CompareOptions struct {
+	// IgnoredTables are the tables not compared
+	IgnoredTables []string
+	// IgnoredColumns are the columns of each table not compared; the keys
+	// of map columns are given as "column:key"
+	IgnoredColumns map[string][]string
+	// SingletonTables are the tables with at most one row, compared
+	// regardless of their content
+	SingletonTables []string
+}
+
+// Empty returns whether no difference was found
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Tables) == 0
+}
+
+// String returns a summary of the differences
+func (d *SnapshotDiff) String() string {
+	if d.Empty() {
+		return "no differences"
+	}
+	summary := make([]string, 0, len(d.Tables))
+	for _, table := range d.Tables {
+		summary = append(summary, fmt.Sprintf("%s: %d missing, %d unexpected, %d differing",
+			table.Table, len(table.Missing), len(table.Unexpected), len(table.Differing)))
+	}
+	return strings.Join(summary, "; ")
+}
+
+// CompareWithSnapshot returns the differences between the rows of a snapshot
+// of a database and the rows of a database. As UUIDs are not preserved from
+// one database to another, rows and references to rows are compared by key.
+func CompareWithSnapshot(dbModel model.DatabaseModel, snapshot, db DBRows, opts CompareOptions) *SnapshotDiff {
+	snapshotRows := newRowIndexer(dbModel, snapshot, opts).canonicalRows()
+	dbRows := newRowIndexer(dbModel, db, opts).canonicalRows()
+
+	ignoredTables := sets.New[string](opts.IgnoredTables...)
+	tables := sets.New[string]()
+	for table := range snapshotRows {
+		tables.Insert(table)
+	}
+	for table := range dbRows {
+		tables.Insert(table)
+	}
+
+	diff := &SnapshotDiff{}
+	for _, table := range sets.List(tables.Difference(ignoredTables)) {
+		tableDiff := TableDiff{Table: table}
+		for _, key := range sortedKeys(snapshotRows[table]) {
+			snapshotRow := snapshotRows[table][key]
+			dbRow, ok := dbRows[table][key]
+			if !ok {
+				tableDiff.Missing = append(tableDiff.Missing, key)
+				continue
+			}
+			var columns []ColumnDiff
+			for _, column := range sortedKeys(mergeKeys(snapshotRow, dbRow)) {
+				if snapshotRow[column] != dbRow[column] {
+					columns = append(columns, ColumnDiff{
+						Column:   column,
+						Snapshot: snapshotRow[column],
+						Database: dbRow[column],
+					})
+				}
+			}
+			if len(columns) > 0 {
+				tableDiff.Differing = append(tableDiff.Differing, RowDiff{Row: key, Columns: columns})
+			}
+		}
+		for _, key := range sortedKeys(dbRows[table]) {
+			if _, ok := snapshotRows[table][key]; !ok {
+				tableDiff.Unexpected = append(tableDiff.Unexpected, key)
+			}
+		}
+		if len(tableDiff.Missing) > 0 || len(tableDiff.Unexpected) > 0 || len(tableDiff.Differing) > 0 {
+			diff.Tables = append(diff.Tables, tableDiff)
+		}
+	}
+	return diff
+}
+
+// rowReference is a reference from a column of a row to another row
+type rowReference struct {
+	table  string
+	uuid   string
+	column string
+}
+
+// rowIndexer computes the keys and the canonical content of the rows of a
+// database, independent of their UUIDs
+type rowIndexer struct {
+	dbModel     model.DatabaseModel
+	rows        DBRows
+	ignored     map[string]sets.Set[string]
+	singletons  sets.Set[string]
+	referrers   map[string][]rowReference
+	keys        map[string]string
+	contentKeys map[string]string
+	resolving   sets.Set[string]
+}
+
+func newRowIndexer(dbModel model.DatabaseModel, rows DBRows, opts CompareOptions) *rowIndexer {
+	ri := &rowIndexer{
+		dbModel:     dbModel,
+		rows:        rows,
+		ignored:     map[string]sets.Set[string]{},
+		singletons:  sets.New[string](opts.SingletonTables...),
+		referrers:   map[string][]rowReference{},
+		keys:        map[string]string{},
+		contentKeys: map[string]string{},
+		resolving:   sets.New[string](),
+	}
+	for table, columns := range opts.IgnoredColumns {
+		ri.ignored[table] = sets.New[string](columns...)
+	}
+	for table, tableRows := range rows {
+		for uuid, m := range tableRows {
+			ri.forEachColumn(table, m, func(column string, value interface{}, keyRef, valueRef string) {
+				for _, ref := range referencedUUIDs(value, keyRef, valueRef) {
+					ri.referrers[ref] = append(ri.referrers[ref], rowReference{table: table, uuid: uuid, column: column})
+				}
+			})
+		}
+	}
+	return ri
+}
+
+// canonicalRows returns the canonical content of the rows by table and key.
+// Rows with the same key are told apart by a suffix.
+func (ri *rowIndexer) canonicalRows() map[string]map[string]map[string]string {
+	result := map[string]map[string]map[string]string{}
+	for table, tableRows := range ri.rows {
+		byKey := map[string][]map[string]string{}
+		for uuid, m := range tableRows {
+			key := ri.key(table, uuid)
+			byKey[key] = append(byKey[key], ri.content(table, m, ri.key))
+		}
+		result[table] = map[string]map[string]string{}
+		for key, contents := range byKey {
+			sort.Slice(contents, func(i, j int) bool {
+				return formatColumns(contents[i]) < formatColumns(contents[j])
+			})
+			for i, content := range contents {
+				rowKey := key
+				if i > 0 {
+					rowKey = fmt.Sprintf("%s#%d", key, i+1)
+				}
+				result[table][rowKey] = content
+			}
+		}
+	}
+	return result
+}
+
+// key returns the key of a row: its own key if it has one, else its content
+// key qualified by the keys of the rows referencing it
+func (ri *rowIndexer) key(table, uuid string) string {
+	if key, ok := ri.keys[uuid]; ok {
+		return key
+	}
+	if _, ok := ri.rows[table][uuid]; !ok || ri.resolving.Has(uuid) {
+		// dangling or circular reference
+		return uuid
+	}
+	key := ri.ownKey(table, uuid)
+	if key == "" {
+		ri.resolving.Insert(uuid)
+		var parents []string
+		for _, ref := range ri.referrers[uuid] {
+			parents = append(parents, ri.key(ref.table, ref.uuid)+"."+ref.column)
+		}
+		ri.resolving.Delete(uuid)
+		sort.Strings(parents)
+		key = ri.contentKey(table, uuid)
+		if len(parents) > 0 {
+			key = strings.Join(parents, ",") + ": " + key
+		}
+	}
+	ri.keys[uuid] = key
+	return key
+}
+
+// contentKey returns the key of a row made of its content, with references
+// replaced by the own key of the referenced rows or, if they have none, by
+// their content key
+func (ri *rowIndexer) contentKey(table, uuid string) string {
+	if key, ok := ri.contentKeys[uuid]; ok {
+		return key
+	}
+	m, ok := ri.rows[table][uuid]
+	if !ok || ri.resolving.Has(uuid) {
+		// dangling or circular reference
+		return uuid
+	}
+	ri.resolving.Insert(uuid)
+	key := formatColumns(ri.content(table, m, func(refTable, refUUID string) string {
+		if ownKey := ri.ownKey(refTable, refUUID); ownKey != "" {
+			return ownKey
+		}
+		return "(" + ri.contentKey(refTable, refUUID) + ")"
+	}))
+	ri.resolving.Delete(uuid)
+	ri.contentKeys[uuid] = key
+	return key
+}
+
+// ownKey returns the key of a row made of its identifying columns: its first
+// index, else its name and external IDs. Rows of singleton tables are keyed
+// by their table.
+func (ri *rowIndexer) ownKey(table, uuid string) string {
+	if ri.singletons.Has(table) {
+		return table
+	}
+	m, ok := ri.rows[table][uuid]
+	if !ok {
+		return ""
+	}
+	info, err := ri.dbModel.NewModelInfo(m)
+	if err != nil {
+		return ""
+	}
+	tableSchema := ri.dbModel.Schema.Table(table)
+	var indexes [][]string
+	if len(tableSchema.Indexes) > 0 {
+		indexes = append(indexes, tableSchema.Indexes[0])
+	}
+	indexes = append(indexes, []string{"name", "external_ids"})
+	for _, index := range indexes {
+		parts := map[string]string{}
+		for _, column := range index {
+			columnSchema := tableSchema.Column(column)
+			value, err := info.FieldByColumn(column)
+			if columnSchema == nil || err != nil {
+				continue
+			}
+			// identifying columns are not expected to hold references
+			if s := ri.format(value, "", "", nil, nil); s != "" {
+				parts[column] = s
+			}
+		}
+		if len(parts) > 0 {
+			return formatColumns(parts)
+		}
+	}
+	return ""
+}
+
+// content returns the canonical value of the non empty, not ignored columns
+// of a row, with references replaced by resolve
+func (ri *rowIndexer) content(table string, m model.Model, resolve func(table, uuid string) string) map[string]string {
+	content := map[string]string{}
+	ri.forEachColumn(table, m, func(column string, value interface{}, keyRef, valueRef string) {
+		if ri.ignored[table].Has(column) {
+			return
+		}
+		ignoredKeys := sets.New[string]()
+		for _, ignored := range sets.List(ri.ignored[table]) {
+			if strings.HasPrefix(ignored, column+":") {
+				ignoredKeys.Insert(strings.TrimPrefix(ignored, column+":"))
+			}
+		}
+		if s := ri.format(value, keyRef, valueRef, ignoredKeys, resolve); s != "" {
+			content[column] = s
+		}
+	})
+	return content
+}
+
+// forEachColumn calls f with the value of each column of the row but its
+// UUID and version, along with the tables referenced by its keys and values
+func (ri *rowIndexer) forEachColumn(table string, m model.Model, f func(column string, value interface{}, keyRef, valueRef string)) {
+	info, err := ri.dbModel.NewModelInfo(m)
+	if err != nil {
+		return
+	}
+	for column, columnSchema := range ri.dbModel.Schema.Table(table).Columns {
+		if column == "_uuid" || column == "_version" {
+			continue
+		}
+		value, err := info.FieldByColumn(column)
+		if err != nil {
+			continue
+		}
+		keyRef, valueRef := refTables(columnSchema)
+		f(column, value, keyRef, valueRef)
+	}
+}
+
+// format returns the canonical string of a native column value: sets and maps
+// are sorted, references replaced by resolve and empty values are ""
+func (ri *rowIndexer) format(value interface{}, keyRef, valueRef string, ignoredKeys sets.Set[string],
+	resolve func(table, uuid string) string) string {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return ""
+		}
+		return ri.format(v.Elem().Interface(), keyRef, valueRef, nil, resolve)
+	case reflect.Slice:
+		elems := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, ri.format(v.Index(i).Interface(), keyRef, "", nil, resolve))
+		}
+		if len(elems) == 0 {
+			return ""
+		}
+		sort.Strings(elems)
+		return "[" + strings.Join(elems, ", ") + "]"
+	case reflect.Map:
+		elems := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			key := ri.format(k.Interface(), keyRef, "", nil, resolve)
+			if ignoredKeys.Has(key) {
+				continue
+			}
+			elems = append(elems, key+"="+ri.format(v.MapIndex(k).Interface(), valueRef, "", nil, resolve))
+		}
+		if len(elems) == 0 {
+			return ""
+		}
+		sort.Strings(elems)
+		return "{" + strings.Join(elems, ", ") + "}"
+	case reflect.String:
+		if keyRef != "" && resolve != nil && v.String() != "" {
+			return resolve(keyRef, v.String())
+		}
+		return v.String()
+	default:
+		if !v.IsValid() || v.IsZero() {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// refTables returns the tables referenced by the keys and the values of a
+// column, if any
+func refTables(column *ovsdb.ColumnSchema) (string, string) {
+	if column.TypeObj == nil {
+		return "", ""
+	}
+	var keyRef, valueRef string
+	if column.TypeObj.Key != nil && column.TypeObj.Key.Type == ovsdb.TypeUUID {
+		keyRef, _ = column.TypeObj.Key.RefTable()
+	}
+	if column.TypeObj.Value != nil && column.TypeObj.Value.Type == ovsdb.TypeUUID {
+		valueRef, _ = column.TypeObj.Value.RefTable()
+	}
+	return keyRef, valueRef
+}
+
+// referencedUUIDs returns the UUIDs a native column value references
+func referencedUUIDs(value interface{}, keyRef, valueRef string) []string {
+	var uuids []string
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() && keyRef != "" {
+			uuids = append(uuids, v.Elem().String())
+		}
+	case reflect.Slice:
+		if keyRef != "" {
+			for i := 0; i < v.Len(); i++ {
+				uuids = append(uuids, v.Index(i).String())
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if keyRef != "" {
+				uuids = append(uuids, k.String())
+			}
+			if valueRef != "" {
+				uuids = append(uuids, v.MapIndex(k).String())
+			}
+		}
+	case reflect.String:
+		if keyRef != "" && v.String() != "" {
+			uuids = append(uuids, v.String())
+		}
+	}
+	return uuids
+}
+
+func formatColumns(columns map[string]string) string {
+	elems := make([]string, 0, len(columns))
+	for _, column := range sortedKeys(columns) {
+		elems = append(elems, column+"="+columns[column])
+	}
+	return strings.Join(elems, " ")
+}
+
+func mergeKeys(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for k := range a {
+		merged[k] = ""
+	}
+	for k := range b {
+		merged[k] = ""
+	}
+	return merged
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
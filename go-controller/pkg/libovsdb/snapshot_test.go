@@ -0,0 +1,163 @@
+package libovsdb
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/ovn-org/libovsdb/model"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+const (
+	routerUUID     = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c001"
+	snatUUID       = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c002"
+	dnatUUID       = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c003"
+	node1UUID      = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c004"
+	node2UUID      = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c005"
+	nbGlobalUUID   = "a0c7f7e2-0c53-4d1b-9e1b-2ba1f1f6c006"
+	routerKey      = "name=ovn_cluster_router"
+	snatKey        = routerKey + ".nat: external_ip=10.0.0.1 logical_ip=10.128.0.0/14 type=snat"
+	dnatKey        = routerKey + ".nat: external_ip=10.0.0.2 logical_ip=10.128.0.5 type=dnat_and_snat"
+	rebuiltDNATKey = routerKey + ".nat: external_ip=10.0.0.3 logical_ip=10.128.0.5 type=dnat_and_snat"
+)
+
+func newTestNBDatabaseModel(g *gomega.WithT) model.DatabaseModel {
+	clientDBModel, err := nbdb.FullDatabaseModel()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	dbModel, errs := model.NewDatabaseModel(nbdb.Schema(), clientDBModel)
+	g.Expect(errs).To(gomega.BeEmpty())
+	return dbModel
+}
+
+func TestCompareWithSnapshot(t *testing.T) {
+	dbModel := newTestNBDatabaseModel(gomega.NewWithT(t))
+	snapshot := DBRows{
+		nbdb.LogicalRouterTable: {
+			routerUUID: &nbdb.LogicalRouter{UUID: routerUUID, Name: "ovn_cluster_router", Nat: []string{snatUUID, dnatUUID}},
+		},
+		nbdb.NATTable: {
+			snatUUID: &nbdb.NAT{UUID: snatUUID, Type: nbdb.NATTypeSNAT, ExternalIP: "10.0.0.1", LogicalIP: "10.128.0.0/14"},
+			dnatUUID: &nbdb.NAT{UUID: dnatUUID, Type: nbdb.NATTypeDNATAndSNAT, ExternalIP: "10.0.0.2", LogicalIP: "10.128.0.5"},
+		},
+		nbdb.LogicalSwitchTable: {
+			node1UUID: &nbdb.LogicalSwitch{UUID: node1UUID, Name: "node1", OtherConfig: map[string]string{"subnet": "10.128.0.0/24", "exclude_ips": "10.128.0.2"}},
+		},
+		nbdb.NBGlobalTable: {
+			nbGlobalUUID: &nbdb.NBGlobal{UUID: nbGlobalUUID, NbCfg: 5, Options: map[string]string{"mac_prefix": "0a:58:0a", "use_logical_dp_groups": "true"}},
+		},
+	}
+
+	opts := CompareOptions{
+		SingletonTables: []string{nbdb.NBGlobalTable},
+		IgnoredColumns: map[string][]string{
+			nbdb.NBGlobalTable: {"nb_cfg", "options:mac_prefix"},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		db       DBRows
+		expected *SnapshotDiff
+	}{
+		{
+			desc: "identical content with different UUIDs",
+			db: DBRows{
+				nbdb.LogicalRouterTable: {
+					"r": &nbdb.LogicalRouter{UUID: "r", Name: "ovn_cluster_router", Nat: []string{"d", "s"}},
+				},
+				nbdb.NATTable: {
+					"s": &nbdb.NAT{UUID: "s", Type: nbdb.NATTypeSNAT, ExternalIP: "10.0.0.1", LogicalIP: "10.128.0.0/14"},
+					"d": &nbdb.NAT{UUID: "d", Type: nbdb.NATTypeDNATAndSNAT, ExternalIP: "10.0.0.2", LogicalIP: "10.128.0.5"},
+				},
+				nbdb.LogicalSwitchTable: {
+					"n1": &nbdb.LogicalSwitch{UUID: "n1", Name: "node1", OtherConfig: map[string]string{"subnet": "10.128.0.0/24", "exclude_ips": "10.128.0.2"}},
+				},
+				nbdb.NBGlobalTable: {
+					"g": &nbdb.NBGlobal{UUID: "g", NbCfg: 10, Options: map[string]string{"mac_prefix": "0a:58:0b", "use_logical_dp_groups": "true"}},
+				},
+			},
+			expected: &SnapshotDiff{},
+		},
+		{
+			desc: "missing, unexpected and differing rows",
+			db: DBRows{
+				nbdb.LogicalRouterTable: {
+					"r": &nbdb.LogicalRouter{UUID: "r", Name: "ovn_cluster_router", Nat: []string{"d", "s"}},
+				},
+				nbdb.NATTable: {
+					"s": &nbdb.NAT{UUID: "s", Type: nbdb.NATTypeSNAT, ExternalIP: "10.0.0.1", LogicalIP: "10.128.0.0/14"},
+					"d": &nbdb.NAT{UUID: "d", Type: nbdb.NATTypeDNATAndSNAT, ExternalIP: "10.0.0.3", LogicalIP: "10.128.0.5"},
+				},
+				nbdb.LogicalSwitchTable: {
+					"n1": &nbdb.LogicalSwitch{UUID: "n1", Name: "node1", OtherConfig: map[string]string{"subnet": "10.128.0.0/24"}},
+					"n3": &nbdb.LogicalSwitch{UUID: "n3", Name: "node3"},
+				},
+				nbdb.NBGlobalTable: {
+					"g": &nbdb.NBGlobal{UUID: "g", Options: map[string]string{"use_logical_dp_groups": "false"}},
+				},
+			},
+			expected: &SnapshotDiff{
+				Tables: []TableDiff{
+					{
+						Table: nbdb.LogicalRouterTable,
+						Differing: []RowDiff{
+							{
+								Row: routerKey,
+								Columns: []ColumnDiff{
+									{
+										Column:   "nat",
+										Snapshot: "[" + snatKey + ", " + dnatKey + "]",
+										Database: "[" + snatKey + ", " + rebuiltDNATKey + "]",
+									},
+								},
+							},
+						},
+					},
+					{
+						Table:      nbdb.LogicalSwitchTable,
+						Unexpected: []string{"name=node3"},
+						Differing: []RowDiff{
+							{
+								Row: "name=node1",
+								Columns: []ColumnDiff{
+									{
+										Column:   "other_config",
+										Snapshot: "{exclude_ips=10.128.0.2, subnet=10.128.0.0/24}",
+										Database: "{subnet=10.128.0.0/24}",
+									},
+								},
+							},
+						},
+					},
+					{
+						Table:      nbdb.NATTable,
+						Missing:    []string{dnatKey},
+						Unexpected: []string{rebuiltDNATKey},
+					},
+					{
+						Table: nbdb.NBGlobalTable,
+						Differing: []RowDiff{
+							{
+								Row: nbdb.NBGlobalTable,
+								Columns: []ColumnDiff{
+									{
+										Column:   "options",
+										Snapshot: "{use_logical_dp_groups=true}",
+										Database: "{use_logical_dp_groups=false}",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			diff := CompareWithSnapshot(dbModel, snapshot, tc.db, opts)
+			gomega.NewWithT(t).Expect(diff).To(gomega.Equal(tc.expected))
+		})
+	}
+}
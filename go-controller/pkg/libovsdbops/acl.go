@@ -20,7 +20,7 @@ func GetACLName(acl *nbdb.ACL) string {
 }
 
 func getACLMutableFields(acl *nbdb.ACL) []interface{} {
-	return []interface{}{&acl.Action, &acl.Direction, &acl.ExternalIDs, &acl.Log, &acl.Match, &acl.Meter,
+	return []interface{}{&acl.Action, &acl.Direction, &acl.ExternalIDs, &acl.Label, &acl.Log, &acl.Match, &acl.Meter,
 		&acl.Name, &acl.Options, &acl.Priority, &acl.Severity}
 }
 
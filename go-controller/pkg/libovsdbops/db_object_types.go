@@ -23,6 +23,7 @@ const (
 	MulticastClusterOwnerType   ownerType = "MulticastCluster"
 	NetpolNodeOwnerType         ownerType = "NetpolNode"
 	NetpolNamespaceOwnerType    ownerType = "NetpolNamespace"
+	GatewayBridgeOwnerType      ownerType = "GatewayBridge"
 
 	// owner extra IDs, make sure to define only 1 ExternalIDKey for every string value
 	PriorityKey           ExternalIDKey = "priority"
@@ -34,6 +35,7 @@ const (
 	PortPolicyIndexKey    ExternalIDKey = "port-policy-index"
 	IpBlockIndexKey       ExternalIDKey = "ip-block-index"
 	RuleIndex             ExternalIDKey = "rule-index"
+	GatewayBridgeKey      ExternalIDKey = "gateway-bridge"
 )
 
 // ObjectIDsTypes should only be created here
@@ -93,6 +95,14 @@ var AddressSetEgressService = newObjectIDsType(addressSet, EgressServiceOwnerTyp
 	AddressSetIPFamilyKey,
 })
 
+var AddressSetGatewayBridge = newObjectIDsType(addressSet, GatewayBridgeOwnerType, []ExternalIDKey{
+	// nodeName
+	ObjectNameKey,
+	// name of the gateway bridge of the node
+	GatewayBridgeKey,
+	AddressSetIPFamilyKey,
+})
+
 var ACLNetpolDefault = newObjectIDsType(acl, NetpolDefaultOwnerType, []ExternalIDKey{
 	// for now there is only 1 acl of this type, but we use a name in case more types are needed in the future
 	ObjectNameKey,
@@ -117,6 +117,23 @@ func DeleteLoadBalancersOps(nbClient libovsdbclient.Client, ops []libovsdb.Opera
 	return modelClient.DeleteOps(ops, opModels...)
 }
 
+type loadBalancerPredicate func(*nbdb.LoadBalancer) bool
+
+// DeleteLoadBalancersWithPredicateOps returns the operations to delete the load
+// balancers matching the provided predicate
+func DeleteLoadBalancersWithPredicateOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation,
+	p loadBalancerPredicate) ([]libovsdb.Operation, error) {
+	opModel := operationModel{
+		Model:          &nbdb.LoadBalancer{},
+		ModelPredicate: p,
+		ErrNotFound:    false,
+		BulkOp:         true,
+	}
+
+	modelClient := newModelClient(nbClient)
+	return modelClient.DeleteOps(ops, opModel)
+}
+
 // DeleteLoadBalancers deletes the provided load balancers
 func DeleteLoadBalancers(nbClient libovsdbclient.Client, lbs []*nbdb.LoadBalancer) error {
 	ops, err := DeleteLoadBalancersOps(nbClient, nil, lbs...)
@@ -62,3 +62,25 @@ func UpdateNBGlobalSetOptions(nbClient libovsdbclient.Client, nbGlobal *nbdb.NBG
 	_, err = m.CreateOrUpdate(opModel)
 	return err
 }
+
+// UpdateNBGlobalSetIpsec sets the ipsec column of the NB Global entry
+func UpdateNBGlobalSetIpsec(nbClient libovsdbclient.Client, nbGlobal *nbdb.NBGlobal) error {
+	updatedNbGlobal, err := GetNBGlobal(nbClient, nbGlobal)
+	if err != nil {
+		return err
+	}
+
+	updatedNbGlobal.Ipsec = nbGlobal.Ipsec
+	opModel := operationModel{
+		Model: updatedNbGlobal,
+		OnModelUpdates: []interface{}{
+			&updatedNbGlobal.Ipsec,
+		},
+		ErrNotFound: true,
+		BulkOp:      false,
+	}
+
+	m := newModelClient(nbClient)
+	_, err = m.CreateOrUpdate(opModel)
+	return err
+}
@@ -289,6 +289,50 @@ var metricEgressFirewallCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The number of egress firewall policies",
 })
 
+var metricNBOrphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "nb_orphaned_objects",
+	Help:      "The number of NB database objects whose Kubernetes owner no longer exists, as found by the last audit"},
+	[]string{
+		"table",
+		"owner_type",
+	},
+)
+
+var metricNBOrphanedObjectsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "nb_orphaned_objects_deleted_total",
+	Help:      "The total number of orphaned NB database objects deleted by the audit"},
+	[]string{
+		"table",
+		"owner_type",
+	},
+)
+
+var metricSBOrphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "sb_orphaned_objects",
+	Help:      "The number of SB database objects whose Kubernetes owner no longer exists, as found by the last audit"},
+	[]string{
+		"table",
+		"owner_type",
+	},
+)
+
+var metricSBOrphanedObjectsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "sb_orphaned_objects_deleted_total",
+	Help:      "The total number of orphaned SB database objects deleted by the audit"},
+	[]string{
+		"table",
+		"owner_type",
+	},
+)
+
 // metricFirstSeenLSPLatency is the time between a pod first seen in OVN-Kubernetes and its Logical Switch Port is created
 var metricFirstSeenLSPLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
@@ -429,6 +473,10 @@ func RegisterMasterFunctional() {
 	prometheus.MustRegister(metricEgressFirewallRuleCount)
 	prometheus.MustRegister(metricEgressFirewallCount)
 	prometheus.MustRegister(metricEgressRoutingViaHost)
+	prometheus.MustRegister(metricNBOrphanedObjects)
+	prometheus.MustRegister(metricNBOrphanedObjectsDeleted)
+	prometheus.MustRegister(metricSBOrphanedObjects)
+	prometheus.MustRegister(metricSBOrphanedObjectsDeleted)
 	if err := prometheus.Register(MetricResourceRetryFailuresCount); err != nil {
 		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
 			panic(err)
@@ -618,6 +666,38 @@ func DecrementEgressFirewallCount() {
 	metricEgressFirewallCount.Dec()
 }
 
+// RecordNBOrphanedObjects records the number of orphaned NB database objects
+// found by an audit, by table then owner type
+func RecordNBOrphanedObjects(counts map[string]map[string]int) {
+	metricNBOrphanedObjects.Reset()
+	for table, ownerCounts := range counts {
+		for ownerType, count := range ownerCounts {
+			metricNBOrphanedObjects.WithLabelValues(table, ownerType).Set(float64(count))
+		}
+	}
+}
+
+// RecordNBOrphanedObjectsDeleted records the deletion of orphaned NB database objects
+func RecordNBOrphanedObjectsDeleted(table, ownerType string, count int) {
+	metricNBOrphanedObjectsDeleted.WithLabelValues(table, ownerType).Add(float64(count))
+}
+
+// RecordSBOrphanedObjects records the number of orphaned SB database objects
+// found by an audit, by table then owner type
+func RecordSBOrphanedObjects(counts map[string]map[string]int) {
+	metricSBOrphanedObjects.Reset()
+	for table, ownerCounts := range counts {
+		for ownerType, count := range ownerCounts {
+			metricSBOrphanedObjects.WithLabelValues(table, ownerType).Set(float64(count))
+		}
+	}
+}
+
+// RecordSBOrphanedObjectsDeleted records the deletion of orphaned SB database objects
+func RecordSBOrphanedObjectsDeleted(table, ownerType string, count int) {
+	metricSBOrphanedObjectsDeleted.WithLabelValues(table, ownerType).Add(float64(count))
+}
+
 type (
 	timestampType int
 	operation     int
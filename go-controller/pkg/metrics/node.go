@@ -3,6 +3,7 @@ package metrics
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +21,31 @@ var MetricCNIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOp
 	[]string{"command", "err"},
 )
 
+// MetricCNIRequestPhaseDuration is a prometheus metric that tracks the duration
+// of the phases of CNI ADD requests
+var MetricCNIRequestPhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "cni_request_phase_duration_seconds",
+	Help:      "The duration of the phases of CNI ADD requests.",
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	//labels
+	[]string{"phase"},
+)
+
+// metricPodNetworkReadyDuration is a prometheus metric that tracks the duration
+// for a first packet to be sent to pods through their OVS port, from the CNI
+// ADD request, the OVS port added and the logical switch port created
+var metricPodNetworkReadyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "pod_network_ready_duration_seconds",
+	Help:      "The duration for pod networks to be ready, from the milestone of the phase.",
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	//labels
+	[]string{"phase"},
+)
+
 var MetricNodeReadyDuration = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemNode,
@@ -34,14 +60,64 @@ var metricOvnNodePortEnabled = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "Specifies if the node port is enabled on this node(1) or not(0).",
 })
 
+var metricACLLogMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "acl_log_messages_total",
+	Help:      "The number of ACL log messages of ovn-controller collected on the node, by verdict and by the type of the owner of the ACL."},
+	[]string{
+		"verdict",
+		"owner_type",
+	},
+)
+
+var metricEgressIPTransmitBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "egress_ip_transmit_bytes_total",
+	Help:      "The number of bytes sent to the external network by the egress IPs assigned to the node."},
+	[]string{
+		"egressip",
+		"ip",
+	},
+)
+
+var metricEgressIPTransmitPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "egress_ip_transmit_packets_total",
+	Help:      "The number of packets sent to the external network by the egress IPs assigned to the node."},
+	[]string{
+		"egressip",
+		"ip",
+	},
+)
+
+var metricIPsecCertificateExpiration = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "ipsec_certificate_expiration_timestamp_seconds",
+	Help:      "The time the IPsec certificate of the node expires at, in seconds since the epoch.",
+})
+
 var registerNodeMetricsOnce sync.Once
 
 func RegisterNodeMetrics() {
 	registerNodeMetricsOnce.Do(func() {
 		// ovnkube-node metrics
 		prometheus.MustRegister(MetricCNIRequestDuration)
+		prometheus.MustRegister(MetricCNIRequestPhaseDuration)
+		prometheus.MustRegister(metricPodNetworkReadyDuration)
 		prometheus.MustRegister(MetricNodeReadyDuration)
 		prometheus.MustRegister(metricOvnNodePortEnabled)
+		prometheus.MustRegister(metricACLLogMessages)
+		if config.OVNKubernetesFeature.EnableIPsec {
+			prometheus.MustRegister(metricIPsecCertificateExpiration)
+		}
+		if config.Metrics.EnableTrafficMetrics {
+			prometheus.MustRegister(metricEgressIPTransmitBytes)
+			prometheus.MustRegister(metricEgressIPTransmitPackets)
+		}
 		prometheus.MustRegister(prometheus.NewGaugeFunc(
 			prometheus.GaugeOpts{
 				Namespace: MetricOvnkubeNamespace,
@@ -68,3 +144,34 @@ func RegisterNodeMetrics() {
 		}
 	})
 }
+
+// RecordACLLogMessage records an ACL log message with the verdict of the ACL
+// and the type of the object it was created for
+func RecordACLLogMessage(verdict, ownerType string) {
+	metricACLLogMessages.WithLabelValues(verdict, ownerType).Inc()
+}
+
+// ObservePodNetworkReady records the duration for a pod network to be ready
+// from the milestone of a phase
+func ObservePodNetworkReady(phase string, duration time.Duration) {
+	metricPodNetworkReadyDuration.WithLabelValues(phase).Observe(duration.Seconds())
+}
+
+// SetIPsecCertificateExpiration sets the time the IPsec certificate of the
+// node expires at
+func SetIPsecCertificateExpiration(notAfter time.Time) {
+	metricIPsecCertificateExpiration.Set(float64(notAfter.Unix()))
+}
+
+// ResetEgressIPTrafficMetrics removes the traffic metrics of all egress IPs
+func ResetEgressIPTrafficMetrics() {
+	metricEgressIPTransmitBytes.Reset()
+	metricEgressIPTransmitPackets.Reset()
+}
+
+// SetEgressIPTrafficMetrics sets the number of bytes and packets sent to the
+// external network by an IP of an EgressIP
+func SetEgressIPTrafficMetrics(egressIP, ip string, bytes, packets uint64) {
+	metricEgressIPTransmitBytes.WithLabelValues(egressIP, ip).Set(float64(bytes))
+	metricEgressIPTransmitPackets.WithLabelValues(egressIP, ip).Set(float64(packets))
+}
@@ -4,12 +4,15 @@
 package metrics
 
 import (
+	"encoding/csv"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -226,6 +229,36 @@ var metricOvsInterfaceTotal = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The total number of Open vSwitch interface(s) created for pods",
 })
 
+// pod traffic metrics, from the statistics of the pod interfaces. Interfaces
+// receive what pods transmit and transmit what pods receive.
+var metricPodTrafficBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvsNamespace,
+	Subsystem: MetricOvsSubsystemVswitchd,
+	Name:      "pod_traffic_bytes_total",
+	Help:      "The total number of bytes transmitted or received by a pod on a network, from its Open vSwitch interface.",
+}, []string{"namespace", "pod", "network", "direction"})
+
+var metricPodTrafficPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvsNamespace,
+	Subsystem: MetricOvsSubsystemVswitchd,
+	Name:      "pod_traffic_packets_total",
+	Help:      "The total number of packets transmitted or received by a pod on a network, from its Open vSwitch interface.",
+}, []string{"namespace", "pod", "network", "direction"})
+
+var metricNamespaceTrafficBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvsNamespace,
+	Subsystem: MetricOvsSubsystemVswitchd,
+	Name:      "namespace_traffic_bytes_total",
+	Help:      "The total number of bytes transmitted or received by the pods of the node in a namespace on a network.",
+}, []string{"namespace", "network", "direction"})
+
+var metricNamespaceTrafficPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvsNamespace,
+	Subsystem: MetricOvsSubsystemVswitchd,
+	Name:      "namespace_traffic_packets_total",
+	Help:      "The total number of packets transmitted or received by the pods of the node in a namespace on a network.",
+}, []string{"namespace", "network", "direction"})
+
 var MetricOvsInterfaceUpWait = prometheus.NewCounter(prometheus.CounterOpts{
 	Namespace: MetricOvsNamespace,
 	Subsystem: MetricOvsSubsystemVswitchd,
@@ -517,13 +550,22 @@ func ovsInterfaceMetricsUpdater(ovsVsctl ovsClient, tickPeriod time.Duration, st
 	}
 }
 
+// podTraffic is the traffic of a pod on a network
+type podTraffic struct {
+	rxBytes, txBytes, rxPackets, txPackets float64
+}
+
 // updateOvsInterfaceMetrics updates the ovs interface metrics obtained from ovs-vsctl --columns=<fields> list interface
 func updateOvsInterfaceMetrics(ovsVsctl ovsClient) error {
 	var stdout, stderr string
 	var err error
 
+	columns := []string{"link_resets", "statistics"}
+	if config.Metrics.EnableTrafficMetrics {
+		columns = append(columns, "external_ids")
+	}
 	stdout, stderr, err = ovsVsctl("--no-headings", "--data=bare",
-		"--format=csv", "--columns=link_resets,statistics", "list", "Interface")
+		"--format=csv", "--columns="+strings.Join(columns, ","), "list", "Interface")
 	if err != nil {
 		return fmt.Errorf("failed to get output for ovs-vsctl list Interface "+
 			"stderr(%s) :(%v)", stderr, err)
@@ -534,13 +576,17 @@ func updateOvsInterfaceMetrics(ovsVsctl ovsClient) error {
 	if stdout == "" {
 		return fmt.Errorf("unable to update OVS interface metrics because blank output received from OVS client")
 	}
+	// external_ids values like ip_addresses may contain commas, in which case they are quoted
+	reader := csv.NewReader(strings.NewReader(stdout))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("unexpected data format received while trying to get OVS interface metrics: %v", err)
+	}
 	var linkReset, rxDropped, txDropped, rxErr, txErr, collisions, statValue, interfaceCount float64
-	for _, kvPair := range strings.Split(stdout, "\n") {
-		if kvPair == "" {
-			continue
-		}
-		interfaceFieldValues := strings.Split(kvPair, ",")
-		if len(interfaceFieldValues) != 2 {
+	podTraffics := map[[3]string]*podTraffic{}
+	for _, interfaceFieldValues := range records {
+		if len(interfaceFieldValues) != len(columns) {
 			return fmt.Errorf("unexpected data format received while trying to get OVS interface metrics: %s", stdout)
 		}
 		statValue, err = strconv.ParseFloat(interfaceFieldValues[0], 64)
@@ -549,6 +595,7 @@ func updateOvsInterfaceMetrics(ovsVsctl ovsClient) error {
 		}
 		linkReset += statValue
 		interfaceCount++
+		var traffic podTraffic
 		// sum statistics
 		for _, field := range strings.Fields(interfaceFieldValues[1]) {
 			statsField := strings.Split(field, "=")
@@ -570,6 +617,25 @@ func updateOvsInterfaceMetrics(ovsVsctl ovsClient) error {
 				txErr += statValue
 			case "collisions":
 				collisions += statValue
+			case "rx_bytes":
+				traffic.rxBytes = statValue
+			case "tx_bytes":
+				traffic.txBytes = statValue
+			case "rx_packets":
+				traffic.rxPackets = statValue
+			case "tx_packets":
+				traffic.txPackets = statValue
+			}
+		}
+		if len(interfaceFieldValues) > 2 {
+			if key, ok := getPodInterfaceKey(interfaceFieldValues[2]); ok {
+				if podTraffics[key] == nil {
+					podTraffics[key] = &podTraffic{}
+				}
+				podTraffics[key].rxBytes += traffic.rxBytes
+				podTraffics[key].txBytes += traffic.txBytes
+				podTraffics[key].rxPackets += traffic.rxPackets
+				podTraffics[key].txPackets += traffic.txPackets
 			}
 		}
 	}
@@ -580,9 +646,58 @@ func updateOvsInterfaceMetrics(ovsVsctl ovsClient) error {
 	metricOvsInterfaceRxErrorsTotal.Set(rxErr)
 	metricOvsInterfaceTxErrorsTotal.Set(txErr)
 	metricOvsInterfaceCollisionsTotal.Set(collisions)
+	if config.Metrics.EnableTrafficMetrics {
+		setTrafficMetrics(podTraffics)
+	}
 	return nil
 }
 
+// getPodInterfaceKey returns the namespace, pod and network of a pod
+// interface from its bare external_ids, as set by the CNI in ConfigureOVS
+func getPodInterfaceKey(externalIDs string) ([3]string, bool) {
+	ids := map[string]string{}
+	for _, field := range strings.Fields(externalIDs) {
+		if key, value, found := strings.Cut(field, "="); found {
+			ids[key] = value
+		}
+	}
+	ifaceID, ok := ids["iface-id"]
+	if !ok || ids["sandbox"] == "" {
+		return [3]string{}, false
+	}
+	network := types.DefaultNetworkName
+	if nadName, ok := ids[types.NADExternalID]; ok {
+		network = ids[types.NetworkExternalID]
+		ifaceID = strings.TrimPrefix(ifaceID, util.GetSecondaryNetworkPrefix(nadName))
+	}
+	namespace, pod, found := strings.Cut(ifaceID, "_")
+	if !found {
+		return [3]string{}, false
+	}
+	return [3]string{namespace, pod, network}, true
+}
+
+// setTrafficMetrics sets the traffic metrics of the pods and namespaces, from
+// the traffic of the pods by namespace, name and network
+func setTrafficMetrics(podTraffics map[[3]string]*podTraffic) {
+	// To update not only values but also labels for metrics, we use Reset() to delete previous labels+value
+	metricPodTrafficBytes.Reset()
+	metricPodTrafficPackets.Reset()
+	metricNamespaceTrafficBytes.Reset()
+	metricNamespaceTrafficPackets.Reset()
+	for key, traffic := range podTraffics {
+		namespace, pod, network := key[0], key[1], key[2]
+		metricPodTrafficBytes.WithLabelValues(namespace, pod, network, "transmit").Set(traffic.rxBytes)
+		metricPodTrafficBytes.WithLabelValues(namespace, pod, network, "receive").Set(traffic.txBytes)
+		metricPodTrafficPackets.WithLabelValues(namespace, pod, network, "transmit").Set(traffic.rxPackets)
+		metricPodTrafficPackets.WithLabelValues(namespace, pod, network, "receive").Set(traffic.txPackets)
+		metricNamespaceTrafficBytes.WithLabelValues(namespace, network, "transmit").Add(traffic.rxBytes)
+		metricNamespaceTrafficBytes.WithLabelValues(namespace, network, "receive").Add(traffic.txBytes)
+		metricNamespaceTrafficPackets.WithLabelValues(namespace, network, "transmit").Add(traffic.rxPackets)
+		metricNamespaceTrafficPackets.WithLabelValues(namespace, network, "receive").Add(traffic.txPackets)
+	}
+}
+
 // setOvsMemoryMetrics updates the handlers, revalidators
 // count from "ovs-appctl -t ovs-vswitchd memory/show" output.
 func setOvsMemoryMetrics(ovsVswitchdAppctl ovsClient) (err error) {
@@ -902,6 +1017,12 @@ func registerOvsMetrics(registry prometheus.Registerer, stopChan <-chan struct{}
 		registry.MustRegister(metricOvsInterfaceCollisionsTotal)
 		registry.MustRegister(metricOvsInterfaceTotal)
 		registry.MustRegister(MetricOvsInterfaceUpWait)
+		if config.Metrics.EnableTrafficMetrics {
+			registry.MustRegister(metricPodTrafficBytes)
+			registry.MustRegister(metricPodTrafficPackets)
+			registry.MustRegister(metricNamespaceTrafficBytes)
+			registry.MustRegister(metricNamespaceTrafficPackets)
+		}
 		// Register the OVS coverage/show metrics
 		componentCoverageShowMetricsMap[ovsVswitchd] = ovsVswitchdCoverageShowMetricsMap
 		registerCoverageShowMetrics(ovsVswitchd, MetricOvsNamespace, MetricOvsSubsystemVswitchd)
@@ -3,11 +3,13 @@ package metrics
 import (
 	"fmt"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics/mocks"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 type clientOutput struct {
@@ -34,7 +36,14 @@ func (c *fakeOVSClient) FakeCall(args ...string) (string, string, error) {
 const (
 	ovsAppctlDumpAggregateSampleOutput = "NXST_AGGREGATE reply (xid=0x4): packet_count=856244 byte_count=3464651294 flow_count=30"
 	ovsVsctlListBridgeOutput           = "br-int,porta portb portc\nbr-ex,portd porte"
-	ovsVsctlListInterfaceOutput        = "1,collisions=10 rx_bytes=0 rx_crc_err=0 rx_dropped=5 rx_errors=100 rx_frame_err=0 rx_missed_errors=0 rx_over_err=0 rx_packets=0 tx_bytes=0 tx_dropped=50 tx_errors=20 tx_packets=0\n1,rx_bytes=0 rx_packets=1000 tx_bytes=0 tx_packets=80\n0,collisions=10 rx_bytes=0 rx_crc_err=0 rx_dropped=5 rx_errors=100 rx_frame_err=0 rx_missed_errors=0 rx_over_err=0 rx_packets=0 tx_bytes=0 tx_dropped=50 tx_errors=20 tx_packets=0"
+	ovsVsctlListInterfaceTrafficOutput = "0,rx_bytes=100 rx_packets=2 tx_bytes=300 tx_packets=4," +
+		"\"attached_mac=0a:58:0a:f4:01:03 iface-id=ns1_pod1 ip_addresses=\"\"10.244.1.3/24,fd00:10:244:1::3/64\"\" sandbox=1a2b\"\n" +
+		"0,rx_bytes=1000 rx_packets=20 tx_bytes=3000 tx_packets=40," +
+		"\"iface-id=ns1.blue_ns1_pod1 k8s.ovn.org/nad=ns1/blue k8s.ovn.org/network=tenantblue sandbox=1a2b\"\n" +
+		"0,rx_bytes=10 rx_packets=1 tx_bytes=30 tx_packets=3,\"iface-id=ns1_pod2 sandbox=3c4d\"\n" +
+		"0,rx_bytes=5 rx_packets=5 tx_bytes=5 tx_packets=5,\"iface-id=ovn-k8s-mp0\"\n" +
+		"0,rx_bytes=5 rx_packets=5 tx_bytes=5 tx_packets=5,"
+	ovsVsctlListInterfaceOutput = "1,collisions=10 rx_bytes=0 rx_crc_err=0 rx_dropped=5 rx_errors=100 rx_frame_err=0 rx_missed_errors=0 rx_over_err=0 rx_packets=0 tx_bytes=0 tx_dropped=50 tx_errors=20 tx_packets=0\n1,rx_bytes=0 rx_packets=1000 tx_bytes=0 tx_packets=80\n0,collisions=10 rx_bytes=0 rx_crc_err=0 rx_dropped=5 rx_errors=100 rx_frame_err=0 rx_missed_errors=0 rx_over_err=0 rx_packets=0 tx_bytes=0 tx_dropped=50 tx_errors=20 tx_packets=0"
 )
 
 var _ = ginkgo.Describe("OVS metrics", func() {
@@ -233,6 +242,37 @@ var _ = ginkgo.Describe("OVS metrics", func() {
 			gomega.Expect(collisionsTotalMock.GetValue()).Should(gomega.BeNumerically("==", 20))
 		})
 
+		ginkgo.It("sets pod and namespace traffic metrics when enabled", func() {
+			config.Metrics.EnableTrafficMetrics = true
+			defer func() {
+				config.Metrics.EnableTrafficMetrics = false
+			}()
+			ovsVsctlOutput := []clientOutput{
+				{
+					stdout: ovsVsctlListInterfaceTrafficOutput,
+					stderr: "",
+					err:    nil,
+				},
+			}
+			ovsVsctl := NewFakeOVSClient(ovsVsctlOutput)
+			err := updateOvsInterfaceMetrics(ovsVsctl.FakeCall)
+			gomega.Expect(err).Should(gomega.BeNil())
+			getValue := func(vec *prometheus.GaugeVec, labels ...string) float64 {
+				metric := &dto.Metric{}
+				gomega.Expect(vec.WithLabelValues(labels...).Write(metric)).To(gomega.Succeed())
+				return metric.GetGauge().GetValue()
+			}
+			gomega.Expect(getValue(metricPodTrafficBytes, "ns1", "pod1", "default", "transmit")).Should(gomega.BeNumerically("==", 100))
+			gomega.Expect(getValue(metricPodTrafficBytes, "ns1", "pod1", "default", "receive")).Should(gomega.BeNumerically("==", 300))
+			gomega.Expect(getValue(metricPodTrafficPackets, "ns1", "pod1", "tenantblue", "receive")).Should(gomega.BeNumerically("==", 40))
+			gomega.Expect(getValue(metricNamespaceTrafficBytes, "ns1", "default", "transmit")).Should(gomega.BeNumerically("==", 110))
+			gomega.Expect(getValue(metricNamespaceTrafficPackets, "ns1", "default", "receive")).Should(gomega.BeNumerically("==", 7))
+			// 2 pods on the default network and 1 on tenantblue, each transmitting and receiving
+			gomega.Expect(metricPodTrafficBytes.DeleteLabelValues("ns1", "pod2", "default", "transmit")).To(gomega.BeTrue())
+			gomega.Expect(metricNamespaceTrafficBytes.DeleteLabelValues("ns1", "tenantblue", "transmit")).To(gomega.BeTrue())
+			gomega.Expect(metricNamespaceTrafficBytes.DeleteLabelValues("", "default", "transmit")).To(gomega.BeFalse())
+		})
+
 		ginkgo.It("returns error when OVS vsctl client returns an error", func() {
 			ovsVsctlOutput := []clientOutput{
 				{
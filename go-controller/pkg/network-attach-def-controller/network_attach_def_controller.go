@@ -44,6 +44,8 @@ const (
 
 var ErrNetworkControllerTopologyNotManaged = errors.New("no cluster network controller to manage topology")
 
+var errDefaultNetworkNAD = errors.New("NAD for default network, skip it")
+
 type BaseNetworkController interface {
 	Start(ctx context.Context) error
 	Stop()
@@ -67,6 +69,12 @@ type NetworkControllerManager interface {
 	CleanupDeletedNetworks(allControllers []NetworkController) error
 }
 
+// NetAttachDefErrorReporter is optionally implemented by a NetworkControllerManager to
+// report the net-attach-defs that could not be added to their network
+type NetAttachDefErrorReporter interface {
+	ReportNetAttachDefError(nadName string, err error)
+}
+
 type networkNADInfo struct {
 	nadNames  map[string]struct{}
 	nc        NetworkController
@@ -366,7 +374,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 	if invalidNADErr == nil {
 		netName = nInfo.GetNetworkName()
 		if netName == types.DefaultNetworkName {
-			invalidNADErr = fmt.Errorf("NAD for default network, skip it")
+			invalidNADErr = errDefaultNetworkNAD
 		}
 	}
 
@@ -381,6 +389,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				// invalid nad, nothing to do
 				klog.Warningf("%s: net-attach-def %s is first seen and is invalid: %v", nadController.name, nadName, invalidNADErr)
 				nadController.perNADNetConfInfo.Delete(nadName)
+				nadController.reportNetAttachDefError(nadName, invalidNADErr)
 				return nil
 			}
 			klog.V(5).Infof("%s: net-attach-def %s network %s first seen", nadController.name, nadName, netName)
@@ -388,6 +397,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 			if err != nil {
 				klog.Errorf("%s: Failed to add net-attach-def %s to network %s: %v", nadController.name, nadName, netName, err)
 				nadController.perNADNetConfInfo.Delete(nadName)
+				nadController.reportNetAttachDefError(nadName, err)
 				return err
 			}
 		} else {
@@ -413,6 +423,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				err = nadController.addNADToController(ncm, nadName, nInfo, netConfInfo, doStart)
 				if err != nil {
 					klog.Errorf("%s: Failed to add net-attach-def %s to network %s: %v", nadController.name, nadName, netName, err)
+					nadController.reportNetAttachDefError(nadName, err)
 					return err
 				}
 				return nil
@@ -429,6 +440,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 			}
 			if invalidNADErr != nil {
 				klog.Warningf("%s: net-attach-def %s is invalid: %v", nadController.name, nadName, invalidNADErr)
+				nadController.reportNetAttachDefError(nadName, invalidNADErr)
 				return nil
 			}
 			klog.V(5).Infof("%s: Add updated net-attach-def %s to network %s", nadController.name, nadName, netName)
@@ -437,6 +449,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 			if err != nil {
 				klog.Errorf("%s: Failed to add net-attach-def %s to network %s: %v", nadController.name, nadName, netName, err)
 				nadController.perNADNetConfInfo.Delete(nadName)
+				nadController.reportNetAttachDefError(nadName, err)
 				return err
 			}
 			return nil
@@ -445,6 +458,18 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 	})
 }
 
+// reportNetAttachDefError reports why the given NAD could not be added to its network, if the
+// network controller manager supports it. NADs of other CNIs and of the default network are
+// not reported.
+func (nadController *NetAttachDefinitionController) reportNetAttachDefError(nadName string, err error) {
+	if errors.Is(err, util.ErrorAttachDefNotOvnManaged) || errors.Is(err, errDefaultNetworkNAD) {
+		return
+	}
+	if reporter, ok := nadController.ncm.(NetAttachDefErrorReporter); ok {
+		reporter.ReportNetAttachDefError(nadName, err)
+	}
+}
+
 // DeleteNetAttachDef deletes the given NAD from the associated controller. It delete the controller if this
 // is the last NAD of the network
 func (nadController *NetAttachDefinitionController) DeleteNetAttachDef(netAttachDefName string) error {
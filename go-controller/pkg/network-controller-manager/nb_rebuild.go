@@ -0,0 +1,101 @@
+package networkControllerManager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+// nbRebuildCompareOptions leave out of the comparison of a rebuilt NB
+// database with its snapshot what is not managed by ovnkube: the database
+// connection settings and the values northd and ovn-controller fill in
+var nbRebuildCompareOptions = libovsdb.CompareOptions{
+	IgnoredTables:   []string{nbdb.ConnectionTable, nbdb.SSLTable},
+	SingletonTables: []string{nbdb.NBGlobalTable},
+	IgnoredColumns: map[string][]string{
+		nbdb.NBGlobalTable: {
+			"connections", "ssl",
+			"nb_cfg", "nb_cfg_timestamp", "sb_cfg", "sb_cfg_timestamp", "hv_cfg", "hv_cfg_timestamp",
+			"options:mac_prefix", "options:svc_monitor_mac", "options:max_tunid", "options:northd_internal_version",
+		},
+		nbdb.LogicalSwitchPortTable: {"up", "dynamic_addresses"},
+	},
+}
+
+// nbRebuildSyncPollInterval is how often the sync of the default network is
+// checked before reporting the NB database rebuild
+const nbRebuildSyncPollInterval = 5 * time.Second
+
+// nbRebuildCheckedTables are the tables that must be empty for the NB
+// database to be rebuilt
+var nbRebuildCheckedTables = []string{
+	nbdb.LogicalRouterTable,
+	nbdb.LogicalSwitchTable,
+	nbdb.PortGroupTable,
+	nbdb.AddressSetTable,
+	nbdb.ACLTable,
+	nbdb.LoadBalancerTable,
+}
+
+// checkNBEmptyForRebuild makes sure the NB database does not hold any object
+// created by ovnkube, so that everything is rebuilt from the Kubernetes objects
+func (cm *networkControllerManager) checkNBEmptyForRebuild() error {
+	for _, table := range nbRebuildCheckedTables {
+		rows := cm.nbClient.Cache().Table(table)
+		if rows != nil && rows.Len() > 0 {
+			return fmt.Errorf("NB database rebuild requires an empty NB database, found %d rows in table %s",
+				rows.Len(), table)
+		}
+	}
+	klog.Infof("Rebuilding the NB database from the Kubernetes objects")
+	return nil
+}
+
+// reportNBRebuild waits for the controllers of the default network to sync
+// the objects they held on startup, then compares the rebuilt NB database
+// with the snapshot served at the configured address and reports the rows
+// that differ or are missing. The secondary networks are started by the NAD
+// controller workers and might still be syncing.
+func (cm *networkControllerManager) reportNBRebuild(hasSynced func() bool) error {
+	err := wait.PollImmediateUntil(nbRebuildSyncPollInterval, func() (bool, error) {
+		return hasSynced(), nil
+	}, cm.stopChan)
+	if err != nil {
+		return fmt.Errorf("stopped before the default network was synced: %v", err)
+	}
+
+	snapshotClient, err := libovsdb.NewNBClientWithConfig(config.NBRebuild.SnapshotAuth, prometheus.NewRegistry(), cm.stopChan)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the NB snapshot at %s: %v", config.NBRebuild.SnapshotAddress, err)
+	}
+	snapshot := libovsdb.CacheRows(snapshotClient)
+	snapshotClient.Close()
+
+	diff := libovsdb.CompareWithSnapshot(cm.nbClient.Cache().DatabaseModel(), snapshot, libovsdb.CacheRows(cm.nbClient),
+		nbRebuildCompareOptions)
+	klog.Infof("Rebuilt NB database compared with the snapshot at %s: %s", config.NBRebuild.SnapshotAddress, diff)
+
+	report, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return err
+	}
+	if config.NBRebuild.Report == "" {
+		klog.Infof("NB database rebuild report: %s", report)
+		return nil
+	}
+	if err := os.WriteFile(config.NBRebuild.Report, report, 0o644); err != nil {
+		return fmt.Errorf("failed to write NB database rebuild report %s: %v", config.NBRebuild.Report, err)
+	}
+	klog.Infof("NB database rebuild report written to %s", config.NBRebuild.Report)
+	return nil
+}
@@ -0,0 +1,94 @@
+package networkControllerManager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+var _ = Describe("NB database rebuild", func() {
+	var (
+		cm        *networkControllerManager
+		cleanup   *libovsdbtest.Cleanup
+		reportDir string
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+
+		snapshotClient, snapshotCleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				&nbdb.LogicalSwitch{UUID: "node1-UUID", Name: "node1"},
+			},
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		cleanup = snapshotCleanup
+		nbClient, _, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{}, cleanup)
+		Expect(err).NotTo(HaveOccurred())
+
+		reportDir, err = os.MkdirTemp("", "nb-rebuild")
+		Expect(err).NotTo(HaveOccurred())
+		config.NBRebuild.Enabled = true
+		config.NBRebuild.SnapshotAddress = snapshotClient.CurrentEndpoint()
+		config.NBRebuild.SnapshotAuth = config.OvnAuthConfig{
+			Scheme:  config.OvnDBSchemeUnix,
+			Address: snapshotClient.CurrentEndpoint(),
+		}
+		config.NBRebuild.Report = filepath.Join(reportDir, "report.json")
+
+		cm = &networkControllerManager{
+			nbClient: nbClient,
+			stopChan: make(chan struct{}),
+		}
+	})
+
+	AfterEach(func() {
+		close(cm.stopChan)
+		cleanup.Cleanup()
+		os.RemoveAll(reportDir)
+	})
+
+	It("reports the rows created once the default network is synced", func() {
+		var synced uint32
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- cm.reportNBRebuild(func() bool { return atomic.LoadUint32(&synced) == 1 })
+		}()
+
+		// the controllers of the default network create the rows after the
+		// manager started
+		Consistently(errCh, 2*time.Second).ShouldNot(Receive())
+		Expect(config.NBRebuild.Report).NotTo(BeAnExistingFile())
+		Expect(libovsdbops.CreateOrUpdateLogicalSwitch(cm.nbClient, &nbdb.LogicalSwitch{Name: "node1"})).To(Succeed())
+		atomic.StoreUint32(&synced, 1)
+
+		Eventually(errCh, 2*nbRebuildSyncPollInterval).Should(Receive(BeNil()))
+		report, err := os.ReadFile(config.NBRebuild.Report)
+		Expect(err).NotTo(HaveOccurred())
+		diff := &libovsdb.SnapshotDiff{}
+		Expect(json.Unmarshal(report, diff)).To(Succeed())
+		Expect(diff.Empty()).To(BeTrue(), string(report))
+	})
+
+	It("reports the rows missing from the rebuilt database", func() {
+		Expect(cm.reportNBRebuild(func() bool { return true })).To(Succeed())
+		report, err := os.ReadFile(config.NBRebuild.Report)
+		Expect(err).NotTo(HaveOccurred())
+		diff := &libovsdb.SnapshotDiff{}
+		Expect(json.Unmarshal(report, diff)).To(Succeed())
+		Expect(diff.Tables).To(Equal([]libovsdb.TableDiff{
+			{Table: nbdb.LogicalSwitchTable, Missing: []string{"name=node1"}},
+		}))
+	})
+})
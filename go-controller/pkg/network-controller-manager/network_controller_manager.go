@@ -21,6 +21,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
@@ -171,6 +172,26 @@ func (cm *networkControllerManager) CleanupDeletedNetworks(allControllers []nad.
 	return nil
 }
 
+// ReportNetAttachDefError implements the networkAttachDefController.NetAttachDefErrorReporter
+// interface function. It reports the error in the network status annotation of the NAD.
+func (cm *networkControllerManager) ReportNetAttachDefError(nadName string, err error) {
+	namespace, name, splitErr := cache.SplitMetaNamespaceKey(nadName)
+	if splitErr != nil {
+		klog.Errorf("Failed to report the status of net-attach-def %s: %v", nadName, splitErr)
+		return
+	}
+	status, marshalErr := util.MarshalNetworkStatus(&util.NetworkStatus{Error: err.Error()})
+	if marshalErr != nil {
+		klog.Errorf("Failed to report the status of net-attach-def %s: %v", nadName, marshalErr)
+		return
+	}
+	if patchErr := cm.kube.SetAnnotationsOnNAD(namespace, name, map[string]interface{}{
+		util.OvnNetworkStatusAnnotation: status,
+	}); patchErr != nil {
+		klog.Errorf("Failed to report the status of net-attach-def %s: %v", nadName, patchErr)
+	}
+}
+
 // NewNetworkControllerManager creates a new OVN controller manager to manage all the controller for all networks
 func NewNetworkControllerManager(ovnClient *util.OVNClientset, identity string, wf *factory.WatchFactory,
 	libovsdbOvnNBClient libovsdbclient.Client, libovsdbOvnSBClient libovsdbclient.Client,
@@ -184,6 +205,7 @@ func NewNetworkControllerManager(ovnClient *util.OVNClientset, identity string,
 			EIPClient:            ovnClient.EgressIPClient,
 			EgressFirewallClient: ovnClient.EgressFirewallClient,
 			CloudNetworkClient:   ovnClient.CloudNetworkClient,
+			NADClient:            ovnClient.NetworkAttchDefClient,
 		},
 		stopChan:     make(chan struct{}),
 		watchFactory: wf,
@@ -257,6 +279,20 @@ func (cm *networkControllerManager) enableOVNLogicalDataPathGroups() error {
 	return nil
 }
 
+// configureOVNIPsec sets or unsets the OVN flag to encrypt the tunnel traffic
+// between chassis with IPsec, so that disabling IPsec in the config disables
+// it in OVN too. The nodes configure the IPsec certificates of their chassis
+// in OVS.
+func (cm *networkControllerManager) configureOVNIPsec() error {
+	nbGlobal := nbdb.NBGlobal{
+		Ipsec: config.OVNKubernetesFeature.EnableIPsec,
+	}
+	if err := libovsdbops.UpdateNBGlobalSetIpsec(cm.nbClient, &nbGlobal); err != nil {
+		return fmt.Errorf("failed to set NB global ipsec to %t: %v", nbGlobal.Ipsec, err)
+	}
+	return nil
+}
+
 func (cm *networkControllerManager) configureMetrics(stopChan <-chan struct{}) {
 	metrics.RegisterMasterPerformance(cm.nbClient)
 	metrics.RegisterMasterFunctional()
@@ -286,6 +322,11 @@ func (cm *networkControllerManager) initDefaultNetworkController() error {
 // Start the network controller manager
 func (cm *networkControllerManager) Start(ctx context.Context) error {
 	klog.Info("Starting the network controller manager")
+	if config.NBRebuild.Enabled {
+		if err := cm.checkNBEmptyForRebuild(); err != nil {
+			return err
+		}
+	}
 	cm.configureMetrics(cm.stopChan)
 
 	err := cm.configureSCTPSupport()
@@ -301,6 +342,11 @@ func (cm *networkControllerManager) Start(ctx context.Context) error {
 		return err
 	}
 
+	err = cm.configureOVNIPsec()
+	if err != nil {
+		return err
+	}
+
 	if config.Metrics.EnableConfigDuration {
 		// with k=10,
 		//  for a cluster with 10 nodes, measurement of 1 in every 100 requests
@@ -325,7 +371,20 @@ func (cm *networkControllerManager) Start(ctx context.Context) error {
 
 	// nadController is nil if multi-network is disabled
 	if cm.nadController != nil {
-		return cm.nadController.Start()
+		if err = cm.nadController.Start(); err != nil {
+			return err
+		}
+	}
+
+	if config.NBRebuild.Enabled {
+		hasSynced := cm.defaultNetworkController.(*ovn.DefaultNetworkController).HasSynced
+		cm.wg.Add(1)
+		go func() {
+			defer cm.wg.Done()
+			if err := cm.reportNBRebuild(hasSynced); err != nil {
+				klog.Errorf("Failed to report the NB database rebuild: %v", err)
+			}
+		}()
 	}
 
 	return nil
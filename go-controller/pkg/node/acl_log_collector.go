@@ -0,0 +1,296 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/nxadm/tail"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// aclLogRegex matches the ACL log messages of ovn-controller, e.g.
+// 2023-03-20T10:11:12.345Z|00012|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:Ingress", verdict=drop, severity=alert,
+// direction=to-lport: tcp,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:01,dl_dst=0a:58:0a:f4:01:03,nw_src=10.244.1.1,...
+var aclLogRegex = regexp.MustCompile(`^(\S+)\|\d+\|acl_log\([^)]*\)\|\w+\|name="([^"]*)", verdict=(\w+), severity=(\w+)(?:, direction=([\w-]+))?: (.*)$`)
+
+// aclLogMessage is an ACL log message of ovn-controller, with the Kubernetes
+// objects it is about
+type aclLogMessage struct {
+	Time      string `json:"time"`
+	Node      string `json:"node"`
+	ACL       string `json:"acl"`
+	Verdict   string `json:"verdict"`
+	Severity  string `json:"severity"`
+	Direction string `json:"direction,omitempty"`
+	observability.ObservationPoint
+	Protocol string `json:"protocol,omitempty"`
+	SrcIP    string `json:"srcIP,omitempty"`
+	DstIP    string `json:"dstIP,omitempty"`
+	SrcPort  string `json:"srcPort,omitempty"`
+	DstPort  string `json:"dstPort,omitempty"`
+	// SrcPod and DstPod are the <namespace>/<name> of the pods of the node
+	// with the source and destination IPs
+	SrcPod string `json:"srcPod,omitempty"`
+	DstPod string `json:"dstPod,omitempty"`
+}
+
+// parseACLLogMessage parses an ACL log message of ovn-controller, or returns
+// nil if the line isn't one
+func parseACLLogMessage(line string) *aclLogMessage {
+	subMatches := aclLogRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if subMatches == nil {
+		return nil
+	}
+	msg := &aclLogMessage{
+		Time:      subMatches[1],
+		ACL:       subMatches[2],
+		Verdict:   subMatches[3],
+		Severity:  subMatches[4],
+		Direction: subMatches[5],
+		// the ACL name is built from its external IDs
+		ObservationPoint: observability.ParseACLName(subMatches[2]),
+	}
+	// the flow is <protocol>,<field>=<value>,...
+	for i, field := range strings.Split(subMatches[6], ",") {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			if i == 0 {
+				msg.Protocol = key
+			}
+			continue
+		}
+		switch key {
+		case "nw_src", "ipv6_src":
+			msg.SrcIP = value
+		case "nw_dst", "ipv6_dst":
+			msg.DstIP = value
+		case "tp_src":
+			msg.SrcPort = value
+		case "tp_dst":
+			msg.DstPort = value
+		}
+	}
+	return msg
+}
+
+// aclLogCollector tails the log of ovn-controller for ACL log messages and
+// exposes them as JSON lines, syslog messages and metrics
+type aclLogCollector struct {
+	nodeName     string
+	source       string
+	watchFactory factory.NodeWatchFactory
+	out          io.Writer
+	syslog       io.Writer
+	// flows exports the drops, if enabled
+	flows *flowExporter
+
+	// podNamesLock protects podNames
+	podNamesLock sync.RWMutex
+	// podNames are the <namespace>/<name> of the pods of the node by IP,
+	// kept up to date by a pod handler
+	podNames map[string]string
+}
+
+// newACLLogCollector returns the ACL log collector configured, or nil if
+// none is
+func newACLLogCollector(nodeName string, wf factory.NodeWatchFactory, flows *flowExporter) (*aclLogCollector, error) {
+	if config.Logging.ACLLogCollectorSource == "" {
+		return nil, nil
+	}
+	c := &aclLogCollector{
+		nodeName:     nodeName,
+		source:       config.Logging.ACLLogCollectorSource,
+		watchFactory: wf,
+		flows:        flows,
+		podNames:     map[string]string{},
+	}
+	if config.Logging.ACLLogCollectorFile != "" {
+		file, err := os.OpenFile(config.Logging.ACLLogCollectorFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ACL log collector file: %w", err)
+		}
+		c.out = file
+	}
+	if config.Logging.ACLLogCollectorSyslog != "" {
+		network, address, found := strings.Cut(config.Logging.ACLLogCollectorSyslog, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid ACL log collector syslog server %q, expected <network>:<address>",
+				config.Logging.ACLLogCollectorSyslog)
+		}
+		writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_LOCAL0, "ovnkube-acl-log")
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to ACL log collector syslog server: %w", err)
+		}
+		c.syslog = writer
+	}
+	return c, nil
+}
+
+// Run tails the log of ovn-controller until stopChan is closed, from its end
+// and across rotations
+func (c *aclLogCollector) Run(stopChan <-chan struct{}) {
+	podHandler, err := c.watchPods()
+	if err != nil {
+		klog.Errorf("Failed to watch pods for ACL log messages: %v", err)
+		return
+	}
+	defer c.watchFactory.RemovePodHandler(podHandler)
+	t, err := tail.TailFile(c.source, tail.Config{
+		Location:  &tail.SeekInfo{Whence: io.SeekEnd},
+		ReOpen:    true,
+		MustExist: false,
+		Follow:    true,
+		Logger:    tail.DiscardingLogger,
+	})
+	if err != nil {
+		klog.Errorf("Failed to tail %s for ACL log messages: %v", c.source, err)
+		return
+	}
+	defer t.Cleanup()
+	klog.Infof("Collecting ACL log messages from %s", c.source)
+	for {
+		select {
+		case <-stopChan:
+			if err := t.Stop(); err != nil {
+				klog.Warningf("Failed to stop tailing %s: %v", c.source, err)
+			}
+			return
+		case line, ok := <-t.Lines:
+			if !ok {
+				klog.Errorf("Stopped tailing %s for ACL log messages: %v", c.source, t.Err())
+				return
+			}
+			if line.Err != nil {
+				klog.Warningf("Failed to read %s: %v", c.source, line.Err)
+				continue
+			}
+			if msg := parseACLLogMessage(line.Text); msg != nil {
+				c.collect(msg)
+			}
+		}
+	}
+}
+
+// collect enriches the message with the pods of the node it is about and
+// exposes it
+func (c *aclLogCollector) collect(msg *aclLogMessage) {
+	msg.Node = c.nodeName
+	c.setPods(msg)
+	metrics.RecordACLLogMessage(msg.Verdict, msg.OwnerType)
+	if c.flows != nil && msg.Verdict != "allow" {
+		c.flows.addDrop(msg)
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		klog.Errorf("Failed to marshal ACL log message %+v: %v", msg, err)
+		return
+	}
+	if c.out != nil {
+		if _, err := c.out.Write(append(b, '\n')); err != nil {
+			klog.Errorf("Failed to write ACL log message: %v", err)
+		}
+	}
+	if c.syslog != nil {
+		if _, err := c.syslog.Write(b); err != nil {
+			klog.Errorf("Failed to send ACL log message to syslog: %v", err)
+		}
+	}
+}
+
+// setPods sets the pods of the node that have the source and destination IPs
+// of the message. Pods of other nodes aren't known to the node.
+func (c *aclLogCollector) setPods(msg *aclLogMessage) {
+	c.podNamesLock.RLock()
+	defer c.podNamesLock.RUnlock()
+	msg.SrcPod, msg.DstPod = c.podNames[normalizeIP(msg.SrcIP)], c.podNames[normalizeIP(msg.DstIP)]
+}
+
+// watchPods keeps the names of the pods of the node by IP up to date
+func (c *aclLogCollector) watchPods() (*factory.Handler, error) {
+	return c.watchFactory.AddPodHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.setPodIPs(nil, obj.(*kapi.Pod))
+		},
+		UpdateFunc: func(old, newer interface{}) {
+			c.setPodIPs(old.(*kapi.Pod), newer.(*kapi.Pod))
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod, ok := obj.(*kapi.Pod)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					return
+				}
+				if pod, ok = tombstone.Obj.(*kapi.Pod); !ok {
+					return
+				}
+			}
+			c.setPodIPs(pod, nil)
+		},
+	}, nil)
+}
+
+// setPodIPs replaces the IPs of the old version of a pod with the ones of
+// the new version, either of which may be nil
+func (c *aclLogCollector) setPodIPs(old, newer *kapi.Pod) {
+	c.podNamesLock.Lock()
+	defer c.podNamesLock.Unlock()
+	if old != nil {
+		name := old.Namespace + "/" + old.Name
+		for _, podIP := range old.Status.PodIPs {
+			// the IP may have been given to another pod since
+			if ip := normalizeIP(podIP.IP); c.podNames[ip] == name {
+				delete(c.podNames, ip)
+			}
+		}
+	}
+	if newer != nil && !newer.Spec.HostNetwork {
+		for _, podIP := range newer.Status.PodIPs {
+			c.podNames[normalizeIP(podIP.IP)] = newer.Namespace + "/" + newer.Name
+		}
+	}
+}
+
+// getLocalPodNamesByIP returns the <namespace>/<name> of the pods of the node
+// by IP
+func getLocalPodNamesByIP(wf factory.NodeWatchFactory) (map[string]string, error) {
+	pods, err := wf.GetPods(metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+	podNames := map[string]string{}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		for _, podIP := range pod.Status.PodIPs {
+			podNames[normalizeIP(podIP.IP)] = pod.Namespace + "/" + pod.Name
+		}
+	}
+	return podNames, nil
+}
+
+// normalizeIP returns the canonical form of an IP, or the string as is if it
+// isn't one
+func normalizeIP(s string) string {
+	if ip := utilnet.ParseIPSloppy(s); ip != nil {
+		return ip.String()
+	}
+	return s
+}
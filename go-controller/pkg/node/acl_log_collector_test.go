@@ -0,0 +1,110 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("ACL log collector", func() {
+	It("parses ACL log messages of NetworkPolicy rules", func() {
+		msg := parseACLLogMessage(`2023-03-20T10:11:12.345Z|00012|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:deny-web:Ingress:0", ` +
+			`verdict=drop, severity=alert, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:01,` +
+			`dl_dst=0a:58:0a:f4:01:03,nw_src=10.244.1.1,nw_dst=10.244.1.3,nw_tos=0,nw_ecn=0,nw_ttl=64,tp_src=41532,tp_dst=8080,tcp_flags=syn`)
+		Expect(msg).To(Equal(&aclLogMessage{
+			Time:      "2023-03-20T10:11:12.345Z",
+			ACL:       "NP:ns1:deny-web:Ingress:0",
+			Verdict:   "drop",
+			Severity:  "alert",
+			Direction: "to-lport",
+			ObservationPoint: observability.ObservationPoint{
+				OwnerType:       "NetworkPolicy",
+				Namespace:       "ns1",
+				Policy:          "deny-web",
+				PolicyDirection: "Ingress",
+				Rule:            "0",
+			},
+			Protocol: "tcp",
+			SrcIP:    "10.244.1.1",
+			DstIP:    "10.244.1.3",
+			SrcPort:  "41532",
+			DstPort:  "8080",
+		}))
+	})
+
+	It("parses ACL log messages of namespace default deny and EgressFirewall rules", func() {
+		msg := parseACLLogMessage(`2023-03-20T10:11:12.345Z|00013|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:Egress", ` +
+			`verdict=drop, severity=warning, direction=from-lport: icmp6,vlan_tci=0x0000,ipv6_src=fd00:10:244:1::3,ipv6_dst=fd00:10:244:2::4`)
+		Expect(msg).NotTo(BeNil())
+		Expect(msg.OwnerType).To(Equal("NetpolNamespace"))
+		Expect(msg.Namespace).To(Equal("ns1"))
+		Expect(msg.Policy).To(BeEmpty())
+		Expect(msg.PolicyDirection).To(Equal("Egress"))
+		Expect(msg.Protocol).To(Equal("icmp6"))
+		Expect(msg.SrcIP).To(Equal("fd00:10:244:1::3"))
+		Expect(msg.DstIP).To(Equal("fd00:10:244:2::4"))
+
+		msg = parseACLLogMessage(`2023-03-20T10:11:12.345Z|00014|acl_log(ovn_pinctrl0)|INFO|name="EF:ns2:3", ` +
+			`verdict=allow, severity=info: udp,nw_src=10.244.1.5,nw_dst=8.8.8.8,tp_src=5353,tp_dst=53`)
+		Expect(msg).NotTo(BeNil())
+		Expect(msg.OwnerType).To(Equal("EgressFirewall"))
+		Expect(msg.Namespace).To(Equal("ns2"))
+		Expect(msg.Rule).To(Equal("3"))
+		Expect(msg.Direction).To(BeEmpty())
+	})
+
+	It("ignores other log messages", func() {
+		Expect(parseACLLogMessage(`2023-03-20T10:11:12.345Z|00015|binding|INFO|Claiming lport ns1_pod1 for this chassis.`)).To(BeNil())
+	})
+
+	It("attributes ACL log messages to the pods of the node", func() {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"},
+			Spec:       v1.PodSpec{NodeName: "node1"},
+			Status: v1.PodStatus{
+				PodIP:  "10.244.1.3",
+				PodIPs: []v1.PodIP{{IP: "10.244.1.3"}},
+			},
+		}
+		fakeClient := fake.NewSimpleClientset(&v1.PodList{Items: []v1.Pod{*pod}})
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		out := &bytes.Buffer{}
+		c := &aclLogCollector{nodeName: "node1", watchFactory: wf, out: out, podNames: map[string]string{}}
+		_, err = c.watchPods()
+		Expect(err).NotTo(HaveOccurred())
+		c.collect(parseACLLogMessage(`2023-03-20T10:11:12.345Z|00012|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:deny-web:Ingress:0", ` +
+			`verdict=drop, severity=alert, direction=to-lport: tcp,nw_src=10.244.2.1,nw_dst=10.244.1.3,tp_src=41532,tp_dst=8080`))
+
+		var msg aclLogMessage
+		Expect(json.Unmarshal(out.Bytes(), &msg)).To(Succeed())
+		Expect(msg.Node).To(Equal("node1"))
+		Expect(msg.Policy).To(Equal("deny-web"))
+		Expect(msg.SrcPod).To(BeEmpty())
+		Expect(msg.DstPod).To(Equal("ns1/pod1"))
+
+		// the IP of a deleted pod is given to another pod
+		newPod := pod.DeepCopy()
+		newPod.Name = "pod2"
+		c.setPodIPs(nil, newPod)
+		c.setPodIPs(pod, nil)
+		out.Reset()
+		c.collect(parseACLLogMessage(`2023-03-20T10:11:13.345Z|00013|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:deny-web:Ingress:0", ` +
+			`verdict=drop, severity=alert, direction=to-lport: tcp,nw_src=10.244.2.1,nw_dst=10.244.1.3,tp_src=41533,tp_dst=8080`))
+		Expect(json.Unmarshal(out.Bytes(), &msg)).To(Succeed())
+		Expect(msg.DstPod).To(Equal("ns1/pod2"))
+	})
+})
@@ -3,11 +3,14 @@ package node
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/vishvananda/netlink"
 	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -20,11 +23,28 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// representorCheckInterval is the interval at which the representors of the served pods are checked, to reconfigure
+// the ones that were hot-replaced
+const representorCheckInterval = 5 * time.Second
+
+// servedPod is a pod whose representor was added to the ovs bridge
+type servedPod struct {
+	namespace string
+	name      string
+	// repName is the name of the representor that was added to the ovs bridge
+	repName string
+	// repMissing is set while the representor of the pod is gone
+	repMissing bool
+}
+
 // watchPodsDPU watch updates for pod dpu annotations
 func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 	var retryPods sync.Map
-	// servedPods tracks the pods that got a VF
+	// servedPods tracks the pods that got a VF or a SF, mapping their UID to their servedPod
 	var servedPods sync.Map
+	// servedPodsLock serializes the removal of the representors of the deleted pods with the commit of their
+	// reconfiguration, it is not held while the representors are reconfigured
+	var servedPodsLock sync.Mutex
 
 	clientSet := cni.NewClientSet(bnnc.client, corev1listers.NewPodLister(bnnc.watchFactory.LocalPodInformer().GetIndexer()))
 
@@ -44,10 +64,11 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 					return
 				}
 
-				vfRepName, err := bnnc.getVfRepName(pod)
+				repName, err := bnnc.getRepName(pod)
 				if err != nil {
 					klog.Infof("Failed to get rep name, %s. retrying", err)
 					retryPods.Store(pod.UID, true)
+					bnnc.setPodDPUConnRepError(pod, err)
 					return
 				}
 				isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
@@ -57,12 +78,13 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 					retryPods.Store(pod.UID, true)
 					return
 				}
-				err = bnnc.addRepPort(pod, vfRepName, podInterfaceInfo, clientSet)
+				err = bnnc.addRepPort(pod, repName, podInterfaceInfo, clientSet)
 				if err != nil {
 					klog.Infof("Failed to add rep port, %s. retrying", err)
 					retryPods.Store(pod.UID, true)
+					bnnc.setPodDPUConnError(pod, err)
 				} else {
-					servedPods.Store(pod.UID, true)
+					servedPods.Store(pod.UID, &servedPod{namespace: pod.Namespace, name: pod.Name, repName: repName})
 				}
 			} else {
 				// Handle unscheduled pods later in UpdateFunc
@@ -83,9 +105,10 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 					retryPods.Delete(pod.UID)
 					return
 				}
-				vfRepName, err := bnnc.getVfRepName(pod)
+				repName, err := bnnc.getRepName(pod)
 				if err != nil {
 					klog.Infof("Failed to get rep name, %s. retrying", err)
+					bnnc.setPodDPUConnRepError(pod, err)
 					return
 				}
 				isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
@@ -94,11 +117,12 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 				if err != nil {
 					return
 				}
-				err = bnnc.addRepPort(pod, vfRepName, podInterfaceInfo, clientSet)
+				err = bnnc.addRepPort(pod, repName, podInterfaceInfo, clientSet)
 				if err != nil {
 					klog.Infof("Failed to add rep port, %s. retrying", err)
+					bnnc.setPodDPUConnError(pod, err)
 				} else {
-					servedPods.Store(pod.UID, true)
+					servedPods.Store(pod.UID, &servedPod{namespace: pod.Namespace, name: pod.Name, repName: repName})
 					retryPods.Delete(pod.UID)
 				}
 			}
@@ -106,34 +130,142 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
 		DeleteFunc: func(obj interface{}) {
 			pod := obj.(*kapi.Pod)
 			klog.Infof("Delete for Pod: %s/%s", pod.ObjectMeta.GetNamespace(), pod.ObjectMeta.GetName())
-			if _, ok := servedPods.Load(pod.UID); !ok {
+			servedPodsLock.Lock()
+			defer servedPodsLock.Unlock()
+			served, ok := servedPods.LoadAndDelete(pod.UID)
+			if !ok {
 				return
 			}
-			servedPods.Delete(pod.UID)
 			retryPods.Delete(pod.UID)
-			vfRepName, err := bnnc.getVfRepName(pod)
-			if err != nil {
-				klog.Errorf("Failed to get VF Representor Name from Pod: %s. Representor port may have been deleted.", err)
-				return
-			}
-			err = bnnc.delRepPort(vfRepName)
+			// the representor may have been replaced since it was added, remove the one that was added
+			repName := served.(*servedPod).repName
+			err := bnnc.delRepPort(repName)
 			if err != nil {
-				klog.Errorf("Failed to delete VF representor %s. %s", vfRepName, err)
+				klog.Errorf("Failed to delete representor %s. %s", repName, err)
 			}
 		},
 	}, nil)
-	return err
+	if err != nil {
+		return err
+	}
+
+	bnnc.wg.Add(1)
+	go func() {
+		defer bnnc.wg.Done()
+		wait.Until(func() {
+			bnnc.checkRepPorts(&servedPods, &servedPodsLock, clientSet)
+		}, representorCheckInterval, bnnc.stopChan)
+	}()
+
+	bnnc.runDPUStatus()
+	return nil
 }
 
-// getVfRepName returns the VF's representor of the VF assigned to the pod
-func (bnnc *BaseNodeNetworkController) getVfRepName(pod *kapi.Pod) (string, error) {
+// getRepName returns the representor of the VF or the SF assigned to the pod
+func (bnnc *BaseNodeNetworkController) getRepName(pod *kapi.Pod) (string, error) {
 	dpuCD, err := util.UnmarshalPodDPUConnDetails(pod.Annotations, types.DefaultNetworkName)
 	if err != nil {
 		return "", fmt.Errorf("failed to get dpu annotation for pod %s/%s: %v", pod.Namespace, pod.Name, err)
 	}
+	if dpuCD.IsSF() {
+		return util.GetSriovnetOps().GetSfRepresentorDPU(dpuCD.PfId, dpuCD.SfId)
+	}
 	return util.GetSriovnetOps().GetVfRepresentorDPU(dpuCD.PfId, dpuCD.VfId)
 }
 
+// checkRepPorts checks the representors of a snapshot of the served pods without holding servedPodsLock, so that
+// the deletion of the pods is not blocked while their representors are reconfigured. The new state of a pod is only
+// committed if the pod is still served, otherwise the representor reconfigured for it meanwhile is removed.
+func (bnnc *BaseNodeNetworkController) checkRepPorts(servedPods *sync.Map, servedPodsLock *sync.Mutex,
+	getter cni.PodInfoGetter) {
+	type snapshot struct {
+		uid    ktypes.UID
+		served *servedPod
+		state  servedPod
+	}
+	var snapshots []snapshot
+	servedPodsLock.Lock()
+	servedPods.Range(func(key, value interface{}) bool {
+		served := value.(*servedPod)
+		snapshots = append(snapshots, snapshot{uid: key.(ktypes.UID), served: served, state: *served})
+		return true
+	})
+	servedPodsLock.Unlock()
+
+	for _, snap := range snapshots {
+		checked, repaired := bnnc.checkRepPort(snap.uid, snap.state, getter)
+		if checked == snap.state && !repaired {
+			continue
+		}
+		servedPodsLock.Lock()
+		if served, ok := servedPods.Load(snap.uid); ok {
+			// the pod may have been served again meanwhile, then its new state wins
+			if served.(*servedPod) == snap.served {
+				*snap.served = checked
+			}
+		} else if repaired {
+			// the pod was deleted while its representor was reconfigured, and only the old one was removed
+			if err := bnnc.delRepPort(checked.repName); err != nil {
+				klog.Errorf("Failed to delete representor %s. %s", checked.repName, err)
+			}
+		}
+		servedPodsLock.Unlock()
+	}
+}
+
+// checkRepPort reconfigures the representor of a served pod once it reappears after it was hot-replaced, for
+// instance when the VF or SF of the pod was reset on the host. The new representor comes up down, without the MTU
+// of the pod and possibly with another name, and the ovs port of the old one is left without a device.
+// It returns the new state of the served pod and whether its representor was reconfigured.
+func (bnnc *BaseNodeNetworkController) checkRepPort(podUID ktypes.UID, served servedPod,
+	getter cni.PodInfoGetter) (servedPod, bool) {
+	pod, err := bnnc.watchFactory.GetPod(served.namespace, served.name)
+	if err != nil || pod.UID != podUID {
+		// the pod is being deleted
+		return served, false
+	}
+
+	repName, err := bnnc.getRepName(pod)
+	var link netlink.Link
+	if err == nil {
+		link, err = util.GetNetLinkOps().LinkByName(repName)
+	}
+	if err != nil {
+		if !served.repMissing {
+			klog.Warningf("Representor %s of pod %s/%s is gone, waiting for it to reappear: %v",
+				served.repName, served.namespace, served.name, err)
+			served.repMissing = true
+		}
+		return served, false
+	}
+	if repName == served.repName && !served.repMissing && link.Attrs().Flags&net.FlagUp != 0 {
+		return served, false
+	}
+
+	klog.Infof("Representor %s of pod %s/%s reappeared as %s, reconfiguring it", served.repName, served.namespace,
+		served.name, repName)
+	if err := bnnc.delRepPort(served.repName); err != nil {
+		klog.Errorf("Failed to delete representor %s. %s", served.repName, err)
+		return served, false
+	}
+	isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
+	podInterfaceInfo, err := cni.PodAnnotation2PodInfo(pod.Annotations, nil, isOvnUpEnabled, string(pod.UID),
+		"", types.DefaultNetworkName, types.DefaultNetworkName, config.Default.MTU)
+	if err != nil {
+		klog.Errorf("Failed to get interface info of pod %s/%s: %v", served.namespace, served.name, err)
+		return served, false
+	}
+	if err := bnnc.addRepPort(pod, repName, podInterfaceInfo, getter); err != nil {
+		klog.Errorf("Failed to add representor %s of pod %s/%s, retrying: %v", repName, served.namespace,
+			served.name, err)
+		bnnc.setPodDPUConnError(pod, err)
+		return served, false
+	}
+	served.repName = repName
+	served.repMissing = false
+	return served, true
+}
+
 // updatePodDPUConnStatusWithRetry update the pod annotion with the givin connection details
 func (bnnc *BaseNodeNetworkController) updatePodDPUConnStatusWithRetry(origPod *kapi.Pod,
 	dpuConnStatus *util.DPUConnectionStatus) error {
@@ -157,7 +289,29 @@ func (bnnc *BaseNodeNetworkController) updatePodDPUConnStatusWithRetry(origPod *
 	return nil
 }
 
-// addRepPort adds the representor of the VF to the ovs bridge
+// setPodDPUConnError reports the failure to plug the representor of the pod into the ovs bridge in the
+// connection-status annotation of the pod, so that the CNI on the host can surface it, unless it is already reported
+func (bnnc *BaseNodeNetworkController) setPodDPUConnError(pod *kapi.Pod, err error) {
+	reason := err.Error()
+	status, statusErr := util.UnmarshalPodDPUConnStatus(pod.Annotations, types.DefaultNetworkName)
+	if statusErr == nil && status.Status == util.DPUConnectionStatusError && status.Reason == reason {
+		return
+	}
+	connStatus := util.NewDPUConnectionStatus(util.DPUConnectionStatusError, reason)
+	if statusErr := bnnc.updatePodDPUConnStatusWithRetry(pod, connStatus); statusErr != nil {
+		klog.Errorf("Failed to report error of pod %s/%s: %v", pod.Namespace, pod.Name, statusErr)
+	}
+}
+
+// setPodDPUConnRepError reports the failure to find the representor of the pod, once the CNI on the host set the
+// connection-details annotation of the pod
+func (bnnc *BaseNodeNetworkController) setPodDPUConnRepError(pod *kapi.Pod, err error) {
+	if _, ok := pod.Annotations[util.DPUConnectionDetailsAnnot]; ok {
+		bnnc.setPodDPUConnError(pod, err)
+	}
+}
+
+// addRepPort adds the representor of the VF or the SF to the ovs bridge
 func (bnnc *BaseNodeNetworkController) addRepPort(pod *kapi.Pod, vfRepName string, ifInfo *cni.PodInterfaceInfo, getter cni.PodInfoGetter) error {
 	klog.Infof("Adding VF representor %s", vfRepName)
 	dpuCD, err := util.UnmarshalPodDPUConnDetails(pod.Annotations, types.DefaultNetworkName)
@@ -189,10 +343,8 @@ func (bnnc *BaseNodeNetworkController) addRepPort(pod *kapi.Pod, vfRepName strin
 		return fmt.Errorf("failed to setup representor port. failed to set link up for interface %s", vfRepName)
 	}
 
-	// Update connection-status annotation
-	// TODO(adrianc): we should update Status in case of error as well
-	connStatus := util.DPUConnectionStatus{Status: util.DPUConnectionStatusReady, Reason: ""}
-	err = bnnc.updatePodDPUConnStatusWithRetry(pod, &connStatus)
+	// Update connection-status annotation, failures are reported by the callers
+	err = bnnc.updatePodDPUConnStatusWithRetry(pod, util.NewDPUConnectionStatus(util.DPUConnectionStatusReady, ""))
 	if err != nil {
 		_ = util.GetNetLinkOps().LinkSetDown(link)
 		_ = bnnc.delRepPort(vfRepName)
@@ -201,7 +353,7 @@ func (bnnc *BaseNodeNetworkController) addRepPort(pod *kapi.Pod, vfRepName strin
 	return nil
 }
 
-// delRepPort delete the representor of the VF from the ovs bridge
+// delRepPort delete the representor of the VF or the SF from the ovs bridge
 func (bnnc *BaseNodeNetworkController) delRepPort(vfRepName string) error {
 	//TODO(adrianc): handle: clearPodBandwidth(pr.SandboxID), pr.deletePodConntrack()
 	klog.Infof("Delete VF representor %s port", vfRepName)
@@ -2,10 +2,13 @@ package node
 
 import (
 	"fmt"
+	"net"
+	"sync"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -34,10 +37,10 @@ func genOVSAddPortCmd(hostIfaceName, ifaceID, mac, ip, sandboxID, podUID string)
 	}
 	return fmt.Sprintf("ovs-vsctl --timeout=30 add-port br-int %s other_config:transient=true "+
 		"-- set interface %s external_ids:attached_mac=%s "+
-		"external_ids:iface-id=%s external_ids:iface-id-ver=%s %sexternal_ids:sandbox=%s "+
+		"external_ids:iface-id=%s external_ids:iface-id-ver=%s external_ids:sandbox=%s %s"+
 		"-- --if-exists remove interface %s external_ids k8s.ovn.org/network "+
 		"-- --if-exists remove interface %s external_ids k8s.ovn.org/nad",
-		hostIfaceName, hostIfaceName, mac, ifaceID, podUID, ipAddrExtID, sandboxID, hostIfaceName, hostIfaceName)
+		hostIfaceName, hostIfaceName, mac, ifaceID, podUID, sandboxID, ipAddrExtID, hostIfaceName, hostIfaceName)
 }
 
 func genOVSAddPortCmdWithNetdev(hostIfaceName, netdev, ifaceID, mac, ip, sandboxID, podUID string) string {
@@ -47,10 +50,10 @@ func genOVSAddPortCmdWithNetdev(hostIfaceName, netdev, ifaceID, mac, ip, sandbox
 	}
 	return fmt.Sprintf("ovs-vsctl --timeout=30 add-port br-int %s other_config:transient=true "+
 		"-- set interface %s external_ids:attached_mac=%s "+
-		"external_ids:iface-id=%s external_ids:iface-id-ver=%s %sexternal_ids:sandbox=%s external_ids:vf-netdev-name=%s "+
+		"external_ids:iface-id=%s external_ids:iface-id-ver=%s external_ids:sandbox=%s %sexternal_ids:vf-netdev-name=%s "+
 		"-- --if-exists remove interface %s external_ids k8s.ovn.org/network "+
 		"-- --if-exists remove interface %s external_ids k8s.ovn.org/nad",
-		hostIfaceName, hostIfaceName, mac, ifaceID, podUID, ipAddrExtID, sandboxID, netdev, hostIfaceName, hostIfaceName)
+		hostIfaceName, hostIfaceName, mac, ifaceID, podUID, sandboxID, ipAddrExtID, netdev, hostIfaceName, hostIfaceName)
 }
 
 func genOVSDelPortCmd(portName string) string {
@@ -72,6 +75,14 @@ func genIfaceID(podNamespace, podName string) string {
 	return fmt.Sprintf("%s_%s", podNamespace, podName)
 }
 
+// podWithDPUConnStatus matches a pod with the given connection-status annotation, set with a timestamp
+func podWithDPUConnStatus(status, reason string) interface{} {
+	return mock.MatchedBy(func(pod *v1.Pod) bool {
+		dcs, err := util.UnmarshalPodDPUConnStatus(pod.Annotations, types.DefaultNetworkName)
+		return err == nil && dcs.Status == status && dcs.Reason == reason && dcs.Timestamp != ""
+	})
+}
+
 func newFakeKubeClientWithPod(pod *v1.Pod) *fake.Clientset {
 	return fake.NewSimpleClientset(&v1.PodList{Items: []v1.Pod{*pod}})
 }
@@ -128,19 +139,29 @@ var _ = Describe("Node DPU tests", func() {
 		util.ResetRunner()
 	})
 
-	Context("getVfRepName", func() {
+	Context("getRepName", func() {
 		It("gets VF representor based on dpu.connection-details Pod annotation", func() {
 			podAnnot := map[string]string{
 				util.DPUConnectionDetailsAnnot: `{"pfId":"0","vfId":"9","sandboxId":"a8d09931"}`,
 			}
 			pod.Annotations = podAnnot
 			sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("pf0vf9", nil)
-			rep, err := dnnc.getVfRepName(&pod)
+			rep, err := dnnc.getRepName(&pod)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(rep).To(Equal("pf0vf9"))
 		})
+		It("gets SF representor based on dpu.connection-details Pod annotation", func() {
+			podAnnot := map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"pfId":"0","sfId":"88","sandboxId":"a8d09931"}`,
+			}
+			pod.Annotations = podAnnot
+			sriovnetOpsMock.On("GetSfRepresentorDPU", "0", "88").Return("pf0sf88", nil)
+			rep, err := dnnc.getRepName(&pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rep).To(Equal("pf0sf88"))
+		})
 		It("Fails if dpu.connection-details annotation is missing from Pod", func() {
-			_, err := dnnc.getVfRepName(&pod)
+			_, err := dnnc.getRepName(&pod)
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -328,14 +349,8 @@ var _ = Describe("Node DPU tests", func() {
 				netlinkOpsMock.On("LinkByName", vfRep).Return(vfLink, nil)
 				netlinkOpsMock.On("LinkSetMTU", vfLink, ifInfo.MTU).Return(nil)
 				netlinkOpsMock.On("LinkSetUp", vfLink).Return(nil)
-				dcs := util.DPUConnectionStatus{
-					Status: "Ready",
-				}
 				factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(&pod, nil)
-				cpod := pod.DeepCopy()
-				cpod.Annotations, err = util.MarshalPodDPUConnStatus(cpod.Annotations, &dcs, types.DefaultNetworkName)
-				Expect(err).ToNot(HaveOccurred())
-				kubeMock.On("UpdatePod", cpod).Return(nil)
+				kubeMock.On("UpdatePod", podWithDPUConnStatus(util.DPUConnectionStatusReady, "")).Return(nil)
 
 				podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
 
@@ -349,14 +364,8 @@ var _ = Describe("Node DPU tests", func() {
 				netlinkOpsMock.On("LinkByName", vfRep).Return(vfLink, nil)
 				netlinkOpsMock.On("LinkSetMTU", vfLink, ifInfo.MTU).Return(nil)
 				netlinkOpsMock.On("LinkSetUp", vfLink).Return(nil)
-				dcs := util.DPUConnectionStatus{
-					Status: "Ready",
-				}
 				factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(&pod, nil)
-				cpod := pod.DeepCopy()
-				cpod.Annotations, err = util.MarshalPodDPUConnStatus(cpod.Annotations, &dcs, types.DefaultNetworkName)
-				Expect(err).ToNot(HaveOccurred())
-				kubeMock.On("UpdatePod", cpod).Return(fmt.Errorf("failed to set pod annotations"))
+				kubeMock.On("UpdatePod", podWithDPUConnStatus(util.DPUConnectionStatusReady, "")).Return(fmt.Errorf("failed to set pod annotations"))
 				// Mock netlink/ovs calls for cleanup
 				netlinkOpsMock.On("LinkSetDown", vfLink).Return(nil)
 				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
@@ -372,6 +381,160 @@ var _ = Describe("Node DPU tests", func() {
 		})
 	})
 
+	Context("checkRepPort", func() {
+		var vfLink *linkMock.Link
+		var served servedPod
+
+		BeforeEach(func() {
+			vfLink = &linkMock.Link{}
+			pod.Annotations = map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"pfId":"0","vfId":"9","sandboxId":"a8d09931"}`,
+				util.OvnPodAnnotationName: `{"default":{"ip_addresses":["10.244.0.5/24"],"mac_address":"0a:58:0a:f4:00:05",` +
+					`"gateway_ips":["10.244.0.1"],"ip_address":"10.244.0.5/24","gateway_ip":"10.244.0.1"}}`,
+			}
+			served = servedPod{namespace: pod.Namespace, name: pod.Name, repName: "pf0vf9"}
+			factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(&pod, nil)
+			sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("pf0vf9", nil)
+			clientset = cni.NewClientSet(newFakeKubeClientWithPod(&pod), &podLister)
+		})
+
+		It("Does nothing while the representor is up", func() {
+			vfLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "pf0vf9", Flags: net.FlagUp})
+			netlinkOpsMock.On("LinkByName", "pf0vf9").Return(vfLink, nil)
+			checked, repaired := dnnc.checkRepPort(pod.UID, served, clientset)
+			Expect(repaired).To(BeFalse())
+			Expect(checked).To(Equal(served))
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+		})
+
+		It("Waits for a representor that is gone", func() {
+			netlinkOpsMock.On("LinkByName", "pf0vf9").Return(nil, fmt.Errorf("link not found"))
+			checked, repaired := dnnc.checkRepPort(pod.UID, served, clientset)
+			Expect(repaired).To(BeFalse())
+			Expect(checked.repMissing).To(BeTrue())
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+		})
+
+		// expectRepair expects the removal of the stale port of the pod and the addition of its representor
+		expectRepair := func() {
+			vfLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "pf0vf9"})
+			netlinkOpsMock.On("LinkByName", "pf0vf9").Return(vfLink, nil)
+			// delRepPort of the stale port
+			netlinkOpsMock.On("LinkSetDown", vfLink).Return(nil)
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSDelPortCmd("pf0vf9"),
+			})
+			// addRepPort
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSFindCmd("Interface", "_uuid",
+					"external-ids:iface-id="+genIfaceID(pod.Namespace, pod.Name)),
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSAddPortCmd("pf0vf9", genIfaceID(pod.Namespace, pod.Name), "0a:58:0a:f4:00:05",
+					"10.244.0.5/24", "a8d09931", string(pod.UID)),
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSFindCmd("interface", "name", "external-ids:sandbox=a8d09931"),
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSFindCmd("qos", "_uuid", "external-ids:sandbox=a8d09931"),
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genOVSGetCmd("Interface", "pf0vf9", "ofport", ""),
+				Output: "1",
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genOVSGetCmd("Interface", "pf0vf9", "external-ids", "iface-id"),
+				Output: genIfaceID(pod.Namespace, pod.Name),
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genOfctlDumpFlowsCmd("table=9,dl_src=0a:58:0a:f4:00:05"),
+				Output: "non-empty-output",
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genOfctlDumpFlowsCmd("table=0,in_port=1"),
+				Output: "non-empty-output",
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genOfctlDumpFlowsCmd("table=48,ip,ip_dst=10.244.0.5"),
+				Output: "non-empty-output",
+			})
+			netlinkOpsMock.On("LinkSetMTU", vfLink, 1400).Return(nil)
+			kubeMock.On("UpdatePod", mock.AnythingOfType("*v1.Pod")).Return(nil)
+			podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
+		}
+
+		It("Reconfigures a representor that reappeared", func() {
+			served.repMissing = true
+			expectRepair()
+			netlinkOpsMock.On("LinkSetUp", vfLink).Return(nil)
+
+			checked, repaired := dnnc.checkRepPort(pod.UID, served, clientset)
+			Expect(repaired).To(BeTrue())
+			Expect(checked).To(Equal(servedPod{namespace: pod.Namespace, name: pod.Name, repName: "pf0vf9"}))
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			netlinkOpsMock.AssertCalled(GinkgoT(), "LinkSetUp", vfLink)
+		})
+
+		It("Commits the new state of a pod that is still served", func() {
+			servedPods := sync.Map{}
+			servedPodsLock := sync.Mutex{}
+			served.repMissing = true
+			servedPods.Store(pod.UID, &served)
+			expectRepair()
+			netlinkOpsMock.On("LinkSetUp", vfLink).Return(nil)
+
+			dnnc.checkRepPorts(&servedPods, &servedPodsLock, clientset)
+			Expect(served).To(Equal(servedPod{namespace: pod.Namespace, name: pod.Name, repName: "pf0vf9"}))
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+		})
+
+		It("Removes the representor reconfigured for a pod deleted meanwhile", func() {
+			servedPods := sync.Map{}
+			servedPodsLock := sync.Mutex{}
+			served.repMissing = true
+			servedPods.Store(pod.UID, &served)
+			expectRepair()
+			// the pod is deleted while its representor is reconfigured, which must not wait for the check
+			netlinkOpsMock.On("LinkSetUp", vfLink).Return(nil).Run(func(mock.Arguments) {
+				servedPodsLock.Lock()
+				defer servedPodsLock.Unlock()
+				servedPods.Delete(pod.UID)
+			})
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSDelPortCmd("pf0vf9"),
+			})
+
+			dnnc.checkRepPorts(&servedPods, &servedPodsLock, clientset)
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			netlinkOpsMock.AssertNumberOfCalls(GinkgoT(), "LinkSetDown", 2)
+		})
+	})
+
+	Context("setPodDPUConnError", func() {
+		It("Reports the error in dpu.connection-status pod annotation", func() {
+			factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(&pod, nil)
+			kubeMock.On("UpdatePod", podWithDPUConnStatus(util.DPUConnectionStatusError, "failed to add port")).
+				Return(nil)
+			dnnc.setPodDPUConnError(&pod, fmt.Errorf("failed to add port"))
+			kubeMock.AssertNumberOfCalls(GinkgoT(), "UpdatePod", 1)
+		})
+
+		It("Does not report the same error again", func() {
+			var err error
+			pod.Annotations, err = util.MarshalPodDPUConnStatus(pod.Annotations,
+				util.NewDPUConnectionStatus(util.DPUConnectionStatusError, "failed to add port"), types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			dnnc.setPodDPUConnError(&pod, fmt.Errorf("failed to add port"))
+			kubeMock.AssertNotCalled(GinkgoT(), "UpdatePod", mock.Anything)
+		})
+
+		It("Does not report a missing representor before the connection details are set", func() {
+			dnnc.setPodDPUConnRepError(&pod, fmt.Errorf("failed to get dpu annotation"))
+			kubeMock.AssertNotCalled(GinkgoT(), "UpdatePod", mock.Anything)
+		})
+	})
+
 	Context("delRepPort", func() {
 		var vfRep string
 		var vfLink *linkMock.Link
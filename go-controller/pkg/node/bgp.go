@@ -0,0 +1,273 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// bgpRetryInterval is the interval a failed update of the FRR configuration
+// is retried after
+const bgpRetryInterval = 5 * time.Second
+
+// bgpAdvertiser generates the FRR configuration advertising the host subnet
+// of the node, the egress IPs assigned to it and the load balancer VIPs it
+// serves to the configured BGP peers. FRR runs next to ovnkube-node and
+// reloads the configuration file when it changes.
+type bgpAdvertiser struct {
+	nodeName     string
+	watchFactory *factory.WatchFactory
+	// syncChan requests an update of the FRR configuration
+	syncChan chan struct{}
+}
+
+func newBGPAdvertiser(nodeName string, wf *factory.WatchFactory) *bgpAdvertiser {
+	return &bgpAdvertiser{
+		nodeName:     nodeName,
+		watchFactory: wf,
+		syncChan:     make(chan struct{}, 1),
+	}
+}
+
+// Run watches the objects the advertised prefixes are derived from and
+// updates the FRR configuration until stopChan is closed
+func (a *bgpAdvertiser) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { a.requestSync() },
+		UpdateFunc: func(old, new interface{}) { a.requestSync() },
+		DeleteFunc: func(obj interface{}) { a.requestSync() },
+	}
+	nodeHandler := cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			node, ok := obj.(*kapi.Node)
+			return ok && node.Name == a.nodeName
+		},
+		Handler: handler,
+	}
+	if _, err := a.watchFactory.AddNodeHandler(nodeHandler, nil, a.watchFactory.GetHandlerPriority(factory.NodeType)); err != nil {
+		return err
+	}
+	if _, err := a.watchFactory.AddServiceHandler(handler, nil); err != nil {
+		return err
+	}
+	if _, err := a.watchFactory.AddEndpointSliceHandler(handler, nil); err != nil {
+		return err
+	}
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		if _, err := a.watchFactory.AddEgressIPHandler(handler, nil); err != nil {
+			return err
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-a.syncChan:
+				if err := a.sync(); err != nil {
+					klog.Errorf("Failed to update the FRR configuration of node %s, retrying: %v", a.nodeName, err)
+					time.AfterFunc(bgpRetryInterval, a.requestSync)
+				}
+			}
+		}
+	}()
+	a.requestSync()
+	return nil
+}
+
+func (a *bgpAdvertiser) requestSync() {
+	select {
+	case a.syncChan <- struct{}{}:
+	default:
+	}
+}
+
+func (a *bgpAdvertiser) sync() error {
+	prefixes, err := a.getPrefixes()
+	if err != nil {
+		return err
+	}
+	updated, err := writeFRRConfig(config.BGP.FRRConfigFile, getFRRConfig(prefixes))
+	if err != nil {
+		return err
+	}
+	if updated {
+		klog.Infof("Updated the FRR configuration of node %s, advertising %v", a.nodeName, prefixes)
+	}
+	return nil
+}
+
+// getPrefixes returns the sorted prefixes advertised by the node
+func (a *bgpAdvertiser) getPrefixes() ([]string, error) {
+	prefixes := map[string]bool{}
+
+	node, err := a.watchFactory.GetNode(a.nodeName)
+	if err != nil {
+		return nil, err
+	}
+	// the host subnet is not allocated yet on new nodes
+	if hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName); err == nil {
+		for _, hostSubnet := range hostSubnets {
+			prefixes[hostSubnet.String()] = true
+		}
+	} else if !util.IsAnnotationNotSetError(err) {
+		return nil, err
+	}
+
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		eips, err := a.watchFactory.GetEgressIPs()
+		if err != nil {
+			return nil, err
+		}
+		for _, eip := range eips {
+			for _, status := range eip.Status.Items {
+				if status.Node == a.nodeName {
+					addHostPrefix(prefixes, status.EgressIP)
+				}
+			}
+		}
+	}
+
+	services, err := a.watchFactory.GetServices()
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		if !util.ServiceTypeHasLoadBalancer(service) {
+			continue
+		}
+		if util.ServiceExternalTrafficPolicyLocal(service) {
+			hasLocalEndpoints, err := a.hasLocalEndpoints(service)
+			if err != nil {
+				return nil, err
+			}
+			// only the nodes with endpoints of the service accept its traffic
+			if !hasLocalEndpoints {
+				continue
+			}
+		}
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			addHostPrefix(prefixes, ingress.IP)
+		}
+	}
+
+	sorted := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		sorted = append(sorted, prefix)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// hasLocalEndpoints returns whether the service has serving endpoints on the
+// node
+func (a *bgpAdvertiser) hasLocalEndpoints(service *kapi.Service) (bool, error) {
+	slices, err := a.watchFactory.GetEndpointSlices(service.Namespace, service.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.NodeName != nil && *endpoint.NodeName == a.nodeName && util.IsEndpointServing(endpoint) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// addHostPrefix adds the host prefix of ip to prefixes, ignoring invalid IPs
+func addHostPrefix(prefixes map[string]bool, ip string) {
+	parsed := utilnet.ParseIPSloppy(ip)
+	if parsed == nil {
+		return
+	}
+	prefixes[parsed.String()+util.GetIPFullMask(parsed.String())] = true
+}
+
+// getFRRConfig returns the FRR configuration advertising prefixes to the
+// configured BGP peers
+func getFRRConfig(prefixes []string) string {
+	var b strings.Builder
+	b.WriteString("! Generated by ovnkube-node, do not edit\n")
+	b.WriteString("frr defaults traditional\n")
+	b.WriteString("!\n")
+	fmt.Fprintf(&b, "router bgp %d\n", config.BGP.ASN)
+	// the egress IPs and load balancer VIPs are not routes of the host
+	b.WriteString(" no bgp network import-check\n")
+	b.WriteString(" no bgp ebgp-requires-policy\n")
+	b.WriteString(" no bgp default ipv4-unicast\n")
+	for _, peer := range config.BGP.Peers {
+		fmt.Fprintf(&b, " neighbor %s remote-as %d\n", peer.Address, peer.ASN)
+	}
+	for _, family := range []struct {
+		name   string
+		isIPv6 bool
+	}{{"ipv4", false}, {"ipv6", true}} {
+		b.WriteString(" !\n")
+		fmt.Fprintf(&b, " address-family %s unicast\n", family.name)
+		for _, prefix := range prefixes {
+			if utilnet.IsIPv6CIDRString(prefix) == family.isIPv6 {
+				fmt.Fprintf(&b, "  network %s\n", prefix)
+			}
+		}
+		for _, peer := range config.BGP.Peers {
+			if utilnet.IsIPv6(peer.Address) == family.isIPv6 {
+				fmt.Fprintf(&b, "  neighbor %s activate\n", peer.Address)
+			}
+		}
+		b.WriteString(" exit-address-family\n")
+	}
+	b.WriteString("exit\n")
+	b.WriteString("!\n")
+	return b.String()
+}
+
+// writeFRRConfig atomically replaces the FRR configuration file with
+// frrConfig, returning whether it changed
+func writeFRRConfig(path, frrConfig string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && string(existing) == frrConfig {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read FRR configuration file %s: %w", path, err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return false, fmt.Errorf("failed to create FRR configuration file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.WriteString(frrConfig); err != nil {
+		tmpFile.Close()
+		return false, fmt.Errorf("failed to write FRR configuration file %s: %w", tmpFile.Name(), err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return false, fmt.Errorf("failed to write FRR configuration file %s: %w", tmpFile.Name(), err)
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return false, fmt.Errorf("failed to replace FRR configuration file %s: %w", path, err)
+	}
+	return true, nil
+}
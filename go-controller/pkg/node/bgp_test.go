@@ -0,0 +1,152 @@
+package node
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipv1fake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	utilpointer "k8s.io/utils/pointer"
+)
+
+var _ = Describe("BGP advertisement", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		config.BGP.Enabled = true
+		config.BGP.ASN = 64512
+		config.BGP.Peers = []config.BGPPeer{
+			{Address: net.ParseIP("172.18.0.1"), ASN: 64512},
+			{Address: net.ParseIP("fc00:f853:ccd:e793::1"), ASN: 64513},
+		}
+	})
+
+	newLoadBalancer := func(name, ip string, policy v1.ServiceExternalTrafficPolicyType) v1.Service {
+		return v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec: v1.ServiceSpec{
+				Type:                  v1.ServiceTypeLoadBalancer,
+				ExternalTrafficPolicy: policy,
+			},
+			Status: v1.ServiceStatus{
+				LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+			},
+		}
+	}
+	newEndpointSlice := func(service, nodeName string) discovery.EndpointSlice {
+		return discovery.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      service + "-ab12c",
+				Namespace: "ns1",
+				Labels:    map[string]string{discovery.LabelServiceName: service},
+			},
+			AddressType: discovery.AddressTypeIPv4,
+			Endpoints: []discovery.Endpoint{{
+				Addresses: []string{"10.244.1.5"},
+				NodeName:  utilpointer.String(nodeName),
+			}},
+		}
+	}
+
+	It("advertises the host subnet, egress IPs and load balancer VIPs of the node", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node1",
+				Annotations: map[string]string{"k8s.ovn.org/node-subnets": `{"default":["10.244.1.0/24","fd00:10:244:2::/64"]}`},
+			},
+		}
+		eip := egressipv1.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip1"},
+			Status: egressipv1.EgressIPStatus{
+				Items: []egressipv1.EgressIPStatusItem{
+					{Node: "node1", EgressIP: "172.18.0.100"},
+					{Node: "node2", EgressIP: "172.18.0.101"},
+				},
+			},
+		}
+		kubeClient := fake.NewSimpleClientset(
+			&v1.NodeList{Items: []v1.Node{node}},
+			&v1.ServiceList{Items: []v1.Service{
+				newLoadBalancer("cluster", "192.168.10.1", v1.ServiceExternalTrafficPolicyTypeCluster),
+				newLoadBalancer("local", "192.168.10.2", v1.ServiceExternalTrafficPolicyTypeLocal),
+				newLoadBalancer("remote", "192.168.10.3", v1.ServiceExternalTrafficPolicyTypeLocal),
+			}},
+			&discovery.EndpointSliceList{Items: []discovery.EndpointSlice{
+				newEndpointSlice("local", "node1"),
+				newEndpointSlice("remote", "node2"),
+			}},
+		)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{
+			KubeClient:     kubeClient,
+			EgressIPClient: egressipv1fake.NewSimpleClientset(&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eip}}),
+		}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		a := newBGPAdvertiser("node1", wf)
+		prefixes, err := a.getPrefixes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes).To(Equal([]string{
+			"10.244.1.0/24",
+			"172.18.0.100/32",
+			"192.168.10.1/32",
+			"192.168.10.2/32",
+			"fd00:10:244:2::/64",
+		}))
+		Expect(getFRRConfig(prefixes)).To(Equal(`! Generated by ovnkube-node, do not edit
+frr defaults traditional
+!
+router bgp 64512
+ no bgp network import-check
+ no bgp ebgp-requires-policy
+ no bgp default ipv4-unicast
+ neighbor 172.18.0.1 remote-as 64512
+ neighbor fc00:f853:ccd:e793::1 remote-as 64513
+ !
+ address-family ipv4 unicast
+  network 10.244.1.0/24
+  network 172.18.0.100/32
+  network 192.168.10.1/32
+  network 192.168.10.2/32
+  neighbor 172.18.0.1 activate
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  network fd00:10:244:2::/64
+  neighbor fc00:f853:ccd:e793::1 activate
+ exit-address-family
+exit
+!
+`))
+	})
+
+	It("only rewrites the FRR configuration file when it changes", func() {
+		tmpDir, err := os.MkdirTemp("", "frr")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		path := filepath.Join(tmpDir, "frr.conf")
+
+		Expect(writeFRRConfig(path, "router bgp 64512\n")).To(BeTrue())
+		Expect(writeFRRConfig(path, "router bgp 64512\n")).To(BeFalse())
+		Expect(writeFRRConfig(path, "router bgp 64513\n")).To(BeTrue())
+		content, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("router bgp 64513\n"))
+		files, err := os.ReadDir(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+})
@@ -260,6 +260,13 @@ func setupOVNNode(node *kapi.Node) error {
 		if err != nil {
 			return fmt.Errorf("failed to obtain local IP from node %q: %v", node.Name, err)
 		}
+		if nodeIPPolicyConfigured() {
+			policyEncapIP, err := getNodeIPPolicyEncapIP(net.ParseIP(encapIP))
+			if err != nil {
+				return fmt.Errorf("failed to obtain encap IP of node %q: %v", node.Name, err)
+			}
+			encapIP = policyEncapIP.String()
+		}
 		config.Default.EncapIP = encapIP
 	} else {
 		if ip := net.ParseIP(encapIP); ip == nil {
@@ -552,7 +559,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		if !ok {
 			return fmt.Errorf("cannot get kubeclient for starting CNI server")
 		}
-		cniServer, err = cni.NewCNIServer(isOvnUpEnabled, nc.watchFactory, kclient.KClient)
+		cniServer, err = cni.NewCNIServer(isOvnUpEnabled, nc.watchFactory, kclient.KClient, nc.recorder)
 		if err != nil {
 			return err
 		}
@@ -705,6 +712,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 			nc.name,
 			nc.watchFactory.NodeInformer(),
 			nc.watchFactory.LocalPodInformer(),
+			nc.watchFactory.HybridOverlayPeerInformer(),
 			informer.NewDefaultEventHandler,
 		)
 		if err != nil {
@@ -742,6 +750,22 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 
 	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
 		util.SetARPTimeout()
+		if config.OVNKubernetesFeature.EnableIPsec {
+			newIPsecCertManager(nc.name, nc.client, nc.recorder).Run(nc.stopChan, nc.wg)
+		} else if err := cleanupIPsecCertificates(); err != nil {
+			klog.Warningf("Failed to clean up the IPsec certificates of node %s: %v", nc.name, err)
+		}
+		if config.BGP.Enabled {
+			if err := newBGPAdvertiser(nc.name, nc.watchFactory.(*factory.WatchFactory)).Run(nc.stopChan, nc.wg); err != nil {
+				return fmt.Errorf("failed to start BGP advertisement: %w", err)
+			}
+		}
+		if config.Default.NoOverlay {
+			m := newNoOverlayRouteManager(nc.name, nc.gateway.GetGatewayBridgeIface(), nc.watchFactory.(*factory.WatchFactory))
+			if err := m.Run(nc.stopChan, nc.wg); err != nil {
+				return fmt.Errorf("failed to start no-overlay routing: %w", err)
+			}
+		}
 		err := nc.WatchNamespaces()
 		if err != nil {
 			return fmt.Errorf("failed to watch namespaces: %w", err)
@@ -754,6 +778,22 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to watch endpointSlices: %w", err)
 		}
+		var flows *flowExporter
+		if config.Monitoring.FlowExportAddress != "" {
+			flows = newFlowExporter(config.Monitoring.FlowExportAddress, nc.watchFactory)
+			flows.Start(nc.stopChan, nc.wg)
+		}
+		aclLogCollector, err := newACLLogCollector(nc.name, nc.watchFactory, flows)
+		if err != nil {
+			return fmt.Errorf("failed to start ACL log collector: %w", err)
+		}
+		if aclLogCollector != nil {
+			nc.wg.Add(1)
+			go func() {
+				defer nc.wg.Done()
+				aclLogCollector.Run(nc.stopChan)
+			}()
+		}
 	}
 
 	if nc.healthzServer != nil {
@@ -985,7 +1025,8 @@ func (nc *DefaultNodeNetworkController) validateVTEPInterfaceMTU() error {
 		return fmt.Errorf("could not get MTU for the interface with address %s: %w", ovnEncapIP, err)
 	}
 
-	// calc required MTU
+	// calc required MTU, with no-overlay the Geneve tunnels still carry the
+	// NodePort and egress IP traffic between the nodes
 	var requiredMTU int
 	if config.Gateway.SingleNode {
 		requiredMTU = config.Default.MTU
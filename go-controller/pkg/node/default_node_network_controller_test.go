@@ -177,6 +177,40 @@ var _ = Describe("Node", func() {
 			})
 		})
 
+		Context("with a no-overlay cluster", func() {
+			BeforeEach(func() {
+				config.IPv4Mode = true
+				config.IPv6Mode = false
+				config.Default.NoOverlay = true
+			})
+
+			Context("with the node having no room for the Geneve header", func() {
+
+				It("should taint the node", func() {
+					netlinkLinkMock.On("Attrs").Return(&netlink.LinkAttrs{
+						MTU:  configDefaultMTU,
+						Name: linkName,
+					})
+
+					err := nc.validateVTEPInterfaceMTU()
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("with the node having a big enough MTU", func() {
+
+				It("should untaint the node", func() {
+					netlinkLinkMock.On("Attrs").Return(&netlink.LinkAttrs{
+						MTU:  mtuOkForIPv4ButTooSmallForIPv6,
+						Name: linkName,
+					})
+
+					err := nc.validateVTEPInterfaceMTU()
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("with a single-node cluster", func() {
 			BeforeEach(func() {
 				config.Gateway.SingleNode = true
@@ -0,0 +1,114 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// getDPUReadyCondition returns the DPU ready condition of the node
+func getDPUReadyCondition() kapi.NodeCondition {
+	condition := kapi.NodeCondition{Type: util.DPUReadyCondition}
+	hwOffload, err := util.IsOvsHwOffloadEnabled()
+	switch {
+	case err != nil:
+		condition.Status = kapi.ConditionFalse
+		condition.Reason = util.DPUReadyReasonOVSUnreachable
+		condition.Message = fmt.Sprintf("Failed to get the OVS hardware offload configuration: %v", err)
+	case !hwOffload:
+		condition.Status = kapi.ConditionFalse
+		condition.Reason = util.DPUReadyReasonHwOffloadDisabled
+		condition.Message = "OVS hardware offload is disabled"
+	default:
+		condition.Status = kapi.ConditionTrue
+		condition.Reason = util.DPUReadyReasonReady
+		condition.Message = "OVS hardware offload is enabled"
+	}
+	return condition
+}
+
+// runDPUStatus heartbeats the DPU status of the node, so that the CNI on the
+// host can tell why the pods of the node are not plugged when ovnkube-node on
+// the DPU is not ready or unreachable
+func (bnnc *BaseNodeNetworkController) runDPUStatus() {
+	bnnc.wg.Add(1)
+	go func() {
+		defer bnnc.wg.Done()
+		var posted *kapi.NodeCondition
+		wait.Until(func() {
+			posted = bnnc.heartbeatDPUStatus(posted)
+		}, util.DPUStatusHeartbeatInterval, bnnc.stopChan)
+	}()
+}
+
+// heartbeatDPUStatus renews the DPU status lease of the node and sets the DPU
+// ready condition of the node when its status or reason differs from the
+// posted one, so that the node status is only updated on transitions. It
+// returns the condition posted on the node.
+func (bnnc *BaseNodeNetworkController) heartbeatDPUStatus(posted *kapi.NodeCondition) *kapi.NodeCondition {
+	if err := bnnc.renewDPUStatusLease(); err != nil {
+		klog.Errorf("Failed to renew the DPU status lease of node %s: %v", bnnc.name, err)
+	}
+	condition := getDPUReadyCondition()
+	if posted != nil && posted.Status == condition.Status && posted.Reason == condition.Reason {
+		return posted
+	}
+	if err := updateNodeCondition(bnnc.Kube, bnnc.name, condition); err != nil {
+		klog.Errorf("Failed to set the %s condition of node %s: %v", util.DPUReadyCondition, bnnc.name, err)
+		return posted
+	}
+	return &condition
+}
+
+// renewDPUStatusLease renews the DPU status lease of the node, creating it
+// owned by the node if it does not exist
+func (bnnc *BaseNodeNetworkController) renewDPUStatusLease() error {
+	leases := bnnc.client.CoordinationV1().Leases(config.Kubernetes.OVNConfigNamespace)
+	name := util.DPUStatusLeaseName(bnnc.name)
+	now := metav1.NewMicroTime(time.Now())
+	lease, err := leases.Get(context.TODO(), name, metav1.GetOptions{})
+	if err == nil {
+		lease.Spec.RenewTime = &now
+		_, err = leases.Update(context.TODO(), lease, metav1.UpdateOptions{})
+		return err
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	node, err := bnnc.Kube.GetNode(bnnc.name)
+	if err != nil {
+		return fmt.Errorf("error retrieving node %s: %v", bnnc.name, err)
+	}
+	holder := bnnc.name
+	duration := int32(util.DPUStatusLeaseDuration / time.Second)
+	lease = &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: config.Kubernetes.OVNConfigNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(node, kapi.SchemeGroupVersion.WithKind("Node")),
+			},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &now,
+		},
+	}
+	_, err = leases.Create(context.TODO(), lease, metav1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,116 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("DPU status", func() {
+	var fexec *ovntest.FakeExec
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		util.ResetRunner()
+	})
+
+	addHwOffloadCmd := func(output string) {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:hw-offload",
+			Output: output,
+		})
+	}
+
+	It("reports a ready DPU", func() {
+		addHwOffloadCmd("\"true\"")
+		condition := getDPUReadyCondition()
+		Expect(condition.Type).To(Equal(util.DPUReadyCondition))
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(util.DPUReadyReasonReady))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("reports a DPU without hardware offload as not ready", func() {
+		addHwOffloadCmd("")
+		condition := getDPUReadyCondition()
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(util.DPUReadyReasonHwOffloadDisabled))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("reports a DPU whose OVS cannot be reached as not ready", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:hw-offload",
+			Err: fmt.Errorf("database connection failed"),
+		})
+		condition := getDPUReadyCondition()
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(util.DPUReadyReasonOVSUnreachable))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("renews the DPU status lease and only updates the node status on transitions", func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "node1-uid"}}
+		fakeClient := fake.NewSimpleClientset(node)
+		bnnc := &BaseNodeNetworkController{
+			CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{
+				client: fakeClient,
+				Kube:   &kube.Kube{KClient: fakeClient},
+				name:   node.Name,
+			},
+		}
+		isNodeStatusUpdated := func() bool {
+			for _, action := range fakeClient.Actions() {
+				if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+					return true
+				}
+			}
+			return false
+		}
+		getLease := func() *coordinationv1.Lease {
+			lease, err := fakeClient.CoordinationV1().Leases(config.Kubernetes.OVNConfigNamespace).Get(
+				context.TODO(), util.DPUStatusLeaseName(node.Name), metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return lease
+		}
+
+		addHwOffloadCmd("\"true\"")
+		posted := bnnc.heartbeatDPUStatus(nil)
+		Expect(posted.Reason).To(Equal(util.DPUReadyReasonReady))
+		Expect(isNodeStatusUpdated()).To(BeTrue())
+		lease := getLease()
+		Expect(lease.OwnerReferences).To(HaveLen(1))
+		Expect(lease.OwnerReferences[0].UID).To(Equal(node.UID))
+		renewTime := lease.Spec.RenewTime.Time
+
+		fakeClient.ClearActions()
+		addHwOffloadCmd("\"true\"")
+		Expect(bnnc.heartbeatDPUStatus(posted)).To(Equal(posted))
+		Expect(isNodeStatusUpdated()).To(BeFalse())
+		Expect(getLease().Spec.RenewTime.Time).NotTo(BeTemporally("<", renewTime))
+
+		fakeClient.ClearActions()
+		addHwOffloadCmd("")
+		posted = bnnc.heartbeatDPUStatus(posted)
+		Expect(posted.Reason).To(Equal(util.DPUReadyReasonHwOffloadDisabled))
+		Expect(isNodeStatusUpdated()).To(BeTrue())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+})
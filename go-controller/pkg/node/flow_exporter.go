@@ -0,0 +1,284 @@
+package node
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// flowExportInterval is the interval the connections are dumped at
+	flowExportInterval = 10 * time.Second
+	// flowExportMaxDrops is the number of ACL drops kept
+	flowExportMaxDrops = 1000
+	// flowExportPointCacheTTL is the time the observation point of an ID
+	// resolved from the northbound database is cached for
+	flowExportPointCacheTTL = 5 * time.Minute
+)
+
+// observedFlow is a connection allowed, or a packet dropped, by an ACL, with
+// the Kubernetes objects it is about
+type observedFlow struct {
+	Time    string `json:"time"`
+	Verdict string `json:"verdict"`
+	// ACL is the name of the ACL, only known for drops
+	ACL string `json:"acl,omitempty"`
+	observability.ObservationPoint
+	Protocol string `json:"protocol,omitempty"`
+	SrcIP    string `json:"srcIP,omitempty"`
+	DstIP    string `json:"dstIP,omitempty"`
+	SrcPort  string `json:"srcPort,omitempty"`
+	DstPort  string `json:"dstPort,omitempty"`
+	// Packets and Bytes are counted in the original direction of connections
+	Packets uint64 `json:"packets,omitempty"`
+	Bytes   uint64 `json:"bytes,omitempty"`
+	SrcPod  string `json:"srcPod,omitempty"`
+	DstPod  string `json:"dstPod,omitempty"`
+}
+
+// cachedObservationPoint is the observation point of an ID, nil if no ACL
+// or ACLs of different objects have the ID
+type cachedObservationPoint struct {
+	point  *observability.ObservationPoint
+	expiry time.Time
+}
+
+// flowExporter exports the connections allowed by NetworkPolicy ACLs, found
+// in conntrack by the observation point ID OVN writes to their ct_label, and
+// the drops reported by the ACL log collector
+type flowExporter struct {
+	address      string
+	watchFactory factory.NodeWatchFactory
+	// points are the observation points resolved from the ACLs of the
+	// northbound database by ID, only used by syncConntrack
+	points map[int]cachedObservationPoint
+
+	sync.Mutex
+	// allowed are the connections of the last conntrack dump
+	allowed []observedFlow
+	// drops are the last flowExportMaxDrops drops
+	drops []observedFlow
+}
+
+func newFlowExporter(address string, wf factory.NodeWatchFactory) *flowExporter {
+	return &flowExporter{
+		address:      address,
+		watchFactory: wf,
+		points:       map[int]cachedObservationPoint{},
+	}
+}
+
+// Start serves the observed flows on /flows and dumps the connections
+// periodically until stopChan is closed
+func (fe *flowExporter) Start(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	serveMux := http.NewServeMux()
+	serveMux.Handle("/flows", fe)
+	server := &http.Server{
+		Addr:    fe.address,
+		Handler: serveMux,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-stopChan
+		server.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		klog.Infof("Starting flow export server on %s", fe.address)
+		for {
+			err := server.ListenAndServe()
+			if errors.Is(err, http.ErrServerClosed) {
+				return
+			}
+			klog.Errorf("Serving flows on %s failed: %v", fe.address, err)
+			time.Sleep(5 * time.Second)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(fe.syncConntrack, flowExportInterval, stopChan)
+	}()
+}
+
+// ServeHTTP returns the observed flows as JSON, filtered by the namespace,
+// policy and verdict query parameters
+func (fe *flowExporter) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	match := func(flow *observedFlow) bool {
+		return (query.Get("namespace") == "" || query.Get("namespace") == flow.Namespace) &&
+			(query.Get("policy") == "" || query.Get("policy") == flow.Policy) &&
+			(query.Get("verdict") == "" || query.Get("verdict") == flow.Verdict)
+	}
+	flows := []observedFlow{}
+	fe.Lock()
+	for _, list := range [][]observedFlow{fe.allowed, fe.drops} {
+		for i := range list {
+			if match(&list[i]) {
+				flows = append(flows, list[i])
+			}
+		}
+	}
+	fe.Unlock()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	if err := json.NewEncoder(resp).Encode(flows); err != nil {
+		klog.Errorf("Failed to encode flows: %v", err)
+	}
+}
+
+// addDrop adds a drop reported by the ACL log collector
+func (fe *flowExporter) addDrop(msg *aclLogMessage) {
+	fe.Lock()
+	defer fe.Unlock()
+	fe.drops = append(fe.drops, observedFlow{
+		Time:             msg.Time,
+		Verdict:          msg.Verdict,
+		ACL:              msg.ACL,
+		ObservationPoint: msg.ObservationPoint,
+		Protocol:         msg.Protocol,
+		SrcIP:            msg.SrcIP,
+		DstIP:            msg.DstIP,
+		SrcPort:          msg.SrcPort,
+		DstPort:          msg.DstPort,
+		SrcPod:           msg.SrcPod,
+		DstPod:           msg.DstPod,
+	})
+	if len(fe.drops) > flowExportMaxDrops {
+		fe.drops = fe.drops[len(fe.drops)-flowExportMaxDrops:]
+	}
+}
+
+// syncConntrack replaces the allowed connections with the ones of conntrack
+// that have an observation point ID
+func (fe *flowExporter) syncConntrack() {
+	var flows []*netlink.ConntrackFlow
+	for _, family := range []netlink.InetFamily{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		familyFlows, err := util.GetNetLinkOps().ConntrackTableList(netlink.ConntrackTable, family)
+		if err != nil {
+			klog.Errorf("Failed to list conntrack entries: %v", err)
+			return
+		}
+		flows = append(flows, familyFlows...)
+	}
+	podNames, err := getLocalPodNamesByIP(fe.watchFactory)
+	if err != nil {
+		klog.Warningf("Failed to list pods for flow export: %v", err)
+	}
+	now := time.Now()
+	for id, cached := range fe.points {
+		if now.After(cached.expiry) {
+			delete(fe.points, id)
+		}
+	}
+	allowed := []observedFlow{}
+	for _, flow := range flows {
+		id := getObservationPointID(flow.Labels)
+		if id == 0 {
+			continue
+		}
+		point := fe.getObservationPoint(id, now)
+		if point == nil {
+			continue
+		}
+		protocol, ok := nl.L4ProtoMap[flow.Forward.Protocol]
+		if !ok {
+			protocol = fmt.Sprint(flow.Forward.Protocol)
+		}
+		srcIP, dstIP := flow.Forward.SrcIP.String(), flow.Forward.DstIP.String()
+		allowed = append(allowed, observedFlow{
+			Time:             now.UTC().Format(time.RFC3339),
+			Verdict:          "allow",
+			ObservationPoint: *point,
+			Protocol:         protocol,
+			SrcIP:            srcIP,
+			DstIP:            dstIP,
+			SrcPort:          strconv.Itoa(int(flow.Forward.SrcPort)),
+			DstPort:          strconv.Itoa(int(flow.Forward.DstPort)),
+			Packets:          flow.Forward.Packets,
+			Bytes:            flow.Forward.Bytes,
+			SrcPod:           podNames[srcIP],
+			DstPod:           podNames[dstIP],
+		})
+	}
+	fe.Lock()
+	fe.allowed = allowed
+	fe.Unlock()
+}
+
+// getObservationPoint returns the observation point of an ID, from the cache
+// or else from the ACLs of the northbound database that have the ID as label
+func (fe *flowExporter) getObservationPoint(id int, now time.Time) *observability.ObservationPoint {
+	if cached, ok := fe.points[id]; ok {
+		return cached.point
+	}
+	point, err := lookupObservationPoint(id)
+	if err != nil {
+		klog.Warningf("Failed to look up observation point %d for flow export: %v", id, err)
+		return nil
+	}
+	fe.points[id] = cachedObservationPoint{point: point, expiry: now.Add(flowExportPointCacheTTL)}
+	return point
+}
+
+// lookupObservationPoint returns the observation point of the ACLs of the
+// northbound database with the ID as label. It returns nil if there are none,
+// or if ACLs of different objects have the ID, which ovnkube-master prevents
+// but could happen while it relabels ACLs.
+func lookupObservationPoint(id int) (*observability.ObservationPoint, error) {
+	stdout, stderr, err := util.RunOVNNbctl("--data=bare", "--no-heading", "--columns=external_ids",
+		"find", "ACL", fmt.Sprintf("label=%d", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the ACLs with label %d, stderr: %q, error: %v", id, stderr, err)
+	}
+	var point *observability.ObservationPoint
+	for _, line := range strings.Split(stdout, "\n") {
+		externalIDs := map[string]string{}
+		for _, field := range strings.Fields(line) {
+			if key, value, found := strings.Cut(field, "="); found {
+				externalIDs[key] = strings.Trim(value, "\"")
+			}
+		}
+		aclPoint := observability.ACLObservationPoint(externalIDs)
+		if aclPoint.OwnerType == "" {
+			continue
+		}
+		if point != nil && *point != aclPoint {
+			klog.Warningf("Observation point %d is used by the ACLs of both %+v and %+v, not exporting its flows",
+				id, *point, aclPoint)
+			return nil, nil
+		}
+		point = &aclPoint
+	}
+	return point, nil
+}
+
+// getObservationPointID returns the observation point ID OVN writes to
+// ct_label.label, bits 96 to 127 of the conntrack labels bitmap
+func getObservationPointID(labels []byte) int {
+	if len(labels) < 16 {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(labels[12:16]))
+}
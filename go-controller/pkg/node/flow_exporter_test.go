@@ -0,0 +1,185 @@
+package node
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+
+	"github.com/vishvananda/netlink"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Flow exporter", func() {
+	const findACLCmd = "ovn-nbctl --timeout=15 --data=bare --no-heading --columns=external_ids find ACL label="
+
+	var (
+		fexec          *ovntest.FakeExec
+		netlinkOpsMock *utilMocks.NetLinkOps
+		wf             *factory.WatchFactory
+		fe             *flowExporter
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Monitoring.FlowExportAddress = "127.0.0.1:9108"
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		netlinkOpsMock = new(utilMocks.NetLinkOps)
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+
+		fakeClient := fake.NewSimpleClientset(
+			&v1.PodList{Items: []v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "ns1"},
+				Spec:       v1.PodSpec{NodeName: "node1"},
+				Status: v1.PodStatus{
+					PodIP:  "10.244.1.3",
+					PodIPs: []v1.PodIP{{IP: "10.244.1.3"}},
+				},
+			}}},
+		)
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		fe = newFlowExporter("", wf)
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+		util.ResetNetLinkOpMockInst()
+	})
+
+	getFlows := func(query string) []observedFlow {
+		resp := httptest.NewRecorder()
+		fe.ServeHTTP(resp, httptest.NewRequest("GET", "/flows"+query, nil))
+		var flows []observedFlow
+		Expect(json.Unmarshal(resp.Body.Bytes(), &flows)).To(Succeed())
+		return flows
+	}
+
+	newFlow := func(id uint32, protocol uint8, srcIP, dstIP string) *netlink.ConntrackFlow {
+		labels := make([]byte, 16)
+		binary.LittleEndian.PutUint32(labels[12:], id)
+		flow := &netlink.ConntrackFlow{Labels: labels}
+		flow.Forward.Protocol = protocol
+		flow.Forward.SrcIP = ovntest.MustParseIP(srcIP)
+		flow.Forward.DstIP = ovntest.MustParseIP(dstIP)
+		return flow
+	}
+
+	setConntrack := func(flows ...*netlink.ConntrackFlow) {
+		netlinkOpsMock.On("ConntrackTableList", netlink.ConntrackTableType(netlink.ConntrackTable), netlink.InetFamily(netlink.FAMILY_V4)).Return(
+			flows, nil)
+		netlinkOpsMock.On("ConntrackTableList", netlink.ConntrackTableType(netlink.ConntrackTable), netlink.InetFamily(netlink.FAMILY_V6)).Return(
+			[]*netlink.ConntrackFlow{}, nil)
+	}
+
+	It("exports the connections allowed by network policy rules", func() {
+		allowedFlow := newFlow(1001, 6, "10.244.2.5", "10.244.1.3")
+		allowedFlow.Forward.SrcPort = 41532
+		allowedFlow.Forward.DstPort = 8080
+		allowedFlow.Forward.Packets = 10
+		setConntrack(allowedFlow, newFlow(42, 6, "10.244.2.5", "10.244.1.4"), &netlink.ConntrackFlow{})
+		// the ACLs of the rule share the observation point, the second ID has no ACL
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: findACLCmd + "1001",
+			Output: "direction=Ingress gress-index=0 ip-block-index=-1 k8s.ovn.org/name=ns1:allow-client " +
+				"k8s.ovn.org/owner-type=NetworkPolicy port-policy-index=0\n" +
+				"direction=Ingress gress-index=0 ip-block-index=0 k8s.ovn.org/name=ns1:allow-client " +
+				"k8s.ovn.org/owner-type=NetworkPolicy port-policy-index=0",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{findACLCmd + "42"})
+
+		fe.syncConntrack()
+		// the observation points are only looked up once
+		fe.syncConntrack()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		flows := getFlows("")
+		Expect(flows).To(HaveLen(1))
+		Expect(flows[0].Verdict).To(Equal("allow"))
+		Expect(flows[0].ObservationPoint).To(Equal(observability.ObservationPoint{
+			OwnerType:       "NetworkPolicy",
+			Namespace:       "ns1",
+			Policy:          "allow-client",
+			PolicyDirection: "Ingress",
+			Rule:            "0",
+		}))
+		Expect(flows[0].Protocol).To(Equal("tcp"))
+		Expect(flows[0].DstPort).To(Equal("8080"))
+		Expect(flows[0].Packets).To(BeEquivalentTo(10))
+		Expect(flows[0].SrcPod).To(BeEmpty())
+		Expect(flows[0].DstPod).To(Equal("ns1/web-1"))
+	})
+
+	It("exports the connections labeled by default deny and egress firewall ACLs", func() {
+		setConntrack(newFlow(1002, 17, "10.244.1.5", "10.244.1.3"), newFlow(1003, 17, "10.244.1.5", "8.8.8.8"))
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    findACLCmd + "1002",
+			Output: "direction=Egress k8s.ovn.org/name=ns1 k8s.ovn.org/owner-type=NetpolNamespace type=defaultDeny",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    findACLCmd + "1003",
+			Output: "k8s.ovn.org/name=ns2 k8s.ovn.org/owner-type=EgressFirewall rule-index=1",
+		})
+
+		fe.syncConntrack()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		flows := getFlows("")
+		Expect(flows).To(HaveLen(2))
+		Expect(flows[0].ObservationPoint).To(Equal(observability.ObservationPoint{
+			OwnerType:       "NetpolNamespace",
+			Namespace:       "ns1",
+			PolicyDirection: "Egress",
+		}))
+		Expect(flows[1].ObservationPoint).To(Equal(observability.ObservationPoint{
+			OwnerType: "EgressFirewall",
+			Namespace: "ns2",
+			Rule:      "1",
+		}))
+		Expect(flows[1].DstIP).To(Equal("8.8.8.8"))
+	})
+
+	It("does not export the connections of observation points used by ACLs of different objects", func() {
+		setConntrack(newFlow(1004, 6, "10.244.2.5", "10.244.1.3"))
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: findACLCmd + "1004",
+			Output: "direction=Ingress k8s.ovn.org/name=ns1 k8s.ovn.org/owner-type=NetpolNamespace type=defaultDeny\n" +
+				"direction=Ingress k8s.ovn.org/name=ns3 k8s.ovn.org/owner-type=NetpolNamespace type=defaultDeny",
+		})
+
+		fe.syncConntrack()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(getFlows("")).To(BeEmpty())
+	})
+
+	It("exports the drops of the ACL log collector", func() {
+		c := &aclLogCollector{nodeName: "node1", watchFactory: wf, flows: fe, podNames: map[string]string{}}
+		_, err := c.watchPods()
+		Expect(err).NotTo(HaveOccurred())
+		c.collect(parseACLLogMessage(`2023-03-20T10:11:12.345Z|00012|acl_log(ovn_pinctrl0)|INFO|name="NP:ns1:Ingress", ` +
+			`verdict=drop, severity=alert, direction=to-lport: tcp,nw_src=10.244.2.1,nw_dst=10.244.1.3,tp_src=41532,tp_dst=8080`))
+		c.collect(parseACLLogMessage(`2023-03-20T10:11:13.345Z|00013|acl_log(ovn_pinctrl0)|INFO|name="EF:ns2:0", ` +
+			`verdict=drop, severity=alert: udp,nw_src=10.244.1.5,nw_dst=8.8.8.8,tp_src=5353,tp_dst=53`))
+
+		Expect(getFlows("")).To(HaveLen(2))
+		flows := getFlows("?namespace=ns1&verdict=drop")
+		Expect(flows).To(HaveLen(1))
+		Expect(flows[0].ACL).To(Equal("NP:ns1:Ingress"))
+		Expect(flows[0].OwnerType).To(Equal("NetpolNamespace"))
+		Expect(flows[0].DstPod).To(Equal("ns1/web-1"))
+		Expect(getFlows("?verdict=allow")).To(BeEmpty())
+	})
+})
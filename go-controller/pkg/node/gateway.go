@@ -44,6 +44,9 @@ type gateway struct {
 	initFunc        func() error
 	readyFunc       func() (bool, error)
 
+	// egressIPCounters is used to count the traffic of the egress IPs of the node
+	egressIPCounters *egressIPCounters
+
 	watchFactory *factory.WatchFactory // used for retry
 	stopChan     <-chan struct{}
 	wg           *sync.WaitGroup
@@ -233,6 +236,10 @@ func (g *gateway) Start() {
 		klog.Info("Spawning Conntrack Rule Check Thread")
 		g.openflowManager.Run(g.stopChan, g.wg)
 	}
+
+	if g.egressIPCounters != nil {
+		g.egressIPCounters.Run(g.stopChan, g.wg)
+	}
 }
 
 // sets up an uplink interface for UDP Generic Receive Offload forwarding as part of
@@ -255,22 +262,26 @@ func setupUDPAggregationUplink(ifname string) error {
 }
 
 func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops []net.IP, gwIPs []*net.IPNet, nodeAnnotator kube.Annotator) (
-	*bridgeConfiguration, *bridgeConfiguration, error) {
+	*bridgeConfiguration, *bridgeConfiguration, map[string]*bridgeConfiguration, error) {
 	gatewayBridge, err := bridgeForInterface(gwIntf, nodeName, types.PhysicalNetworkName, gwIPs)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", gwIntf)
+		return nil, nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", gwIntf)
 	}
 	var egressGWBridge *bridgeConfiguration
 	if egressGatewayIntf != "" {
 		egressGWBridge, err = bridgeForInterface(egressGatewayIntf, nodeName, types.PhysicalNetworkExGwName, nil)
 		if err != nil {
-			return nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", egressGatewayIntf)
+			return nil, nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", egressGatewayIntf)
 		}
 	}
+	gwBridges, err := initGatewayBridges(nodeName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	chassisID, err := util.GetNodeChassisID()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Set annotation that determines if options:gateway_mtu shall be set for this node.
@@ -283,7 +294,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 	} else {
 		chkPktLengthSupported, err := util.DetectCheckPktLengthSupport(gatewayBridge.bridgeName)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		if !chkPktLengthSupported {
 			klog.Warningf("OVS does not support check_packet_length action. " +
@@ -300,7 +311,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 			 */
 			ovsHardwareOffloadEnabled, err := util.IsOvsHwOffloadEnabled()
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			if ovsHardwareOffloadEnabled {
 				klog.Warningf("OVS hardware offloading is enabled. " +
@@ -311,7 +322,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 		}
 	}
 	if err := util.SetGatewayMTUSupport(nodeAnnotator, enableGatewayMTU); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if config.Default.EnableUDPAggregation {
@@ -319,6 +330,11 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 		if err == nil && egressGWBridge != nil {
 			err = setupUDPAggregationUplink(egressGWBridge.uplinkName)
 		}
+		for _, bridge := range gwBridges {
+			if err == nil {
+				err = setupUDPAggregationUplink(bridge.uplinkName)
+			}
+		}
 		if err != nil {
 			klog.Warningf("Could not enable UDP packet aggregation on uplink interface (aggregation will be disabled): %v", err)
 			config.Default.EnableUDPAggregation = false
@@ -334,6 +350,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 		NextHops:       gwNextHops,
 		NodePortEnable: config.Gateway.NodeportEnable,
 		VLANID:         &config.Gateway.VLANID,
+		Bridges:        getL3GatewayBridgesConfig(gwBridges),
 	}
 	if egressGWBridge != nil {
 		l3GwConfig.EgressGWInterfaceID = egressGWBridge.interfaceID
@@ -342,7 +359,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 	}
 
 	err = util.SetL3GatewayConfig(nodeAnnotator, &l3GwConfig)
-	return gatewayBridge, egressGWBridge, err
+	return gatewayBridge, egressGWBridge, gwBridges, err
 }
 
 func gatewayReady(patchPort string) (bool, error) {
@@ -0,0 +1,116 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// initGatewayBridges creates the additional named gateway bridges of the node,
+// each mapped to its own physical network
+func initGatewayBridges(nodeName string) (map[string]*bridgeConfiguration, error) {
+	bridges := make(map[string]*bridgeConfiguration, len(config.Gateway.Bridges))
+	for _, gwBridge := range config.Gateway.Bridges {
+		bridge, err := bridgeForInterface(interfaceForEXGW(gwBridge.Interface), nodeName,
+			util.GetGatewayBridgePhysicalNetworkName(gwBridge.Name), nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Bridge for interface failed for gateway bridge %s", gwBridge.Name)
+		}
+		bridges[gwBridge.Name] = bridge
+	}
+	return bridges, nil
+}
+
+// getL3GatewayBridgesConfig returns the configuration of the additional named
+// gateway bridges published in the l3 gateway annotation
+func getL3GatewayBridgesConfig(bridges map[string]*bridgeConfiguration) map[string]*util.L3GatewayBridgeConfig {
+	if len(bridges) == 0 {
+		return nil
+	}
+	bridgesConfig := make(map[string]*util.L3GatewayBridgeConfig, len(bridges))
+	for _, gwBridge := range config.Gateway.Bridges {
+		bridge := bridges[gwBridge.Name]
+		bridgeConfig := &util.L3GatewayBridgeConfig{
+			InterfaceID: bridge.interfaceID,
+			MACAddress:  bridge.macAddress,
+			IPAddresses: bridge.ips,
+			Routes:      gwBridge.Routes,
+		}
+		if gwBridge.VLANID != 0 {
+			vlanID := gwBridge.VLANID
+			bridgeConfig.VLANID = &vlanID
+		}
+		bridgesConfig[gwBridge.Name] = bridgeConfig
+	}
+	return bridgesConfig
+}
+
+// withGatewayBridgesReady returns a gateway ready function that also waits for
+// the patch ports of the additional named gateway bridges
+func withGatewayBridgesReady(readyFunc func() (bool, error), bridges map[string]*bridgeConfiguration) func() (bool, error) {
+	if len(bridges) == 0 {
+		return readyFunc
+	}
+	return func() (bool, error) {
+		ready, err := readyFunc()
+		if err != nil || !ready {
+			return false, err
+		}
+		for _, bridge := range bridges {
+			ready, err := gatewayReady(bridge.patchPort)
+			if err != nil || !ready {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// setupGatewayBridges gets the ports of the additional named gateway bridges
+// and routes the destinations of each bridge through it on the host, so that
+// the traffic leaving through the host, as in local gateway mode, also egresses
+// via the right physical network
+func setupGatewayBridges(bridges map[string]*bridgeConfiguration) error {
+	for _, name := range sortedGatewayBridgeNames(bridges) {
+		bridge := bridges[name]
+		if err := setBridgeOfPorts(bridge); err != nil {
+			return err
+		}
+		if config.Gateway.DisableForwarding {
+			if err := initExternalBridgeDropForwardingRules(bridge.bridgeName); err != nil {
+				return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", bridge.bridgeName, err)
+			}
+		}
+	}
+
+	for _, gwBridge := range config.Gateway.Bridges {
+		if len(gwBridge.Routes) == 0 {
+			continue
+		}
+		bridge := bridges[gwBridge.Name]
+		link, err := util.LinkSetUp(bridge.bridgeName)
+		if err != nil {
+			return err
+		}
+		for _, route := range gwBridge.Routes {
+			if err := util.LinkRoutesApply(link, route.NextHop, []*net.IPNet{route.Destination}, 0, nil); err != nil {
+				return fmt.Errorf("failed to route %s via %s on gateway bridge %s: %v",
+					route.Destination, route.NextHop, gwBridge.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedGatewayBridgeNames(bridges map[string]*bridgeConfiguration) []string {
+	names := make([]string, 0, len(bridges))
+	for name := range bridges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,173 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// egressIPCounterOpenFlowCookie identifies the open flow rules added to the
+	// host OVS bridge to count the traffic of the egress IPs of the node.
+	// The hex number 0xe1fc0417, aka eipcount, is meant to sound like egress IP counters.
+	egressIPCounterOpenFlowCookie = "0xe1fc0417"
+	// egressIPCounterFlowCacheKey is the key of the counter flows in the flow cache
+	egressIPCounterFlowCacheKey = "EgressIPCounters"
+	// egressIPCounterInterval is the interval the counters are updated at
+	egressIPCounterInterval = 30 * time.Second
+)
+
+var (
+	egressIPCounterStatsRE = regexp.MustCompile(`n_packets=(\d+), n_bytes=(\d+)`)
+	egressIPCounterSrcRE   = regexp.MustCompile(`(?:nw_src|ipv6_src)=([^ ,]+)`)
+)
+
+// egressIPCounter is the traffic of an egress IP counted by its flow
+type egressIPCounter struct {
+	packets uint64
+	bytes   uint64
+}
+
+// egressIPCounters counts the traffic sent to the external network by the
+// egress IPs assigned to the node. The counter flows do the same as the
+// default flow of the traffic coming from OVN, only with a higher priority,
+// so that their statistics only account for the traffic of each egress IP.
+type egressIPCounters struct {
+	nodeName     string
+	bridge       *bridgeConfiguration
+	ofm          *openflowManager
+	watchFactory *factory.WatchFactory
+	// flows are the counter flows in the flow cache
+	flows []string
+}
+
+func newEgressIPCounters(nodeName string, bridge *bridgeConfiguration, ofm *openflowManager, wf *factory.WatchFactory) *egressIPCounters {
+	return &egressIPCounters{
+		nodeName:     nodeName,
+		bridge:       bridge,
+		ofm:          ofm,
+		watchFactory: wf,
+	}
+}
+
+// Run updates the counter flows and the metrics periodically until stopChan
+// is closed
+func (c *egressIPCounters) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(c.sync, egressIPCounterInterval, stopChan)
+	}()
+}
+
+func (c *egressIPCounters) sync() {
+	egressIPs, err := c.getEgressIPs()
+	if err != nil {
+		klog.Errorf("Failed to get the egress IPs of node %s: %v", c.nodeName, err)
+		return
+	}
+	flows := c.getFlows(egressIPs)
+	if !reflect.DeepEqual(flows, c.flows) {
+		c.flows = flows
+		c.ofm.updateFlowCacheEntry(egressIPCounterFlowCacheKey, flows)
+		c.ofm.requestFlowSync()
+	}
+
+	stdout, stderr, err := util.RunOVSOfctl("dump-flows", c.bridge.bridgeName,
+		fmt.Sprintf("cookie=%s/-1", egressIPCounterOpenFlowCookie))
+	if err != nil {
+		klog.Errorf("Failed to dump the egress IP counter flows of bridge %s, stderr: %q, error: %v",
+			c.bridge.bridgeName, stderr, err)
+		return
+	}
+	metrics.ResetEgressIPTrafficMetrics()
+	for ip, counter := range parseEgressIPCounterFlows(stdout) {
+		if name, ok := egressIPs[ip]; ok {
+			metrics.SetEgressIPTrafficMetrics(name, ip, counter.bytes, counter.packets)
+		}
+	}
+}
+
+// getEgressIPs returns the name of the EgressIP of each egress IP assigned to
+// the node
+func (c *egressIPCounters) getEgressIPs() (map[string]string, error) {
+	eips, err := c.watchFactory.GetEgressIPs()
+	if err != nil {
+		return nil, err
+	}
+	egressIPs := map[string]string{}
+	for _, eip := range eips {
+		for _, status := range eip.Status.Items {
+			if status.Node != c.nodeName {
+				continue
+			}
+			ip := utilnet.ParseIPSloppy(status.EgressIP)
+			if ip == nil {
+				continue
+			}
+			egressIPs[ip.String()] = eip.Name
+		}
+	}
+	return egressIPs, nil
+}
+
+// getFlows returns the counter flows of the egress IPs
+func (c *egressIPCounters) getFlows(egressIPs map[string]string) []string {
+	c.bridge.Lock()
+	ofPortPatch, ofPortPhys := c.bridge.ofPortPatch, c.bridge.ofPortPhys
+	c.bridge.Unlock()
+
+	flows := []string{}
+	for ip := range egressIPs {
+		ipPrefix := "ip"
+		if utilnet.IsIPv6String(ip) {
+			ipPrefix = "ipv6"
+		}
+		// table 0, packets of the egress IP coming from pods headed externally,
+		// handled like the priority 100 flow.
+		flows = append(flows,
+			fmt.Sprintf("cookie=%s, priority=101, in_port=%s, %s, %s_src=%s, "+
+				"actions=ct(commit, zone=%d, exec(set_field:%s->ct_mark)), output:%s",
+				egressIPCounterOpenFlowCookie, ofPortPatch, ipPrefix, ipPrefix, ip,
+				config.Default.ConntrackZone, ctMarkOVN, ofPortPhys))
+	}
+	sort.Strings(flows)
+	return flows
+}
+
+// parseEgressIPCounterFlows returns the counters of the flows of dump-flows
+// by egress IP
+func parseEgressIPCounterFlows(stdout string) map[string]egressIPCounter {
+	counters := map[string]egressIPCounter{}
+	for _, line := range strings.Split(stdout, "\n") {
+		stats := egressIPCounterStatsRE.FindStringSubmatch(line)
+		src := egressIPCounterSrcRE.FindStringSubmatch(line)
+		if stats == nil || src == nil {
+			continue
+		}
+		packets, err := strconv.ParseUint(stats[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		bytes, err := strconv.ParseUint(stats[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		counters[src[1]] = egressIPCounter{packets: packets, bytes: bytes}
+	}
+	return counters
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipv1fake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Gateway EgressIP counters", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		config.Metrics.EnableTrafficMetrics = true
+	})
+
+	It("adds a counter flow for each egress IP assigned to the node", func() {
+		eip := &egressipv1.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip1"},
+			Status: egressipv1.EgressIPStatus{
+				Items: []egressipv1.EgressIPStatusItem{
+					{Node: "node1", EgressIP: "172.18.0.100"},
+					{Node: "node1", EgressIP: "fc00:f853:ccd:e793::64"},
+					{Node: "node2", EgressIP: "172.18.0.101"},
+				},
+			},
+		}
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{
+			KubeClient:     fake.NewSimpleClientset(),
+			EgressIPClient: egressipv1fake.NewSimpleClientset(&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{*eip}}),
+		}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		c := newEgressIPCounters("node1", &bridgeConfiguration{bridgeName: "breth0", ofPortPatch: "2", ofPortPhys: "1"}, nil, wf)
+		egressIPs, err := c.getEgressIPs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressIPs).To(Equal(map[string]string{
+			"172.18.0.100":           "eip1",
+			"fc00:f853:ccd:e793::64": "eip1",
+		}))
+		Expect(c.getFlows(egressIPs)).To(Equal([]string{
+			"cookie=0xe1fc0417, priority=101, in_port=2, ip, ip_src=172.18.0.100, " +
+				"actions=ct(commit, zone=64000, exec(set_field:0x1->ct_mark)), output:1",
+			"cookie=0xe1fc0417, priority=101, in_port=2, ipv6, ipv6_src=fc00:f853:ccd:e793::64, " +
+				"actions=ct(commit, zone=64000, exec(set_field:0x1->ct_mark)), output:1",
+		}))
+	})
+
+	It("parses the statistics of the counter flows", func() {
+		stdout := "NXST_FLOW reply (xid=0x4):\n" +
+			" cookie=0xe1fc0417, duration=12.3s, table=0, n_packets=10, n_bytes=980, idle_age=1, priority=101,ip,in_port=2,nw_src=172.18.0.100 " +
+			"actions=ct(commit,zone=64000,exec(load:0x1->NXM_NX_CT_MARK[])),output:1\n" +
+			" cookie=0xe1fc0417, duration=12.3s, table=0, n_packets=0, n_bytes=0, idle_age=1, priority=101,ipv6,in_port=2,ipv6_src=fc00:f853:ccd:e793::64 " +
+			"actions=ct(commit,zone=64000,exec(load:0x1->NXM_NX_CT_MARK[])),output:1\n"
+		Expect(parseEgressIPCounterFlows(stdout)).To(Equal(map[string]egressIPCounter{
+			"172.18.0.100":           {packets: 10, bytes: 980},
+			"fc00:f853:ccd:e793::64": {},
+		}))
+	})
+})
@@ -342,6 +342,13 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 		klog.Errorf("Unable to set primary IP net label on node, err: %v", err)
 	}
 
+	// clean up the host configuration of the previous gateway mode of the node
+	// before creating the gateway of the new mode
+	reportGatewayModeMigration, err := nc.startGatewayModeMigration(managementPortConfig)
+	if err != nil {
+		return err
+	}
+
 	var gw *gateway
 	switch config.Gateway.Mode {
 	case config.GatewayModeLocal:
@@ -385,7 +392,11 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 	}
 
 	initGwFunc := func() error {
-		return gw.Init(nc.watchFactory, nc.stopChan, nc.wg)
+		err := gw.Init(nc.watchFactory, nc.stopChan, nc.wg)
+		if reportGatewayModeMigration {
+			nc.finishGatewayModeMigration(err)
+		}
+		return err
 	}
 
 	readyGwFunc := func() (bool, error) {
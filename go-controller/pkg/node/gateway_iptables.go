@@ -285,7 +285,7 @@ func generateIPTRulesForLoadBalancersWithoutNodePorts(svcPort kapi.ServicePort,
 	}
 	numLocalEndpoints := len(localEndpoints)
 	for i, ip := range localEndpoints {
-		iptRules = append([]iptRule{
+		rules := []iptRule{
 			{
 				table: "nat",
 				chain: iptableETPChain,
@@ -301,7 +301,11 @@ func generateIPTRulesForLoadBalancersWithoutNodePorts(svcPort kapi.ServicePort,
 				},
 				protocol: getIPTablesProtocol(externalIP),
 			},
-			{
+		}
+		// the traffic to the management port is not masqueraded when the
+		// traffic of the pods to the host is routed back to it by OVN
+		if !config.Gateway.MgmtPortOVNRouting {
+			rules = append(rules, iptRule{
 				table: "nat",
 				chain: iptableMgmPortChain,
 				args: []string{
@@ -311,8 +315,9 @@ func generateIPTRulesForLoadBalancersWithoutNodePorts(svcPort kapi.ServicePort,
 					"-j", "RETURN",
 				},
 				protocol: getIPTablesProtocol(externalIP),
-			},
-		}, iptRules...)
+			})
+		}
+		iptRules = append(rules, iptRules...)
 	}
 	return iptRules
 }
@@ -613,7 +618,9 @@ func getGatewayIPTRules(service *kapi.Service, localEndpoints []string, svcHasLo
 					// A DNAT rule to masqueradeIP is added that takes priority over DNAT to clusterIP.
 					rules = append(rules, getNodePortIPTRules(svcPort, clusterIP, svcPort.NodePort, svcHasLocalHostNetEndPnt, svcTypeIsETPLocal)...)
 					// add a skip SNAT rule to OVN-KUBE-SNAT-MGMTPORT to preserve sourceIP for etp=local traffic.
-					rules = append(rules, getNodePortETPLocalIPTRules(svcPort, clusterIP)...)
+					if !config.Gateway.MgmtPortOVNRouting {
+						rules = append(rules, getNodePortETPLocalIPTRules(svcPort, clusterIP)...)
+					}
 				}
 				// case2 (see function description for details)
 				rules = append(rules, getNodePortIPTRules(svcPort, clusterIP, svcPort.Port, svcHasLocalHostNetEndPnt, false)...)
@@ -41,7 +41,7 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 		}
 	}
 
-	gwBridge, exGwBridge, err := gatewayInitInternal(
+	gwBridge, exGwBridge, gwBridges, err := gatewayInitInternal(
 		nodeName, gwIntf, egressGWIntf, gwNextHops, gwIPs, nodeAnnotator)
 	if err != nil {
 		return nil, err
@@ -64,6 +64,7 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 			return gatewayReady(gwBridge.patchPort)
 		}
 	}
+	gw.readyFunc = withGatewayBridgesReady(gw.readyFunc, gwBridges)
 
 	gw.initFunc = func() error {
 		klog.Info("Creating Local Gateway Openflow Manager")
@@ -82,6 +83,9 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 				}
 			}
 		}
+		if err := setupGatewayBridges(gwBridges); err != nil {
+			return err
+		}
 
 		gw.nodeIPManager = newAddressManager(nodeName, kube, cfg, watchFactory, gwBridge)
 
@@ -93,10 +97,14 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 			return fmt.Errorf("failed to set the node masquerade route to OVN: %v", err)
 		}
 
-		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, hostSubnets, gw.nodeIPManager.ListAddresses())
+		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, gwBridges, hostSubnets, gw.nodeIPManager.ListAddresses())
 		if err != nil {
 			return err
 		}
+
+		if config.Metrics.EnableTrafficMetrics && config.OVNKubernetesFeature.EnableEgressIP {
+			gw.egressIPCounters = newEgressIPCounters(nodeName, gwBridge, gw.openflowManager, watchFactory.(*factory.WatchFactory))
+		}
 		// resync flows on IP change
 		gw.nodeIPManager.OnChanged = func() {
 			klog.V(5).Info("Node addresses changed, re-syncing bridge flows")
@@ -0,0 +1,157 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// gatewayModeMigrationCondition is the node condition reporting the
+	// migration of the gateway of the node between the shared and local
+	// gateway modes. It is True while the node is being migrated.
+	gatewayModeMigrationCondition kapi.NodeConditionType = "GatewayModeMigration"
+
+	gatewayModeMigrationReasonMigrating = "Migrating"
+	gatewayModeMigrationReasonMigrated  = "Migrated"
+	gatewayModeMigrationReasonFailed    = "MigrationFailed"
+)
+
+// gatewayModeMigration is a migration of the gateway of the node from the mode
+// it last published to the configured mode
+type gatewayModeMigration struct {
+	from config.GatewayMode
+	to   config.GatewayMode
+}
+
+func (m *gatewayModeMigration) String() string {
+	return fmt.Sprintf("from %s to %s gateway mode", m.from, m.to)
+}
+
+// getGatewayModeMigration returns the migration of the gateway of the node,
+// when the mode published in its l3 gateway annotation by the previous run of
+// ovnkube-node is another of the shared and local gateway modes than the
+// configured one, or nil otherwise
+func getGatewayModeMigration(node *kapi.Node) *gatewayModeMigration {
+	if config.Gateway.Mode != config.GatewayModeShared && config.Gateway.Mode != config.GatewayModeLocal {
+		return nil
+	}
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return nil
+	}
+	if l3GatewayConfig.Mode != config.GatewayModeShared && l3GatewayConfig.Mode != config.GatewayModeLocal {
+		return nil
+	}
+	if l3GatewayConfig.Mode == config.Gateway.Mode {
+		return nil
+	}
+	return &gatewayModeMigration{from: l3GatewayConfig.Mode, to: config.Gateway.Mode}
+}
+
+// isGatewayModeMigrationInProgress returns true if the gateway mode migration
+// condition of the node is True, when a previous run of ovnkube-node failed to
+// complete the migration after publishing the new mode
+func isGatewayModeMigrationInProgress(node *kapi.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == gatewayModeMigrationCondition {
+			return condition.Status == kapi.ConditionTrue
+		}
+	}
+	return false
+}
+
+// cleanupGatewayMode removes the host configuration that is specific to the
+// gateway mode the node is migrated from, before the gateway of the new mode is
+// created. The OVN routes of the node are reconciled by ovnkube-master, which
+// follows the gateway mode it is configured with.
+func cleanupGatewayMode(mode config.GatewayMode, cfg *managementPortConfig) error {
+	switch mode {
+	case config.GatewayModeShared:
+		// remove the patch port of the gateway bridge, its OpenFlow flows and
+		// the nodeport and external IP iptables chains; ovn-controller and the
+		// gateway of the new mode create them again
+		if err := cleanupSharedGateway(); err != nil {
+			return fmt.Errorf("failed to clean up the shared gateway: %v", err)
+		}
+	case config.GatewayModeLocal:
+		// remove the masquerading of the traffic leaving the host from the
+		// management port, which only happens in local gateway mode
+		for _, familyCfg := range []*managementPortIPFamilyConfig{cfg.ipv4, cfg.ipv6} {
+			if familyCfg == nil {
+				continue
+			}
+			cidrNet := &net.IPNet{IP: familyCfg.ifAddr.IP.Mask(familyCfg.ifAddr.Mask), Mask: familyCfg.ifAddr.Mask}
+			if err := delIptRules(getLocalGatewayNATRules(cfg.ifName, cidrNet)); err != nil {
+				return fmt.Errorf("failed to delete local gateway NAT rules for %s: %v", cfg.ifName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// startGatewayModeMigration migrates the host configuration of the node when
+// its gateway mode changed since the previous run of ovnkube-node, and reports
+// the migration in the node conditions. It returns whether the completion of a
+// migration must be reported once the gateway of the new mode is initialized.
+func (nc *DefaultNodeNetworkController) startGatewayModeMigration(cfg *managementPortConfig) (bool, error) {
+	node, err := nc.Kube.GetNode(nc.name)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving node %s: %v", nc.name, err)
+	}
+	migration := getGatewayModeMigration(node)
+	if migration == nil {
+		return isGatewayModeMigrationInProgress(node), nil
+	}
+
+	klog.Infof("Migrating node %s %s", nc.name, migration)
+	nc.setGatewayModeMigrationCondition(kapi.ConditionTrue, gatewayModeMigrationReasonMigrating,
+		fmt.Sprintf("Migrating %s", migration))
+	if config.OvnKubeNode.Mode == types.NodeModeFull {
+		if err := cleanupGatewayMode(migration.from, cfg); err != nil {
+			nc.setGatewayModeMigrationCondition(kapi.ConditionTrue, gatewayModeMigrationReasonFailed,
+				fmt.Sprintf("Failed to migrate %s: %v", migration, err))
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// finishGatewayModeMigration reports the result of the initialization of the
+// gateway of the new mode in the gateway mode migration condition of the node
+func (nc *DefaultNodeNetworkController) finishGatewayModeMigration(initErr error) {
+	if initErr != nil {
+		nc.setGatewayModeMigrationCondition(kapi.ConditionTrue, gatewayModeMigrationReasonFailed,
+			fmt.Sprintf("Failed to initialize the %s gateway: %v", config.Gateway.Mode, initErr))
+		return
+	}
+	klog.Infof("Migrated node %s to %s gateway mode", nc.name, config.Gateway.Mode)
+	nc.setGatewayModeMigrationCondition(kapi.ConditionFalse, gatewayModeMigrationReasonMigrated,
+		fmt.Sprintf("Migrated to %s gateway mode", config.Gateway.Mode))
+}
+
+// setGatewayModeMigrationCondition sets the gateway mode migration condition
+// of the node. Failing to report the progress of the migration does not fail
+// the migration itself.
+func (nc *DefaultNodeNetworkController) setGatewayModeMigrationCondition(status kapi.ConditionStatus,
+	reason, message string) {
+	resultErr := updateNodeCondition(nc.Kube, nc.name, kapi.NodeCondition{
+		Type:    gatewayModeMigrationCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if resultErr != nil {
+		klog.Errorf("Failed to set the %s condition of node %s to %s: %v", gatewayModeMigrationCondition, nc.name,
+			reason, resultErr)
+	}
+}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Gateway mode migration", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	newNode := func(mode string) *v1.Node {
+		annotations := map[string]string{
+			"k8s.ovn.org/node-chassis-id": "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+		}
+		if mode != "" {
+			annotations["k8s.ovn.org/l3-gateway-config"] = `{"default":{"mode":"` + mode +
+				`","mac-address":"52:54:00:e2:ed:d0","ip-addresses":["192.168.122.14/24"],"next-hops":["192.168.122.1"]}}`
+		}
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: annotations}}
+	}
+
+	It("migrates the node when its published gateway mode is another enabled mode", func() {
+		config.Gateway.Mode = config.GatewayModeLocal
+		Expect(getGatewayModeMigration(newNode("shared"))).To(Equal(&gatewayModeMigration{
+			from: config.GatewayModeShared,
+			to:   config.GatewayModeLocal,
+		}))
+
+		config.Gateway.Mode = config.GatewayModeShared
+		Expect(getGatewayModeMigration(newNode("local"))).To(Equal(&gatewayModeMigration{
+			from: config.GatewayModeLocal,
+			to:   config.GatewayModeShared,
+		}))
+	})
+
+	It("does not migrate new nodes, nodes in the same mode, or from or to a disabled gateway", func() {
+		config.Gateway.Mode = config.GatewayModeShared
+		Expect(getGatewayModeMigration(newNode(""))).To(BeNil())
+		Expect(getGatewayModeMigration(newNode("shared"))).To(BeNil())
+
+		config.Gateway.Mode = config.GatewayModeDisabled
+		Expect(getGatewayModeMigration(newNode("local"))).To(BeNil())
+	})
+
+	It("sets the migration condition and keeps its transition time while its status does not change", func() {
+		node := newNode("shared")
+		setNodeCondition(node, v1.NodeCondition{
+			Type:   gatewayModeMigrationCondition,
+			Status: v1.ConditionTrue,
+			Reason: gatewayModeMigrationReasonMigrating,
+		})
+		Expect(node.Status.Conditions).To(HaveLen(1))
+		Expect(isGatewayModeMigrationInProgress(node)).To(BeTrue())
+		transitionTime := metav1.NewTime(node.Status.Conditions[0].LastTransitionTime.Add(-time.Minute))
+		node.Status.Conditions[0].LastTransitionTime = transitionTime
+
+		setNodeCondition(node, v1.NodeCondition{
+			Type:   gatewayModeMigrationCondition,
+			Status: v1.ConditionTrue,
+			Reason: gatewayModeMigrationReasonFailed,
+		})
+		Expect(node.Status.Conditions).To(HaveLen(1))
+		Expect(node.Status.Conditions[0].Reason).To(Equal(gatewayModeMigrationReasonFailed))
+		Expect(node.Status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+
+		setNodeCondition(node, v1.NodeCondition{
+			Type:   gatewayModeMigrationCondition,
+			Status: v1.ConditionFalse,
+			Reason: gatewayModeMigrationReasonMigrated,
+		})
+		Expect(node.Status.Conditions).To(HaveLen(1))
+		Expect(node.Status.Conditions[0].LastTransitionTime).NotTo(Equal(transitionTime))
+		Expect(isGatewayModeMigrationInProgress(node)).To(BeFalse())
+	})
+
+	Context("cleaning up the previous gateway mode", func() {
+		const mgmtPortName = "ovn-k8s-mp0"
+		var (
+			fExec      *ovntest.FakeExec
+			iptV4      util.IPTablesHelper
+			mgmtPortIP = ovntest.MustParseIPNet("10.1.1.2/24")
+			mgmtPort   = &managementPortConfig{
+				ifName: mgmtPortName,
+				ipv4:   &managementPortIPFamilyConfig{ifAddr: mgmtPortIP},
+			}
+		)
+
+		BeforeEach(func() {
+			fExec = ovntest.NewFakeExec()
+			Expect(util.SetExec(fExec)).To(Succeed())
+			iptV4, _ = util.SetFakeIPTablesHelpers()
+		})
+
+		It("removes the shared gateway patch port, flows and iptables chains when migrating to local gateway mode", func() {
+			for _, chain := range []string{iptableNodePortChain, iptableExternalIPChain} {
+				Expect(iptV4.NewChain("nat", chain)).To(Succeed())
+				Expect(iptV4.Append("nat", chain, "-d", "172.18.0.2", "-j", "DNAT")).To(Succeed())
+			}
+			fExec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --columns=name --no-heading find port external_ids:ovn-localnet-port!=_",
+				Output: "patch-breth0_node1-to-br-int",
+			})
+			fExec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=15 --if-exists del-port patch-breth0_node1-to-br-int",
+			})
+			fExec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+				Output: "physnet:breth0",
+			})
+			fExec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-ofctl -O OpenFlow13 replace-flows breth0 -",
+			})
+
+			Expect(cleanupGatewayMode(config.GatewayModeShared, mgmtPort)).To(Succeed())
+			Expect(fExec.CalledMatchesExpected()).To(BeTrue(), fExec.ErrorDesc)
+			f4 := iptV4.(*util.FakeIPTables)
+			Expect(f4.MatchState(map[string]util.FakeTable{
+				"nat":    {},
+				"filter": {},
+				"mangle": {},
+			})).To(Succeed())
+		})
+
+		It("removes the management port masquerading when migrating to shared gateway mode", func() {
+			Expect(initLocalGatewayNATRules(mgmtPortName, ovntest.MustParseIPNet("10.1.1.0/24"))).To(Succeed())
+
+			Expect(cleanupGatewayMode(config.GatewayModeLocal, mgmtPort)).To(Succeed())
+			Expect(fExec.CalledMatchesExpected()).To(BeTrue(), fExec.ErrorDesc)
+			f4 := iptV4.(*util.FakeIPTables)
+			Expect(f4.MatchState(map[string]util.FakeTable{
+				"nat":    {"POSTROUTING": []string{}},
+				"filter": {"FORWARD": []string{}, "INPUT": []string{}},
+				"mangle": {},
+			})).To(Succeed())
+		})
+	})
+})
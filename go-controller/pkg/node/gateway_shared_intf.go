@@ -771,7 +771,11 @@ func (npw *nodePortWatcher) SyncServices(services []interface{}) error {
 	// sync IPtables rules once only for Full mode
 	if !npw.dpuMode {
 		// (NOTE: Order is important, add jump to iptableETPChain before jump to NP/EIP chains)
-		for _, chain := range []string{iptableITPChain, iptableESVCChain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain, iptableMgmPortChain} {
+		chains := []string{iptableITPChain, iptableESVCChain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain}
+		if !config.Gateway.MgmtPortOVNRouting {
+			chains = append(chains, iptableMgmPortChain)
+		}
+		for _, chain := range chains {
 			if err = recreateIPTRules("nat", chain, keepIPTRules); err != nil {
 				errors = append(errors, err)
 			}
@@ -1079,7 +1083,8 @@ func (npwipt *nodePortWatcherIptables) SyncServices(services []interface{}) erro
 //
 // -- to handle host -> service access, via masquerading from the host to OVN GR
 // -- to handle external -> service(ExternalTrafficPolicy: Local) -> host access without SNAT
-func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, subnets []*net.IPNet, extraIPs []net.IP) (*openflowManager, error) {
+func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, gwBridges map[string]*bridgeConfiguration,
+	subnets []*net.IPNet, extraIPs []net.IP) (*openflowManager, error) {
 	// add health check function to check default OpenFlow flows are on the shared gateway bridge
 	ofm := &openflowManager{
 		defaultBridge:         gwBridge,
@@ -1088,6 +1093,8 @@ func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, subnet
 		flowMutex:             sync.Mutex{},
 		exGWFlowCache:         make(map[string][]string),
 		exGWFlowMutex:         sync.Mutex{},
+		gatewayBridges:        gwBridges,
+		gatewayBridgeFlows:    make(map[string][]string),
 		flowChan:              make(chan struct{}, 1),
 	}
 
@@ -1128,6 +1135,19 @@ func (ofm *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs
 		}
 		ofm.updateExBridgeFlowCacheEntry("DEFAULT", exGWBridgeDftFlows)
 	}
+
+	// the additional named gateway bridges only need the same flows as the
+	// ex gw bridge
+	for name, bridge := range ofm.gatewayBridges {
+		gwBridgeDftFlows, err := commonFlows(subnets, bridge)
+		if err != nil {
+			return err
+		}
+		gwBridgeDftFlows = append(gwBridgeDftFlows, fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction))
+		ofm.flowMutex.Lock()
+		ofm.gatewayBridgeFlows[name] = gwBridgeDftFlows
+		ofm.flowMutex.Unlock()
+	}
 	return nil
 }
 
@@ -1690,7 +1710,7 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 	klog.Info("Creating new shared gateway")
 	gw := &gateway{}
 
-	gwBridge, exGwBridge, err := gatewayInitInternal(
+	gwBridge, exGwBridge, gwBridges, err := gatewayInitInternal(
 		nodeName, gwIntf, egressGWIntf, gwNextHops, gwIPs, nodeAnnotator)
 	if err != nil {
 		return nil, err
@@ -1713,6 +1733,7 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 			return gatewayReady(gwBridge.patchPort)
 		}
 	}
+	gw.readyFunc = withGatewayBridgesReady(gw.readyFunc, gwBridges)
 
 	gw.initFunc = func() error {
 		// Program cluster.GatewayIntf to let non-pod traffic to go to host
@@ -1733,6 +1754,9 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 				}
 			}
 		}
+		if err := setupGatewayBridges(gwBridges); err != nil {
+			return err
+		}
 		gw.nodeIPManager = newAddressManager(nodeName, kube, cfg, watchFactory, gwBridge)
 		nodeIPs := gw.nodeIPManager.ListAddresses()
 
@@ -1746,11 +1770,15 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 			}
 		}
 
-		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, subnets, nodeIPs)
+		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, gwBridges, subnets, nodeIPs)
 		if err != nil {
 			return err
 		}
 
+		if config.Metrics.EnableTrafficMetrics && config.OVNKubernetesFeature.EnableEgressIP {
+			gw.egressIPCounters = newEgressIPCounters(nodeName, gwBridge, gw.openflowManager, watchFactory.(*factory.WatchFactory))
+		}
+
 		// resync flows on IP change
 		gw.nodeIPManager.OnChanged = func() {
 			klog.V(5).Info("Node addresses changed, re-syncing bridge flows")
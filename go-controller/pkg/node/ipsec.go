@@ -0,0 +1,341 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ipsecCACertFile is the copy of the CA certificate bundle of the signer
+	// in the IPsec certificate directory
+	ipsecCACertFile = "ipsec-cacert.pem"
+	// ipsecCertCheckInterval is the interval the IPsec certificate is checked at
+	ipsecCertCheckInterval = time.Hour
+	// ipsecCertRetryInterval is the interval a failed IPsec certificate
+	// check or renewal is retried at
+	ipsecCertRetryInterval = time.Minute
+	// ipsecCSRTimeout is the time to wait for the IPsec certificate signing
+	// request to be signed
+	ipsecCSRTimeout = 5 * time.Minute
+	// ipsecCertRenewFraction is the fraction of the lifetime of the IPsec
+	// certificate after which it is renewed
+	ipsecCertRenewFraction = 0.8
+)
+
+// ipsecCertManager manages the IPsec private key and certificate of the node
+// chassis. It requests the certificate with a certificate signing request
+// whose common name is the chassis ID, as required by OVN, configures their
+// paths in the other_config of OVS for ovs-monitor-ipsec, and renews the
+// certificate before it expires. New keys and certificates are written to new
+// files, so that ovs-monitor-ipsec sees their paths change and loads them.
+type ipsecCertManager struct {
+	nodeName string
+	client   clientset.Interface
+	recorder record.EventRecorder
+	nodeRef  *kapi.ObjectReference
+	certDir  string
+}
+
+func newIPsecCertManager(nodeName string, client clientset.Interface, recorder record.EventRecorder) *ipsecCertManager {
+	return &ipsecCertManager{
+		nodeName: nodeName,
+		client:   client,
+		recorder: recorder,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		certDir: config.OvnKubeNode.IPsecCertDir,
+	}
+}
+
+// Run checks the IPsec certificate, and renews it when needed, until stopChan
+// is closed
+func (m *ipsecCertManager) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			next := ipsecCertCheckInterval
+			notAfter, err := m.sync()
+			if err != nil {
+				klog.Errorf("Failed to configure the IPsec certificate of node %s: %v", m.nodeName, err)
+				m.recorder.Eventf(m.nodeRef, kapi.EventTypeWarning, "IPsecCertificateFailed",
+					"Failed to configure the IPsec certificate: %v", err)
+				next = ipsecCertRetryInterval
+			} else {
+				metrics.SetIPsecCertificateExpiration(notAfter)
+			}
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(next):
+			}
+		}
+	}()
+}
+
+// sync makes sure OVS is configured with a valid IPsec certificate of the
+// chassis, requesting a new one if it is missing or due for renewal, and
+// returns when it expires
+func (m *ipsecCertManager) sync() (time.Time, error) {
+	chassisID, err := util.GetNodeChassisID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	caCertPath, err := m.syncCACert()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var paths []string
+	for _, key := range []string{"certificate", "private_key"} {
+		path, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:"+key)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to get the OVS %s configuration, stderr: %q, error: %v", key, stderr, err)
+		}
+		paths = append(paths, path)
+	}
+	certPath, keyPath := paths[0], paths[1]
+	if certPath != "" && keyPath != "" {
+		certificate, err := loadIPsecCertificate(certPath, keyPath, chassisID)
+		if err == nil && time.Now().Before(getIPsecCertRenewTime(certificate)) {
+			return certificate.NotAfter, m.configureOVS(certPath, keyPath, caCertPath)
+		}
+		if err != nil {
+			klog.Infof("Requesting a new IPsec certificate for node %s: %v", m.nodeName, err)
+		} else {
+			klog.Infof("Renewing the IPsec certificate of node %s expiring at %v", m.nodeName, certificate.NotAfter)
+		}
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to generate a private key: %v", err)
+	}
+	certPEM, err := m.requestCertificate(key, chassisID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	certificate, err := parseIPsecCertificate(certPEM, key.Public(), chassisID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid signed certificate: %v", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	suffix := certificate.NotBefore.UTC().Format("20060102150405")
+	newCertPath := filepath.Join(m.certDir, fmt.Sprintf("ipsec-cert-%s.pem", suffix))
+	newKeyPath := filepath.Join(m.certDir, fmt.Sprintf("ipsec-privkey-%s.pem", suffix))
+	if err := os.MkdirAll(m.certDir, 0700); err != nil {
+		return time.Time{}, err
+	}
+	if err := os.WriteFile(newKeyPath, keyPEM, 0600); err != nil {
+		return time.Time{}, err
+	}
+	if err := os.WriteFile(newCertPath, certPEM, 0644); err != nil {
+		return time.Time{}, err
+	}
+	if err := m.configureOVS(newCertPath, newKeyPath, caCertPath); err != nil {
+		return time.Time{}, err
+	}
+	// remove the previous files once OVS no longer uses them
+	for _, path := range []string{certPath, keyPath} {
+		if path != "" && path != newCertPath && path != newKeyPath && filepath.Dir(path) == filepath.Clean(m.certDir) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				klog.Warningf("Failed to remove the previous IPsec file %s: %v", path, err)
+			}
+		}
+	}
+	m.recorder.Eventf(m.nodeRef, kapi.EventTypeNormal, "IPsecCertificateIssued",
+		"Configured a new IPsec certificate expiring at %v", certificate.NotAfter)
+	return certificate.NotAfter, nil
+}
+
+// syncCACert copies the CA certificate bundle of the signer to the IPsec
+// certificate directory if it changed, and returns the path of the copy
+func (m *ipsecCertManager) syncCACert() (string, error) {
+	caCert, err := os.ReadFile(config.OvnKubeNode.IPsecCACert)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the IPsec CA certificate: %v", err)
+	}
+	if _, err := cert.ParseCertsPEM(caCert); err != nil {
+		return "", fmt.Errorf("invalid IPsec CA certificate %s: %v", config.OvnKubeNode.IPsecCACert, err)
+	}
+	path := filepath.Join(m.certDir, ipsecCACertFile)
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, caCert) {
+		return path, nil
+	}
+	if err := os.MkdirAll(m.certDir, 0700); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, caCert, 0644)
+}
+
+// requestCertificate requests a certificate of the chassis for the private
+// key with a certificate signing request, and returns it once it is signed
+func (m *ipsecCertManager) requestCertificate(key crypto.Signer, chassisID string) ([]byte, error) {
+	csrPEM, err := cert.MakeCSR(key, &pkix.Name{CommonName: chassisID, Organization: []string{"ovnkubernetes"}},
+		[]string{chassisID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate signing request: %v", err)
+	}
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("ovn-ipsec-%s-", m.nodeName),
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    csrPEM,
+			SignerName: config.OvnKubeNode.IPsecSignerName,
+			Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+		},
+	}
+	csr, err = m.client.CertificatesV1().CertificateSigningRequests().Create(context.TODO(), csr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate signing request: %v", err)
+	}
+	defer func() {
+		// the signed certificate is only needed once
+		if err := m.client.CertificatesV1().CertificateSigningRequests().Delete(context.TODO(), csr.Name,
+			metav1.DeleteOptions{}); err != nil {
+			klog.Warningf("Failed to delete the certificate signing request %s: %v", csr.Name, err)
+		}
+	}()
+
+	var certPEM []byte
+	err = wait.PollImmediate(time.Second, ipsecCSRTimeout, func() (bool, error) {
+		current, err := m.client.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), csr.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(5).Infof("Failed to get the certificate signing request %s: %v", csr.Name, err)
+			return false, nil
+		}
+		for _, condition := range current.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				return false, fmt.Errorf("certificate signing request %s %s: %s", csr.Name, condition.Type, condition.Message)
+			}
+		}
+		certPEM = current.Status.Certificate
+		return len(certPEM) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the certificate signing request %s signed: %v", csr.Name, err)
+	}
+	return certPEM, nil
+}
+
+// configureOVS sets the paths of the IPsec certificates and private key in
+// the other_config of OVS
+func (m *ipsecCertManager) configureOVS(certPath, keyPath, caCertPath string) error {
+	_, stderr, err := util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		"other_config:certificate="+certPath,
+		"other_config:private_key="+keyPath,
+		"other_config:ca_cert="+caCertPath)
+	if err != nil {
+		return fmt.Errorf("failed to configure the IPsec certificate in OVS, stderr: %q, error: %v", stderr, err)
+	}
+	return nil
+}
+
+// cleanupIPsecCertificates removes the IPsec configuration of OVS and the
+// IPsec files of the node once IPsec is disabled. The configuration is only
+// removed if it points to the IPsec certificate directory, so that
+// certificates configured by other means are left untouched.
+func cleanupIPsecCertificates() error {
+	certDir := filepath.Clean(config.OvnKubeNode.IPsecCertDir)
+	certPath, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:certificate")
+	if err != nil {
+		return fmt.Errorf("failed to get the OVS certificate configuration, stderr: %q, error: %v", stderr, err)
+	}
+	if certPath != "" && filepath.Dir(certPath) == certDir {
+		_, stderr, err = util.RunOVSVsctl("remove", "Open_vSwitch", ".", "other_config",
+			"certificate", "private_key", "ca_cert")
+		if err != nil {
+			return fmt.Errorf("failed to remove the IPsec certificate configuration of OVS, stderr: %q, error: %v", stderr, err)
+		}
+		klog.Infof("Removed the IPsec certificate configuration of OVS as IPsec is disabled")
+	}
+
+	var paths []string
+	for _, pattern := range []string{"ipsec-cert-*.pem", "ipsec-privkey-*.pem", ipsecCACertFile} {
+		matches, err := filepath.Glob(filepath.Join(certDir, pattern))
+		if err != nil {
+			return err
+		}
+		paths = append(paths, matches...)
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove the IPsec file %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// loadIPsecCertificate returns the certificate of the chassis at certPath
+// after checking it is for the private key at keyPath
+func loadIPsecCertificate(certPath, keyPath, chassisID string) (*x509.Certificate, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyutil.PrivateKeyFromFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key in %s", keyPath)
+	}
+	return parseIPsecCertificate(certPEM, signer.Public(), chassisID)
+}
+
+// parseIPsecCertificate returns the first certificate of certPEM after
+// checking it is the certificate of the chassis for the public key
+func parseIPsecCertificate(certPEM []byte, publicKey crypto.PublicKey, chassisID string) (*x509.Certificate, error) {
+	certs, err := cert.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	certificate := certs[0]
+	if certificate.Subject.CommonName != chassisID {
+		return nil, fmt.Errorf("certificate common name %q is not the chassis ID %q", certificate.Subject.CommonName, chassisID)
+	}
+	if key, ok := publicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !key.Equal(certificate.PublicKey) {
+		return nil, fmt.Errorf("certificate is not for the private key")
+	}
+	return certificate, nil
+}
+
+// getIPsecCertRenewTime returns the time an IPsec certificate is renewed at
+func getIPsecCertRenewTime(certificate *x509.Certificate) time.Time {
+	lifetime := certificate.NotAfter.Sub(certificate.NotBefore)
+	return certificate.NotBefore.Add(time.Duration(float64(lifetime) * ipsecCertRenewFraction))
+}
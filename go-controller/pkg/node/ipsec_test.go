@@ -0,0 +1,177 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/cert"
+)
+
+var _ = Describe("IPsec certificate manager", func() {
+	const chassisID = "1a2b3c4d-5e6f-4a5b-8c9d-0e1f2a3b4c5d"
+
+	var (
+		fexec      *ovntest.FakeExec
+		fakeClient *fake.Clientset
+		certDir    string
+		notBefore  time.Time
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+
+		tmpDir, err := os.MkdirTemp("", "ipsec")
+		Expect(err).NotTo(HaveOccurred())
+		certDir = filepath.Join(tmpDir, "keys")
+		config.OvnKubeNode.IPsecCertDir = certDir
+		config.OvnKubeNode.IPsecCACert = filepath.Join(tmpDir, "ca-bundle.crt")
+		config.OvnKubeNode.IPsecSignerName = "example.com/ipsec-signer"
+
+		caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		caCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "ipsec-signer"}, caKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(config.OvnKubeNode.IPsecCACert,
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}), 0644)).To(Succeed())
+
+		// sign the certificate signing requests as the signer would
+		notBefore = time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+		fakeClient = fake.NewSimpleClientset()
+		fakeClient.PrependReactor("create", "certificatesigningrequests", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			csr := action.(k8stesting.CreateAction).GetObject().(*certificatesv1.CertificateSigningRequest)
+			Expect(csr.Spec.SignerName).To(Equal("example.com/ipsec-signer"))
+			Expect(csr.Spec.Usages).To(Equal([]certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel}))
+			block, _ := pem.Decode(csr.Spec.Request)
+			request, err := x509.ParseCertificateRequest(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: request.Subject.CommonName},
+				NotBefore:    notBefore,
+				NotAfter:     notBefore.Add(24 * time.Hour),
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, caCert, request.PublicKey, caKey)
+			Expect(err).NotTo(HaveOccurred())
+			csr.Name = csr.GenerateName + "x7k2p"
+			csr.Status.Certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+			return false, nil, nil
+		})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(certDir))
+	})
+
+	It("requests a certificate of the chassis and configures it in OVS", func() {
+		suffix := notBefore.Format("20060102150405")
+		certPath := filepath.Join(certDir, "ipsec-cert-"+suffix+".pem")
+		keyPath := filepath.Join(certDir, "ipsec-privkey-"+suffix+".pem")
+		caCertPath := filepath.Join(certDir, "ipsec-cacert.pem")
+		setCmd := "ovs-vsctl --timeout=15 set Open_vSwitch . other_config:certificate=" + certPath +
+			" other_config:private_key=" + keyPath + " other_config:ca_cert=" + caCertPath
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:system-id",
+			Output: chassisID,
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:certificate",
+			"ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:private_key",
+			setCmd,
+		})
+		// the certificate is reused until it is due for renewal
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:system-id",
+			Output: chassisID,
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:certificate",
+			Output: "\"" + certPath + "\"",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:private_key",
+			Output: "\"" + keyPath + "\"",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{setCmd})
+
+		m := newIPsecCertManager("node1", fakeClient, record.NewFakeRecorder(10))
+		notAfter, err := m.sync()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notAfter).To(Equal(notBefore.Add(24 * time.Hour)))
+		certificate, err := loadIPsecCertificate(certPath, keyPath, chassisID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certificate.Subject.CommonName).To(Equal(chassisID))
+		Expect(caCertPath).To(BeAnExistingFile())
+		// the certificate signing request is deleted once signed
+		csrs, err := fakeClient.CertificatesV1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(csrs.Items).To(BeEmpty())
+
+		notAfter, err = m.sync()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notAfter).To(Equal(notBefore.Add(24 * time.Hour)))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("removes the IPsec configuration and files of the node once IPsec is disabled", func() {
+		Expect(os.MkdirAll(certDir, 0700)).To(Succeed())
+		certPath := filepath.Join(certDir, "ipsec-cert-20260101000000.pem")
+		keepPath := filepath.Join(certDir, "other.pem")
+		for _, path := range []string{certPath, filepath.Join(certDir, "ipsec-privkey-20260101000000.pem"),
+			filepath.Join(certDir, "ipsec-cacert.pem"), keepPath} {
+			Expect(os.WriteFile(path, []byte("pem"), 0600)).To(Succeed())
+		}
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:certificate",
+			Output: "\"" + certPath + "\"",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 remove Open_vSwitch . other_config certificate private_key ca_cert",
+		})
+
+		Expect(cleanupIPsecCertificates()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		entries, err := os.ReadDir(certDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Name()).To(Equal(filepath.Base(keepPath)))
+	})
+
+	It("leaves the certificates configured outside of the IPsec certificate directory", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:certificate",
+			Output: "\"/etc/openvswitch/keys/ipsec-cert.pem\"",
+		})
+
+		Expect(cleanupIPsecCertificates()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("renews certificates after most of their lifetime", func() {
+		now := time.Now()
+		certificate := &x509.Certificate{NotBefore: now.Add(-20 * time.Hour), NotAfter: now.Add(4 * time.Hour)}
+		Expect(getIPsecCertRenewTime(certificate)).To(BeTemporally("~", now.Add(-1*time.Hour+12*time.Minute), time.Second))
+	})
+})
@@ -190,6 +190,12 @@ func setupManagementPortIPFamilyConfig(mpcfg *managementPortConfig, cfg *managem
 		return warnings, err
 	}
 
+	// when the traffic of the host is not masqueraded to the management port
+	// IP, make sure the host sends it from the management port IP
+	var routeSrc net.IP
+	if config.Gateway.MgmtPortOVNRouting {
+		routeSrc = cfg.ifAddr.IP
+	}
 	for _, subnet := range cfg.allSubnets {
 		if exists, err = util.LinkRouteExists(mpcfg.link, cfg.gwIP, subnet); err == nil && !exists {
 			// we need to warn so that it can be debugged as to why routes are disappearing
@@ -200,7 +206,7 @@ func setupManagementPortIPFamilyConfig(mpcfg *managementPortConfig, cfg *managem
 			return warnings, err
 		}
 
-		err = util.LinkRoutesApply(mpcfg.link, cfg.gwIP, []*net.IPNet{subnet}, config.Default.RoutableMTU, nil)
+		err = util.LinkRoutesApply(mpcfg.link, cfg.gwIP, []*net.IPNet{subnet}, config.Default.RoutableMTU, routeSrc)
 		if err != nil {
 			return warnings, err
 		}
@@ -240,6 +246,12 @@ func setupManagementPortIPFamilyConfig(mpcfg *managementPortConfig, cfg *managem
 		}
 	}
 
+	if config.Gateway.MgmtPortOVNRouting {
+		// the traffic of the host already leaves from the management port
+		// IP, remove the SNAT of a previous run
+		return warnings, deleteManagementPortSNAT(mpcfg, cfg)
+	}
+
 	if _, err = cfg.ipt.List("nat", iptableMgmPortChain); err != nil {
 		warnings = append(warnings, fmt.Sprintf("missing iptables chain %s in the nat table, adding it",
 			iptableMgmPortChain))
@@ -275,6 +287,31 @@ func setupManagementPortIPFamilyConfig(mpcfg *managementPortConfig, cfg *managem
 	return warnings, nil
 }
 
+// deleteManagementPortSNAT removes the iptables chain and rule masquerading the
+// traffic of the host leaving through the management port
+func deleteManagementPortSNAT(mpcfg *managementPortConfig, cfg *managementPortIPFamilyConfig) error {
+	rule := []string{"-o", mpcfg.ifName, "-j", iptableMgmPortChain}
+	exists, err := cfg.ipt.Exists("nat", "POSTROUTING", rule...)
+	if err == nil && exists {
+		klog.Infof("Removing iptables rule %q for management port", strings.Join(rule, " "))
+		err = cfg.ipt.Delete("nat", "POSTROUTING", rule...)
+	}
+	if err != nil {
+		return fmt.Errorf("could not delete iptables rule %q for management port: %v", strings.Join(rule, " "), err)
+	}
+	if _, err = cfg.ipt.List("nat", iptableMgmPortChain); err != nil {
+		// the chain does not exist
+		return nil
+	}
+	if err = cfg.ipt.ClearChain("nat", iptableMgmPortChain); err == nil {
+		err = cfg.ipt.DeleteChain("nat", iptableMgmPortChain)
+	}
+	if err != nil {
+		return fmt.Errorf("could not delete iptables nat chain %q for management port: %v", iptableMgmPortChain, err)
+	}
+	return nil
+}
+
 func setupManagementPortConfig(cfg *managementPortConfig) ([]string, error) {
 	var warnings, allWarnings []string
 	var err error
@@ -475,7 +512,8 @@ func DelMgtPortIptRules() {
 // checks to make sure that following configurations are present on the k8s node
 // 1. route entries to cluster CIDR and service CIDR through management port
 // 2. ARP entry for the node subnet's gateway ip
-// 3. IPtables chain and rule for SNATing packets entering the logical topology
+// 3. IPtables chain and rule for SNATing packets entering the logical topology, unless the
+// traffic of the pods to the host is routed back to the management port by OVN
 func checkManagementPortHealth(cfg *managementPortConfig) {
 	warnings, err := setupManagementPortConfig(cfg)
 	for _, warning := range warnings {
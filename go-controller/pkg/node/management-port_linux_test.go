@@ -116,6 +116,13 @@ func checkMgmtPortTestIptables(configs []managementPortTestConfig, mgmtPortName
 			"filter": {},
 			"mangle": {},
 		}
+		if config.Gateway.MgmtPortOVNRouting {
+			// the host traffic is not masqueraded and the chain of a
+			// previous run is removed
+			expectedTables["nat"] = util.FakeTable{
+				"POSTROUTING": []string{},
+			}
+		}
 		if cfg.protocol == iptables.ProtocolIPv4 {
 			err = fakeIpv4.MatchState(expectedTables)
 			Expect(err).NotTo(HaveOccurred())
@@ -157,6 +164,11 @@ func checkMgmtTestPortIpsAndRoutes(configs []managementPortTestConfig, mgmtPortN
 			Expect(err).NotTo(HaveOccurred())
 			for _, r := range routes {
 				if r.Gw.Equal(gatewayIP) && r.LinkIndex == mgmtPortLink.Attrs().Index {
+					// the host sends its traffic from the management port IP
+					// when it is not masqueraded
+					if config.Gateway.MgmtPortOVNRouting && !r.Src.Equal(mgtPortAddrs[i].IP) {
+						continue
+					}
 					foundRoute = true
 					break
 				}
@@ -770,6 +782,32 @@ var _ = Describe("Management Port Operations", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			ovntest.OnSupportedPlatformsIt("sets up the management port without SNAT for IPv4 clusters with management port OVN routing", func() {
+				app.Action = func(ctx *cli.Context) error {
+					testManagementPort(ctx, fexec, testNS,
+						[]managementPortTestConfig{
+							{
+								family:   netlink.FAMILY_V4,
+								protocol: iptables.ProtocolIPv4,
+
+								clusterCIDR: v4clusterCIDR,
+								nodeSubnet:  v4nodeSubnet,
+
+								expectedManagementPortIP: v4mgtPortIP,
+								expectedGatewayIP:        v4gwIP,
+							},
+						}, v4lrpMAC)
+					return nil
+				}
+				err := app.Run([]string{
+					app.Name,
+					"--cluster-subnets=" + v4clusterCIDR,
+					"--gateway-mode=shared",
+					"--mgmt-port-ovn-routing",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			ovntest.OnSupportedPlatformsIt("sets up the management port for IPv6 clusters", func() {
 				app.Action = func(ctx *cli.Context) error {
 					testManagementPort(ctx, fexec, testNS,
@@ -917,4 +955,45 @@ var _ = Describe("Management Port Operations", func() {
 			})
 		})
 	})
+
+	Describe("Removing the management port SNAT", func() {
+		var (
+			mpcfg *managementPortConfig
+			cfg   *managementPortIPFamilyConfig
+			iptV4 util.IPTablesHelper
+		)
+
+		BeforeEach(func() {
+			Expect(config.PrepareTestConfig()).To(Succeed())
+			iptV4, _ = util.SetFakeIPTablesHelpers()
+			mpcfg = &managementPortConfig{ifName: types.K8sMgmtIntfName}
+			cfg = &managementPortIPFamilyConfig{ipt: iptV4}
+			Expect(iptV4.NewChain("nat", "POSTROUTING")).To(Succeed())
+		})
+
+		It("removes the jump and the chain of a previous run", func() {
+			Expect(iptV4.NewChain("nat", iptableMgmPortChain)).To(Succeed())
+			Expect(iptV4.Append("nat", iptableMgmPortChain, "-o", types.K8sMgmtIntfName, "-j", "SNAT",
+				"--to-source", "10.1.1.2", "-m", "comment", "--comment", "OVN SNAT to Management Port")).To(Succeed())
+			Expect(iptV4.Append("nat", "POSTROUTING", "-o", types.K8sMgmtIntfName, "-j", iptableMgmPortChain)).To(Succeed())
+
+			Expect(deleteManagementPortSNAT(mpcfg, cfg)).To(Succeed())
+
+			Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+				"nat":    {"POSTROUTING": []string{}},
+				"filter": {},
+				"mangle": {},
+			})).To(Succeed())
+		})
+
+		It("does nothing when there is no SNAT", func() {
+			Expect(deleteManagementPortSNAT(mpcfg, cfg)).To(Succeed())
+
+			Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+				"nat":    {"POSTROUTING": []string{}},
+				"filter": {},
+				"mangle": {},
+			})).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,255 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// noOverlayRetryInterval is the interval a failed sync of the no-overlay
+// routes is retried after
+const noOverlayRetryInterval = 5 * time.Second
+
+// noOverlayRoute is the route of the host subnet of a remote node through the
+// node network
+type noOverlayRoute struct {
+	subnet  *net.IPNet
+	nexthop net.IP
+}
+
+// noOverlayRouteManager routes the traffic of the local pods to the pods of
+// the other nodes through the node network instead of the overlay. The cluster
+// router reroutes this traffic to the management port, and the host forwards
+// it out of the gateway bridge to the remote node, or to the next hop of the
+// gateway when the remote node is not on the same subnet.
+type noOverlayRouteManager struct {
+	nodeName     string
+	bridgeIface  string
+	watchFactory *factory.WatchFactory
+	// syncChan requests a sync of the routes
+	syncChan chan struct{}
+}
+
+func newNoOverlayRouteManager(nodeName, bridgeIface string, wf *factory.WatchFactory) *noOverlayRouteManager {
+	return &noOverlayRouteManager{
+		nodeName:     nodeName,
+		bridgeIface:  bridgeIface,
+		watchFactory: wf,
+		syncChan:     make(chan struct{}, 1),
+	}
+}
+
+// Run syncs the routes to the host subnets of the other nodes and the iptables
+// rules forwarding their traffic until stopChan is closed
+func (m *noOverlayRouteManager) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { m.requestSync() },
+		UpdateFunc: func(old, new interface{}) { m.requestSync() },
+		DeleteFunc: func(obj interface{}) { m.requestSync() },
+	}
+	if _, err := m.watchFactory.AddNodeHandler(handler, nil, m.watchFactory.GetHandlerPriority(factory.NodeType)); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-m.syncChan:
+				if err := m.sync(); err != nil {
+					klog.Errorf("Failed to sync the no-overlay routes of node %s, retrying: %v", m.nodeName, err)
+					time.AfterFunc(noOverlayRetryInterval, m.requestSync)
+				}
+			}
+		}
+	}()
+	m.requestSync()
+	return nil
+}
+
+func (m *noOverlayRouteManager) requestSync() {
+	select {
+	case m.syncChan <- struct{}{}:
+	default:
+	}
+}
+
+func (m *noOverlayRouteManager) sync() error {
+	if err := insertIptRules(getNoOverlayIptRules()); err != nil {
+		return fmt.Errorf("failed to add the no-overlay iptables rules: %w", err)
+	}
+
+	localNode, err := m.watchFactory.GetNode(m.nodeName)
+	if err != nil {
+		return err
+	}
+	nodes, err := m.watchFactory.GetNodes()
+	if err != nil {
+		return err
+	}
+	routes, err := getNoOverlayRoutes(localNode, nodes)
+	if err != nil {
+		return err
+	}
+
+	link, err := util.LinkSetUp(m.bridgeIface)
+	if err != nil {
+		return err
+	}
+	desired := map[string]bool{}
+	for _, route := range routes {
+		desired[route.subnet.String()] = true
+		if err := util.LinkRoutesApply(link, route.nexthop, []*net.IPNet{route.subnet}, 0, nil); err != nil {
+			return err
+		}
+	}
+
+	// remove the routes of the nodes that were deleted
+	existing, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list the routes of link %s: %w", m.bridgeIface, err)
+	}
+	for i := range existing {
+		route := existing[i]
+		if route.Dst == nil || desired[route.Dst.String()] || !isClusterSubnet(route.Dst) {
+			continue
+		}
+		klog.Infof("Deleting stale no-overlay route %s via %s", route.Dst, route.Gw)
+		if err := util.GetNetLinkOps().RouteDel(&route); err != nil {
+			return fmt.Errorf("failed to delete route %s of link %s: %w", route.Dst, m.bridgeIface, err)
+		}
+	}
+	return nil
+}
+
+// getNoOverlayRoutes returns the routes of the host subnets of the other
+// nodes, sorted by subnet. The next hop of a subnet is the gateway IP of its
+// node when the node is on the subnet of the gateway of the local node, and
+// the next hop of the gateway of the local node otherwise.
+func getNoOverlayRoutes(localNode *kapi.Node, nodes []*kapi.Node) ([]noOverlayRoute, error) {
+	localGatewayConfig, err := util.ParseNodeL3GatewayAnnotation(localNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gateway config of node %s: %w", localNode.Name, err)
+	}
+
+	var routes []noOverlayRoute
+	for _, node := range nodes {
+		if node.Name == localNode.Name {
+			continue
+		}
+		hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+		if err != nil {
+			// the host subnet is not allocated yet on new nodes
+			if util.IsAnnotationNotSetError(err) {
+				continue
+			}
+			return nil, err
+		}
+		var remoteIPs []*net.IPNet
+		if gatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node); err == nil {
+			remoteIPs = gatewayConfig.IPAddresses
+		}
+		for _, hostSubnet := range hostSubnets {
+			nexthop := getNoOverlayNexthop(localGatewayConfig, remoteIPs, utilnet.IsIPv6CIDR(hostSubnet))
+			if nexthop == nil {
+				klog.Warningf("No next hop to host subnet %s of node %s", hostSubnet, node.Name)
+				continue
+			}
+			routes = append(routes, noOverlayRoute{subnet: hostSubnet, nexthop: nexthop})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].subnet.String() < routes[j].subnet.String() })
+	return routes, nil
+}
+
+func getNoOverlayNexthop(localGatewayConfig *util.L3GatewayConfig, remoteIPs []*net.IPNet, isIPv6 bool) net.IP {
+	for _, remoteIP := range remoteIPs {
+		if utilnet.IsIPv6(remoteIP.IP) != isIPv6 {
+			continue
+		}
+		for _, localIP := range localGatewayConfig.IPAddresses {
+			if localIP.Contains(remoteIP.IP) {
+				return remoteIP.IP
+			}
+		}
+	}
+	for _, nexthop := range localGatewayConfig.NextHops {
+		if utilnet.IsIPv6(nexthop) == isIPv6 {
+			return nexthop
+		}
+	}
+	return nil
+}
+
+// getNoOverlayIptRules returns the iptables rules forwarding the traffic of the
+// cluster subnets through the host, without masquerading the traffic of the
+// remote pods to the management port, nor the traffic of the local pods to the
+// remote pods to the node IP in local gateway mode
+func getNoOverlayIptRules() []iptRule {
+	var rules []iptRule
+	for _, clusterSubnet := range config.Default.ClusterSubnets {
+		cidr := clusterSubnet.CIDR.String()
+		protocol := getIPTablesProtocol(clusterSubnet.CIDR.IP.String())
+		for _, dstSubnet := range config.Default.ClusterSubnets {
+			if utilnet.IsIPv6CIDR(dstSubnet.CIDR) != utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
+				continue
+			}
+			// inserted before the host subnet MASQUERADE rule of local
+			// gateway mode, which is appended
+			rules = append(rules, iptRule{
+				table:    "nat",
+				chain:    "POSTROUTING",
+				args:     []string{"-s", cidr, "-d", dstSubnet.CIDR.String(), "-j", "RETURN"},
+				protocol: protocol,
+			})
+		}
+		rules = append(rules,
+			iptRule{
+				table:    "filter",
+				chain:    "FORWARD",
+				args:     []string{"-s", cidr, "-j", "ACCEPT"},
+				protocol: protocol,
+			},
+			iptRule{
+				table:    "filter",
+				chain:    "FORWARD",
+				args:     []string{"-d", cidr, "-j", "ACCEPT"},
+				protocol: protocol,
+			},
+		)
+		if !config.Gateway.MgmtPortOVNRouting {
+			rules = append(rules, iptRule{
+				table:    "nat",
+				chain:    iptableMgmPortChain,
+				args:     []string{"-s", cidr, "-j", "RETURN"},
+				protocol: protocol,
+			})
+		}
+	}
+	return rules
+}
+
+func isClusterSubnet(subnet *net.IPNet) bool {
+	for _, clusterSubnet := range config.Default.ClusterSubnets {
+		if clusterSubnet.CIDR.Contains(subnet.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("No-overlay routes", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Default.NoOverlay = true
+	})
+
+	newNode := func(name, gatewayIP, nexthop, hostSubnet string) *v1.Node {
+		annotations := map[string]string{
+			"k8s.ovn.org/node-chassis-id": "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+			"k8s.ovn.org/l3-gateway-config": `{"default":{"mode":"shared","mac-address":"52:54:00:e2:ed:d0","ip-addresses":["` +
+				gatewayIP + `"],"next-hops":["` + nexthop + `"]}}`,
+		}
+		if hostSubnet != "" {
+			annotations["k8s.ovn.org/node-subnets"] = `{"default":["` + hostSubnet + `"]}`
+		}
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+
+	It("routes the host subnets of the other nodes to them or the gateway next hop", func() {
+		local := newNode("node1", "192.168.122.14/24", "192.168.122.1", "10.244.0.0/24")
+		nodes := []*v1.Node{
+			local,
+			newNode("node2", "192.168.122.15/24", "192.168.122.1", "10.244.1.0/24"),
+			newNode("node3", "192.168.123.20/24", "192.168.123.1", "10.244.2.0/24"),
+			// the host subnet of new nodes is not allocated yet
+			newNode("node4", "192.168.122.16/24", "192.168.122.1", ""),
+		}
+		routes, err := getNoOverlayRoutes(local, nodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(Equal([]noOverlayRoute{
+			{subnet: ovntest.MustParseIPNet("10.244.1.0/24"), nexthop: net.ParseIP("192.168.122.15")},
+			{subnet: ovntest.MustParseIPNet("10.244.2.0/24"), nexthop: net.ParseIP("192.168.122.1")},
+		}))
+	})
+	It("does not masquerade the traffic between the pods in local gateway mode", func() {
+		config.Gateway.Mode = config.GatewayModeLocal
+		config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.244.0.0/16"), HostSubnetLength: 24}}
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+
+		// the local gateway NAT rules are appended and the no-overlay ones
+		// inserted, whatever their order
+		Expect(initLocalGatewayNATRules(types.K8sMgmtIntfName, ovntest.MustParseIPNet("10.244.0.0/24"))).To(Succeed())
+		Expect(insertIptRules(getNoOverlayIptRules())).To(Succeed())
+
+		rules, err := iptV4.List("nat", "POSTROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			"-s 10.244.0.0/16 -d 10.244.0.0/16 -j RETURN",
+			"-s " + types.V4OVNMasqueradeIP + " -j MASQUERADE",
+			"-s 10.244.0.0/24 -j MASQUERADE",
+		}))
+	})
+})
@@ -6,9 +6,11 @@ package node
 import (
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -34,39 +36,56 @@ type addressManager struct {
 	nodePrimaryAddr net.IP
 	gatewayBridge   *bridgeConfiguration
 
+	// changeDelay is the time a change of the host addresses must last
+	// before the published host addresses and the encap IP follow it
+	changeDelay time.Duration
+	// removedAddresses holds the time the addresses gone from the host were
+	// removed, while they are still published during changeDelay
+	removedAddresses map[string]time.Time
+	// encapIPCandidate is the address the encap IP changes to once it has
+	// been selected since encapIPCandidateSince for changeDelay
+	encapIPCandidate      net.IP
+	encapIPCandidateSince time.Time
+
 	OnChanged func()
 	sync.Mutex
 }
 
 // initializes a new address manager which will hold all the IPs on a node
-func newAddressManager(nodeName string, k kube.Interface, config *managementPortConfig, watchFactory factory.NodeWatchFactory, gwBridge *bridgeConfiguration) *addressManager {
-	return newAddressManagerInternal(nodeName, k, config, watchFactory, gwBridge, true)
+func newAddressManager(nodeName string, k kube.Interface, mgmtPortConfig *managementPortConfig, watchFactory factory.NodeWatchFactory, gwBridge *bridgeConfiguration) *addressManager {
+	return newAddressManagerInternal(nodeName, k, mgmtPortConfig, watchFactory, gwBridge, true)
 }
 
 // newAddressManagerInternal creates a new address manager; this function is
 // only expose for testcases to disable netlink subscription to ensure
 // reproducibility of unit tests.
-func newAddressManagerInternal(nodeName string, k kube.Interface, config *managementPortConfig, watchFactory factory.NodeWatchFactory, gwBridge *bridgeConfiguration, useNetlink bool) *addressManager {
+func newAddressManagerInternal(nodeName string, k kube.Interface, mgmtPortConfig *managementPortConfig, watchFactory factory.NodeWatchFactory, gwBridge *bridgeConfiguration, useNetlink bool) *addressManager {
 	mgr := &addressManager{
-		nodeName:       nodeName,
-		watchFactory:   watchFactory,
-		addresses:      sets.New[string](),
-		mgmtPortConfig: config,
-		gatewayBridge:  gwBridge,
-		OnChanged:      func() {},
-		useNetlink:     useNetlink,
+		nodeName:         nodeName,
+		watchFactory:     watchFactory,
+		addresses:        sets.New[string](),
+		mgmtPortConfig:   mgmtPortConfig,
+		gatewayBridge:    gwBridge,
+		changeDelay:      time.Duration(config.OvnKubeNode.NodeIPChangeDelay) * time.Second,
+		removedAddresses: map[string]time.Time{},
+		OnChanged:        func() {},
+		useNetlink:       useNetlink,
 	}
 	mgr.nodeAnnotator = kube.NewNodeAnnotator(k, nodeName)
 	mgr.sync()
 	return mgr
 }
 
-// updates the address manager with a new IP
+// updates the address manager with a new IP of the interface of the name
 // returns true if there was an update
-func (c *addressManager) addAddr(ip net.IP) bool {
+func (c *addressManager) addAddr(ip net.IP, ifName string) bool {
 	c.Lock()
 	defer c.Unlock()
-	if !c.addresses.Has(ip.String()) && c.isValidNodeIP(ip) {
+	if _, removed := c.removedAddresses[ip.String()]; removed {
+		klog.Infof("IP: %s, is back on the node, keeping it in node IP manager", ip)
+		delete(c.removedAddresses, ip.String())
+	}
+	if !c.addresses.Has(ip.String()) && c.isValidNodeIP(ip) && matchesNodeIPPolicy(ip, ifName) {
 		klog.Infof("Adding IP: %s, to node IP manager", ip)
 		c.addresses.Insert(ip.String())
 		return true
@@ -75,12 +94,19 @@ func (c *addressManager) addAddr(ip net.IP) bool {
 	return false
 }
 
-// removes IP from address manager
+// removes IP from address manager, once it has been gone for the change delay
 // returns true if there was an update
 func (c *addressManager) delAddr(ip net.IP) bool {
 	c.Lock()
 	defer c.Unlock()
 	if c.addresses.Has(ip.String()) && c.isValidNodeIP(ip) {
+		if c.changeDelay > 0 {
+			if _, removed := c.removedAddresses[ip.String()]; !removed {
+				klog.Infof("IP: %s, removed from the node, removing it from node IP manager in %v", ip, c.changeDelay)
+				c.removedAddresses[ip.String()] = time.Now()
+			}
+			return false
+		}
 		klog.Infof("Removing IP: %s, from node IP manager", ip)
 		c.addresses.Delete(ip.String())
 		return true
@@ -89,6 +115,33 @@ func (c *addressManager) delAddr(ip net.IP) bool {
 	return false
 }
 
+// expireRemovedAddresses removes the IPs that have been gone from the node for
+// the change delay from the address manager
+// returns true if there was an update
+func (c *addressManager) expireRemovedAddresses(now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+	changed := false
+	for addr, removed := range c.removedAddresses {
+		if now.Sub(removed) < c.changeDelay {
+			continue
+		}
+		klog.Infof("Removing IP: %s, from node IP manager", addr)
+		c.addresses.Delete(addr)
+		delete(c.removedAddresses, addr)
+		changed = true
+	}
+	return changed
+}
+
+// hasPendingChanges returns true if removed IPs or a new encap IP wait for the
+// change delay
+func (c *addressManager) hasPendingChanges() bool {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.removedAddresses) > 0 || c.encapIPCandidate != nil
+}
+
 // ListAddresses returns all the addresses we know about
 func (c *addressManager) ListAddresses() []net.IP {
 	c.Lock()
@@ -151,6 +204,15 @@ func (c *addressManager) runInternal(stopChan <-chan struct{}, doneWg *sync.Wait
 		addressSyncTimer := time.NewTicker(30 * time.Second)
 		defer addressSyncTimer.Stop()
 
+		// with a change delay, check periodically whether the pending
+		// changes of the host addresses lasted long enough to be followed
+		var changeDelayTicker <-chan time.Time
+		if c.changeDelay > 0 {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			changeDelayTicker = ticker.C
+		}
+
 		subscribed, addrChan, err := subscribe()
 		if err != nil {
 			klog.Error("Error during netlink subscribe for IP Manager: %v", err)
@@ -168,7 +230,7 @@ func (c *addressManager) runInternal(stopChan <-chan struct{}, doneWg *sync.Wait
 				}
 				addrChanged := false
 				if a.NewAddr {
-					addrChanged = c.addAddr(a.LinkAddress.IP)
+					addrChanged = c.addAddr(a.LinkAddress.IP, getLinkName(a.LinkIndex))
 				} else {
 					addrChanged = c.delAddr(a.LinkAddress.IP)
 				}
@@ -182,6 +244,20 @@ func (c *addressManager) runInternal(stopChan <-chan struct{}, doneWg *sync.Wait
 					}
 					c.OnChanged()
 				}
+			case <-changeDelayTicker:
+				if !c.hasPendingChanges() {
+					continue
+				}
+				addrChanged := c.expireRemovedAddresses(time.Now())
+				c.handleNodePrimaryAddrChange()
+				if addrChanged {
+					klog.Infof("Host addresses changed to %v. Updating node address annotation.", c.addresses)
+					err := c.updateNodeAddressAnnotations()
+					if err != nil {
+						klog.Errorf("Address Manager failed to update node address annotations: %v", err)
+					}
+					c.OnChanged()
+				}
 			case <-addressSyncTimer.C:
 				if subscribed {
 					klog.V(5).Info("Node IP manager calling sync() explicitly")
@@ -268,6 +344,21 @@ func (c *addressManager) assignAddresses(nodeHostAddresses sets.Set[string]) boo
 	c.Lock()
 	defer c.Unlock()
 
+	if c.changeDelay > 0 {
+		// keep the addresses gone from the host for the change delay
+		now := time.Now()
+		for addr := range c.addresses {
+			if nodeHostAddresses.Has(addr) {
+				delete(c.removedAddresses, addr)
+				continue
+			}
+			if _, removed := c.removedAddresses[addr]; !removed {
+				klog.Infof("IP: %s, removed from the node, removing it from node IP manager in %v", addr, c.changeDelay)
+				c.removedAddresses[addr] = now
+			}
+			nodeHostAddresses.Insert(addr)
+		}
+	}
 	if nodeHostAddresses.Equal(c.addresses) {
 		return false
 	}
@@ -297,6 +388,9 @@ func (c *addressManager) doesNodeHostAddressesMatch() bool {
 
 // nodePrimaryAddrChanged returns false if there is an error or if the IP does
 // match, otherwise it returns true and updates the current primary IP address.
+// The IP is the one selected by selectNodeIP among the addresses of the node,
+// and it replaces the current one once it has been selected for the change
+// delay.
 func (c *addressManager) nodePrimaryAddrChanged() (bool, error) {
 	node, err := c.watchFactory.GetNode(c.nodeName)
 	if err != nil {
@@ -314,13 +408,33 @@ func (c *addressManager) nodePrimaryAddrChanged() (bool, error) {
 		return false, fmt.Errorf("failed to parse the primary IP address string from kubernetes node status")
 	}
 	c.Lock()
-	exists := c.addresses.Has(nodePrimaryAddrStr)
-	c.Unlock()
+	defer c.Unlock()
+	addresses := make([]net.IP, 0, len(c.addresses))
+	for _, addr := range sets.List(c.addresses) {
+		if _, removed := c.removedAddresses[addr]; !removed {
+			addresses = append(addresses, net.ParseIP(addr))
+		}
+	}
+	encapIP := selectNodeIP(nodePrimaryAddr, addresses)
 
-	if !exists || c.nodePrimaryAddr.Equal(nodePrimaryAddr) {
+	if encapIP == nil || c.nodePrimaryAddr.Equal(encapIP) {
+		c.encapIPCandidate = nil
 		return false, nil
 	}
-	c.nodePrimaryAddr = nodePrimaryAddr
+	if c.nodePrimaryAddr != nil && c.changeDelay > 0 {
+		now := time.Now()
+		if !c.encapIPCandidate.Equal(encapIP) {
+			klog.Infof("Node encap IP candidate changed to %s, changing OVN encap IP in %v", encapIP, c.changeDelay)
+			c.encapIPCandidate = encapIP
+			c.encapIPCandidateSince = now
+			return false, nil
+		}
+		if now.Sub(c.encapIPCandidateSince) < c.changeDelay {
+			return false, nil
+		}
+	}
+	c.nodePrimaryAddr = encapIP
+	c.encapIPCandidate = nil
 
 	return true, nil
 }
@@ -381,10 +495,10 @@ func (c *addressManager) isValidNodeIP(addr net.IP) bool {
 
 func (c *addressManager) sync() {
 	var err error
-	var addrs []net.Addr
+	var addrs []hostAddress
 
 	if c.useNetlink {
-		addrs, err = net.InterfaceAddrs()
+		addrs, err = getHostAddresses()
 		if err != nil {
 			klog.Errorf("Failed to sync Node IP Manager: unable list all IPs on the node, error: %v", err)
 			return
@@ -393,16 +507,15 @@ func (c *addressManager) sync() {
 
 	currAddresses := sets.New[string]()
 	for _, addr := range addrs {
-		ip, _, err := net.ParseCIDR(addr.String())
-		if err != nil {
-			klog.Errorf("Invalid IP address found on host: %s", addr.String())
+		if !c.isValidNodeIP(addr.ip) {
+			klog.V(5).Infof("Skipping non-useable IP address for host: %s", addr.ip.String())
 			continue
 		}
-		if !c.isValidNodeIP(ip) {
-			klog.V(5).Infof("Skipping non-useable IP address for host: %s", ip.String())
+		if !matchesNodeIPPolicy(addr.ip, addr.ifName) {
+			klog.V(5).Infof("Skipping IP address for host not matching the node IP policy: %s", addr.ip.String())
 			continue
 		}
-		currAddresses.Insert(ip.String())
+		currAddresses.Insert(addr.ip.String())
 	}
 
 	addrChanged := c.assignAddresses(currAddresses)
@@ -415,3 +528,119 @@ func (c *addressManager) sync() {
 		}
 	}
 }
+
+// hostAddress is an IP address of the host with the name of its interface
+type hostAddress struct {
+	ip     net.IP
+	ifName string
+}
+
+// getHostAddresses returns the IP addresses of all the interfaces of the host
+func getHostAddresses() ([]hostAddress, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var hostAddrs []hostAddress
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the IPs of interface %s: %v", iface.Name, err)
+		}
+		for _, addr := range addrs {
+			ip, _, err := net.ParseCIDR(addr.String())
+			if err != nil {
+				klog.Errorf("Invalid IP address found on host: %s", addr.String())
+				continue
+			}
+			hostAddrs = append(hostAddrs, hostAddress{ip: ip, ifName: iface.Name})
+		}
+	}
+	return hostAddrs, nil
+}
+
+// getLinkName returns the name of the link of the index, or an empty name if
+// the node IP policy does not need it or the link is gone
+func getLinkName(index int) string {
+	if config.OvnKubeNode.NodeIPInterface == "" {
+		return ""
+	}
+	link, err := util.GetNetLinkOps().LinkByIndex(index)
+	if err != nil {
+		klog.Warningf("Failed to get the link of index %d: %v", index, err)
+		return ""
+	}
+	return link.Attrs().Name
+}
+
+// nodeIPPolicyConfigured returns true if the addresses of the node used as
+// encap IP and published as host addresses are restricted to some CIDRs or to
+// an interface
+func nodeIPPolicyConfigured() bool {
+	return len(config.OvnKubeNode.NodeIPCIDRs) > 0 || config.OvnKubeNode.NodeIPInterface != ""
+}
+
+// matchesNodeIPPolicy returns true if the address, on the interface of the
+// name, is in one of the configured node IP CIDRs and on the configured node
+// IP interface, when they are configured
+func matchesNodeIPPolicy(addr net.IP, ifName string) bool {
+	if config.OvnKubeNode.NodeIPInterface != "" && ifName != config.OvnKubeNode.NodeIPInterface {
+		return false
+	}
+	if len(config.OvnKubeNode.NodeIPCIDRs) == 0 {
+		return true
+	}
+	for _, cidr := range config.OvnKubeNode.NodeIPCIDRs {
+		if cidr.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectNodeIP returns the address of the node used as encap IP among its
+// sorted addresses: the primary IP of the node if it is one of them, else when
+// a node IP policy is configured the first one of the IP family of the primary
+// IP, or nil
+func selectNodeIP(primary net.IP, addresses []net.IP) net.IP {
+	for _, addr := range addresses {
+		if addr.Equal(primary) {
+			return primary
+		}
+	}
+	if !nodeIPPolicyConfigured() {
+		return nil
+	}
+	for _, addr := range addresses {
+		if utilnet.IsIPv6(addr) == utilnet.IsIPv6(primary) {
+			return addr
+		}
+	}
+	return nil
+}
+
+// getNodeIPPolicyEncapIP returns the encap IP of the node selected among the
+// addresses of the host matching the node IP policy
+func getNodeIPPolicyEncapIP(primary net.IP) (net.IP, error) {
+	hostAddrs, err := getHostAddresses()
+	if err != nil {
+		return nil, err
+	}
+	var addresses []net.IP
+	for _, addr := range hostAddrs {
+		if addr.ip.IsLoopback() || addr.ip.IsLinkLocalUnicast() || util.IsAddressReservedForInternalUse(addr.ip) {
+			continue
+		}
+		if matchesNodeIPPolicy(addr.ip, addr.ifName) {
+			addresses = append(addresses, addr.ip)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].String() < addresses[j].String()
+	})
+	encapIP := selectNodeIP(primary, addresses)
+	if encapIP == nil {
+		return nil, fmt.Errorf("no address of the host matches the node IP policy")
+	}
+	return encapIP, nil
+}
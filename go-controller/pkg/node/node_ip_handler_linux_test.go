@@ -5,15 +5,18 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 
 	"github.com/vishvananda/netlink"
 
@@ -24,9 +27,14 @@ import (
 )
 
 func ipEvent(ipStr string, isAdd bool, addrChan chan netlink.AddrUpdate) *net.IPNet {
+	return ipEventOnLink(ipStr, 0, isAdd, addrChan)
+}
+
+func ipEventOnLink(ipStr string, linkIndex int, isAdd bool, addrChan chan netlink.AddrUpdate) *net.IPNet {
 	ipNet := ovntest.MustParseIPNet(ipStr)
 	addrChan <- netlink.AddrUpdate{
 		LinkAddress: *ipNet,
+		LinkIndex:   linkIndex,
 		NewAddr:     isAdd,
 	}
 	return ipNet
@@ -65,6 +73,12 @@ var _ = Describe("Node IP Handler tests", func() {
 	)
 
 	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	// the address manager is created once the node IP policy of the specs is
+	// configured
+	JustBeforeEach(func() {
 		node := &v1.Node{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: nodeName,
@@ -193,4 +207,98 @@ var _ = Describe("Node IP Handler tests", func() {
 			}, 5).Should(BeFalse())
 		})
 	})
+
+	Describe("Node IP policy", func() {
+		Context("with node IP CIDRs", func() {
+			BeforeEach(func() {
+				config.OvnKubeNode.NodeIPCIDRs = ovntest.MustParseIPNets("10.1.1.0/24", "2001:db8::/64")
+			})
+
+			It("should only publish the addresses in the CIDRs", func() {
+				ipNet := ipEvent(nodeAddr4, true, tc.addrChan)
+				Eventually(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 5).Should(BeTrue())
+
+				ipNet = ipEvent("192.168.50.10/24", true, tc.addrChan)
+				Consistently(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 3).Should(BeFalse())
+			})
+		})
+
+		Context("with a node IP interface", func() {
+			BeforeEach(func() {
+				config.OvnKubeNode.NodeIPInterface = "eth1"
+				netlinkMock := &mocks.NetLinkOps{}
+				netlinkMock.On("LinkByIndex", 2).Return(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}}, nil)
+				netlinkMock.On("LinkByIndex", 3).Return(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0"}}, nil)
+				util.SetNetLinkOpMockInst(netlinkMock)
+			})
+
+			AfterEach(func() {
+				util.ResetNetLinkOpMockInst()
+			})
+
+			It("should only publish the addresses of the interface", func() {
+				ipNet := ipEventOnLink(nodeAddr4, 2, true, tc.addrChan)
+				Eventually(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 5).Should(BeTrue())
+
+				ipNet = ipEventOnLink("192.168.50.10/24", 3, true, tc.addrChan)
+				Consistently(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 3).Should(BeFalse())
+			})
+		})
+
+		Context("with a node IP change delay", func() {
+			BeforeEach(func() {
+				config.OvnKubeNode.NodeIPChangeDelay = 2
+			})
+
+			It("should keep publishing a deleted address for the delay", func() {
+				ipNet := ipEvent(nodeAddr4, true, tc.addrChan)
+				Eventually(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 5).Should(BeTrue())
+
+				deleted := time.Now()
+				ipEvent(nodeAddr4, false, tc.addrChan)
+				Eventually(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 5).Should(BeFalse())
+				Expect(time.Since(deleted)).To(BeNumerically(">=", 2*time.Second))
+			})
+
+			It("should keep publishing an address added back within the delay", func() {
+				ipNet := ipEvent(nodeAddr4, true, tc.addrChan)
+				Eventually(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 5).Should(BeTrue())
+
+				ipEvent(nodeAddr4, false, tc.addrChan)
+				ipEvent(nodeAddr4, true, tc.addrChan)
+				Consistently(func() bool {
+					return nodeHasAddress(tc.fakeClient, nodeName, ipNet)
+				}, 4).Should(BeTrue())
+			})
+		})
+
+		It("should select the primary IP of the node, or the first address of its IP family with a policy", func() {
+			addresses := []net.IP{
+				ovntest.MustParseIP("10.1.1.10"),
+				ovntest.MustParseIP("10.1.2.10"),
+				ovntest.MustParseIP("2001:db8::10"),
+			}
+			Expect(selectNodeIP(ovntest.MustParseIP("10.1.2.10"), addresses)).To(Equal(ovntest.MustParseIP("10.1.2.10")))
+			Expect(selectNodeIP(ovntest.MustParseIP("192.168.50.10"), addresses)).To(BeNil())
+
+			config.OvnKubeNode.NodeIPCIDRs = ovntest.MustParseIPNets("10.1.0.0/16", "2001:db8::/64")
+			Expect(selectNodeIP(ovntest.MustParseIP("192.168.50.10"), addresses)).To(Equal(ovntest.MustParseIP("10.1.1.10")))
+			Expect(selectNodeIP(ovntest.MustParseIP("fd00::10"), addresses)).To(Equal(ovntest.MustParseIP("2001:db8::10")))
+			Expect(selectNodeIP(ovntest.MustParseIP("192.168.50.10"), addresses[2:])).To(BeNil())
+		})
+	})
 })
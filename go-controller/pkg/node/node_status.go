@@ -0,0 +1,42 @@
+package node
+
+import (
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// updateNodeCondition sets the condition of the node in its status
+func updateNodeCondition(kube kube.Interface, nodeName string, condition kapi.NodeCondition) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		oldNode, err := kube.GetNode(nodeName)
+		if err != nil {
+			return err
+		}
+		node := oldNode.DeepCopy()
+		setNodeCondition(node, condition)
+		return kube.UpdateNodeStatus(node)
+	})
+}
+
+// setNodeCondition sets the condition of the node, updating its transition
+// time when its status changes
+func setNodeCondition(node *kapi.Node, condition kapi.NodeCondition) {
+	now := metav1.Now()
+	condition.LastHeartbeatTime = now
+	condition.LastTransitionTime = now
+	for i := range node.Status.Conditions {
+		existing := &node.Status.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return
+	}
+	node.Status.Conditions = append(node.Status.Conditions, condition)
+}
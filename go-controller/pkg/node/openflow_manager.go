@@ -19,6 +19,10 @@ type openflowManager struct {
 	flowMutex     sync.Mutex
 	exGWFlowCache map[string][]string
 	exGWFlowMutex sync.Mutex
+	// gatewayBridges are the additional named gateway bridges, with their
+	// static flows in gatewayBridgeFlows protected by flowMutex
+	gatewayBridges     map[string]*bridgeConfiguration
+	gatewayBridgeFlows map[string][]string
 	// channel to indicate we need to update flows immediately
 	flowChan chan struct{}
 }
@@ -82,6 +86,14 @@ func (c *openflowManager) syncFlows() {
 			klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.exGWFlowCache)
 		}
 	}
+
+	for name, bridge := range c.gatewayBridges {
+		_, stderr, err := util.ReplaceOFFlows(bridge.bridgeName, c.gatewayBridgeFlows[name])
+		if err != nil {
+			klog.Errorf("Failed to add flows of gateway bridge %s, error: %v, stderr, %s, flows: %s",
+				name, err, stderr, c.gatewayBridgeFlows[name])
+		}
+	}
 }
 
 // checkDefaultOpenFlow checks for the existence of default OpenFlow rules and
@@ -109,6 +121,10 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 						continue
 					}
 				}
+				if err := c.checkGatewayBridgesPorts(); err != nil {
+					klog.Errorf("Checkports failed %v", err)
+					continue
+				}
 				c.syncFlows()
 			case <-c.flowChan:
 				c.syncFlows()
@@ -120,6 +136,15 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 	}()
 }
 
+func (c *openflowManager) checkGatewayBridgesPorts() error {
+	for _, bridge := range c.gatewayBridges {
+		if err := checkPorts(bridge.patchPort, bridge.ofPortPatch, bridge.uplinkName, bridge.ofPortPhys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func checkPorts(patchIntf, ofPortPatch, physIntf, ofPortPhys string) error {
 	// it could be that the ovn-controller recreated the patch between the host OVS bridge and
 	// the integration bridge, as a result the ofport number changed for that patch interface
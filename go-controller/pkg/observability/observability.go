@@ -0,0 +1,116 @@
+// Package observability maps the ACLs built by ovnkube-master back to the
+// NetworkPolicy, namespace and EgressFirewall objects they implement, so that
+// observed traffic can be attributed to them.
+package observability
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+
+	"k8s.io/klog/v2"
+)
+
+// ObservationPoint is the Kubernetes object an ACL implements
+type ObservationPoint struct {
+	// OwnerType is the owner type of the ACL: NetworkPolicy, NetpolNamespace
+	// for the default deny of a namespace, or EgressFirewall
+	OwnerType string `json:"ownerType,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Policy is the name of the NetworkPolicy of the ACL
+	Policy string `json:"policy,omitempty"`
+	// PolicyDirection is Ingress or Egress for NetworkPolicy ACLs
+	PolicyDirection string `json:"policyDirection,omitempty"`
+	// Rule is the index of the NetworkPolicy or EgressFirewall rule
+	Rule string `json:"rule,omitempty"`
+}
+
+// ParseACLName returns the observation point of an ACL name built by
+// getACLName in pkg/ovn/acl.go:
+// - NP:<namespace>:<policy>:<direction>:<gress index> for NetworkPolicy rules
+// - NP:<namespace>:<direction> for the default deny of namespaces
+// - EF:<namespace>:<rule index> for EgressFirewall rules
+// Names are cropped to 63 characters, the fields cropped out are left empty.
+func ParseACLName(name string) ObservationPoint {
+	var point ObservationPoint
+	fields := strings.Split(name, ":")
+	switch {
+	case fields[0] == "NP" && len(fields) >= 4:
+		point.OwnerType = string(libovsdbops.NetworkPolicyOwnerType)
+		point.Namespace, point.Policy, point.PolicyDirection = fields[1], fields[2], fields[3]
+		if len(fields) > 4 {
+			point.Rule = fields[4]
+		}
+	case fields[0] == "NP" && len(fields) == 3:
+		point.OwnerType = string(libovsdbops.NetpolNamespaceOwnerType)
+		point.Namespace, point.PolicyDirection = fields[1], fields[2]
+	case fields[0] == "EF" && len(fields) >= 2:
+		point.OwnerType = string(libovsdbops.EgressFirewallOwnerType)
+		point.Namespace = fields[1]
+		if len(fields) > 2 {
+			point.Rule = fields[2]
+		}
+	}
+	return point
+}
+
+// observationPointIDs holds the ACL name each observation point ID was given to
+var observationPointIDs = struct {
+	sync.Mutex
+	names map[int]string
+}{names: map[int]string{}}
+
+// ObservationPointID returns the ID of the observation point of the ACL with
+// the given uncropped name, set as the ACL label. OVN writes the label of
+// allow-related ACLs to the ct_label of the connections they match.
+// IDs are hashes of the names: an ID is kept by the first name it is given
+// to, and 0, no label, is returned for the other names with the same hash so
+// that their traffic is never attributed to the wrong object.
+func ObservationPointID(aclName string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(aclName))
+	id := int(h.Sum32())
+	if id == 0 {
+		// 0 is no label
+		id = 1
+	}
+	observationPointIDs.Lock()
+	defer observationPointIDs.Unlock()
+	if name, ok := observationPointIDs.names[id]; ok && name != aclName {
+		klog.Warningf("ACL %s has the same observation point ID %d as ACL %s, it will not be observed", aclName, id, name)
+		return 0
+	}
+	observationPointIDs.names[id] = aclName
+	return id
+}
+
+// ACLObservationPoint returns the observation point of an ACL from its
+// external IDs, built by BuildACL in pkg/ovn/acl.go from its DbObjectIDs
+func ACLObservationPoint(externalIDs map[string]string) ObservationPoint {
+	var point ObservationPoint
+	ownerType := externalIDs[libovsdbops.OwnerTypeKey.String()]
+	name := externalIDs[libovsdbops.ObjectNameKey.String()]
+	switch ownerType {
+	case string(libovsdbops.NetworkPolicyOwnerType):
+		// the name is <namespace>:<policy>
+		namespace, policy, found := strings.Cut(name, ":")
+		if !found {
+			return point
+		}
+		point.Namespace, point.Policy = namespace, policy
+		point.PolicyDirection = externalIDs[libovsdbops.PolicyDirectionKey.String()]
+		point.Rule = externalIDs[libovsdbops.GressIdxKey.String()]
+	case string(libovsdbops.NetpolNamespaceOwnerType):
+		point.Namespace = name
+		point.PolicyDirection = externalIDs[libovsdbops.PolicyDirectionKey.String()]
+	case string(libovsdbops.EgressFirewallOwnerType):
+		point.Namespace = name
+		point.Rule = externalIDs[libovsdbops.RuleIndex.String()]
+	default:
+		return point
+	}
+	point.OwnerType = ownerType
+	return point
+}
@@ -0,0 +1,108 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseACLName(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		want ObservationPoint
+	}{
+		{
+			desc: "network policy rule",
+			name: "NP:ns1:deny-web:Egress:2",
+			want: ObservationPoint{OwnerType: "NetworkPolicy", Namespace: "ns1", Policy: "deny-web", PolicyDirection: "Egress", Rule: "2"},
+		},
+		{
+			desc: "cropped network policy rule",
+			name: "NP:ns1:deny-web:Egress",
+			want: ObservationPoint{OwnerType: "NetworkPolicy", Namespace: "ns1", Policy: "deny-web", PolicyDirection: "Egress"},
+		},
+		{
+			desc: "namespace default deny",
+			name: "NP:ns1:Ingress",
+			want: ObservationPoint{OwnerType: "NetpolNamespace", Namespace: "ns1", PolicyDirection: "Ingress"},
+		},
+		{
+			desc: "egress firewall rule",
+			name: "EF:ns1:5",
+			want: ObservationPoint{OwnerType: "EgressFirewall", Namespace: "ns1", Rule: "5"},
+		},
+		{
+			desc: "unnamed ACL",
+			name: "",
+			want: ObservationPoint{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseACLName(tc.name))
+		})
+	}
+}
+
+func TestObservationPointID(t *testing.T) {
+	id := ObservationPointID("NP:ns122789:Ingress")
+	assert.NotZero(t, id)
+	assert.Equal(t, id, ObservationPointID("NP:ns122789:Ingress"))
+	// the names have the same FNV-32a hash, the second one is not given the ID
+	assert.Zero(t, ObservationPointID("NP:ns339192:Ingress"))
+	assert.Equal(t, id, ObservationPointID("NP:ns122789:Ingress"))
+	assert.NotZero(t, ObservationPointID(""))
+}
+
+func TestACLObservationPoint(t *testing.T) {
+	tests := []struct {
+		desc        string
+		externalIDs map[string]string
+		want        ObservationPoint
+	}{
+		{
+			desc: "network policy rule",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "NetworkPolicy",
+				"k8s.ovn.org/name":       "ns1:deny-web",
+				"direction":              "Egress",
+				"gress-index":            "2",
+				"port-policy-index":      "0",
+			},
+			want: ObservationPoint{OwnerType: "NetworkPolicy", Namespace: "ns1", Policy: "deny-web", PolicyDirection: "Egress", Rule: "2"},
+		},
+		{
+			desc: "namespace default deny",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "NetpolNamespace",
+				"k8s.ovn.org/name":       "ns1",
+				"direction":              "Ingress",
+				"type":                   "defaultDeny",
+			},
+			want: ObservationPoint{OwnerType: "NetpolNamespace", Namespace: "ns1", PolicyDirection: "Ingress"},
+		},
+		{
+			desc: "egress firewall rule",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "EgressFirewall",
+				"k8s.ovn.org/name":       "ns1",
+				"rule-index":             "5",
+			},
+			want: ObservationPoint{OwnerType: "EgressFirewall", Namespace: "ns1", Rule: "5"},
+		},
+		{
+			desc: "other ACL",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "NetpolNode",
+				"k8s.ovn.org/name":       "node1",
+			},
+			want: ObservationPoint{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.want, ACLObservationPoint(tc.externalIDs))
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/observability"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -69,6 +71,12 @@ func hashedPortGroup(s string) string {
 // Therefore, "feature" as "EF" for EgressFirewall and "NP" for network policy goes first, then namespace,
 // then acl-related info.
 func getACLName(dbIDs *libovsdbops.DbObjectIDs) string {
+	return fmt.Sprintf("%.63s", getACLFullName(dbIDs))
+}
+
+// getACLFullName returns the uncropped acl.Name, the observation point ID set as
+// acl.Label with ACL sampling is computed from it.
+func getACLFullName(dbIDs *libovsdbops.DbObjectIDs) string {
 	t := dbIDs.GetIDsType()
 	aclName := ""
 	switch {
@@ -80,12 +88,12 @@ func getACLName(dbIDs *libovsdbops.DbObjectIDs) string {
 	case t.IsSameType(libovsdbops.ACLEgressFirewall):
 		aclName = "EF:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.RuleIndex)
 	}
-	return fmt.Sprintf("%.63s", aclName)
+	return aclName
 }
 
 // BuildACL should be used to build ACL instead of directly calling libovsdbops.BuildACL.
 // It can properly set and reset log settings for ACL based on ACLLoggingLevels, and
-// set acl.Name and acl.ExternalIDs based on given DbIDs
+// set acl.Name, acl.ExternalIDs and, with ACL sampling enabled, acl.Label based on given DbIDs
 func BuildACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string, logLevels *ACLLoggingLevels,
 	aclT aclPipelineType) *nbdb.ACL {
 	var options map[string]string
@@ -116,6 +124,9 @@ func BuildACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string
 		externalIDs,
 		options,
 	)
+	if config.Monitoring.EnableACLSampling && aclName != "" {
+		ACL.Label = observability.ObservationPointID(getACLFullName(dbIDs))
+	}
 	return ACL
 }
 
@@ -18,6 +18,8 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	esmirror "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/endpointslice_mirror"
+	svccontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/services"
 	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	ovnretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -26,6 +28,7 @@ import (
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -114,6 +117,22 @@ type BaseNetworkController struct {
 // configuration for secondary network controller
 type BaseSecondaryNetworkController struct {
 	BaseNetworkController
+
+	// svcController programs the load balancers of the services exposed on this network
+	svcController *svccontroller.Controller
+	// esMirrorController mirrors the EndpointSlices of the services exposed on this network
+	esMirrorController *esmirror.Controller
+	// svcFactory used to handle service related events of this network
+	svcFactory informers.SharedInformerFactory
+
+	// networkStatuses holds the last network status reported on each NAD of this network.
+	// The entry of a NAD is dropped when the NAD is added to or deleted from the network, as
+	// its annotation may have been overwritten with an error in between.
+	networkStatuses map[string]string
+	// networkStatusesEpoch is incremented when a NAD is added to or deleted from the network,
+	// so that a status reported concurrently is not cached
+	networkStatusesEpoch uint64
+	networkStatusesLock  sync.Mutex
 }
 
 // NewCommonNetworkControllerInfo creates CommonNetworkControllerInfo shared by controllers
@@ -721,6 +721,14 @@ func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *kapi.Pod, nadName
 		lsp.ExternalIDs[ovntypes.NADExternalID] = nadName
 		lsp.ExternalIDs[ovntypes.TopologyExternalID] = bnc.TopologyType()
 	}
+	// record when the port is created, so that nodes can measure the time it takes pod networks to be ready
+	if config.Metrics.EnablePodNetworkReady {
+		if !lspExist {
+			lsp.ExternalIDs[ovntypes.LSPCreatedExternalID] = time.Now().UTC().Format(time.RFC3339Nano)
+		} else if created, ok := existingLSP.ExternalIDs[ovntypes.LSPCreatedExternalID]; ok {
+			lsp.ExternalIDs[ovntypes.LSPCreatedExternalID] = created
+		}
+	}
 
 	// CNI depends on the flows from port security, delay setting it until end
 	lsp.PortSecurity = addresses
@@ -6,9 +6,16 @@ import (
 	"time"
 
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	esmirror "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/endpointslice_mirror"
+	svccontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/services"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	kapi "k8s.io/api/core/v1"
@@ -282,3 +289,71 @@ func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []i
 	}
 	return bsnc.deleteStaleLogicalSwitchPorts(expectedLogicalPorts)
 }
+
+// startServiceControllers starts the controllers load balancing the services exposed on this
+// secondary network through the service-network annotation: the EndpointSlice mirror
+// controller, which mirrors the endpoints with their secondary network IPs, and a services
+// controller attaching the resulting load balancers to the switches of the network.
+func (bsnc *BaseSecondaryNetworkController) startServiceControllers() error {
+	if !config.OVNKubernetesFeature.EnableMultiNetworkServices {
+		return nil
+	}
+	if bsnc.svcController != nil {
+		return nil
+	}
+
+	svcFactory := newServiceInformerFactory(bsnc.client, true)
+	esMirrorController, err := esmirror.NewController(
+		bsnc.client,
+		bsnc.NetInfo,
+		svcFactory.Core().V1().Services(),
+		svcFactory.Discovery().V1().EndpointSlices(),
+		bsnc.watchFactory.PodCoreInformer(),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create endpointslice mirror controller for network %s: %w", bsnc.GetNetworkName(), err)
+	}
+	svcController, err := svccontroller.NewSecondaryNetworkController(
+		bsnc.client,
+		bsnc.nbClient,
+		bsnc.NetInfo,
+		bsnc.TopologyType(),
+		svcFactory.Core().V1().Services(),
+		svcFactory.Discovery().V1().EndpointSlices(),
+		svcFactory.Core().V1().Nodes(),
+		bsnc.recorder,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create service controller for network %s: %w", bsnc.GetNetworkName(), err)
+	}
+	bsnc.svcFactory = svcFactory
+	bsnc.esMirrorController = esMirrorController
+	bsnc.svcController = svcController
+
+	klog.Infof("Starting services controllers for network %s", bsnc.GetNetworkName())
+	svcFactory.Start(bsnc.stopChan)
+
+	bsnc.wg.Add(2)
+	go func() {
+		defer bsnc.wg.Done()
+		if err := esMirrorController.Run(1, bsnc.stopChan); err != nil {
+			klog.Errorf("Error running endpointslice mirror controller for network %s: %v", bsnc.GetNetworkName(), err)
+		}
+	}()
+	go func() {
+		defer bsnc.wg.Done()
+		// secondary networks do not use load balancer groups nor templates
+		if err := svcController.Run(1, bsnc.stopChan, true, false, false); err != nil {
+			klog.Errorf("Error running services controller for network %s: %v", bsnc.GetNetworkName(), err)
+		}
+	}()
+	return nil
+}
+
+// deleteNetworkLoadBalancersOps returns the ops deleting the service load balancers of the given network
+func deleteNetworkLoadBalancersOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, netName string) ([]ovsdb.Operation, error) {
+	return libovsdbops.DeleteLoadBalancersWithPredicateOps(nbClient, ops,
+		func(item *nbdb.LoadBalancer) bool {
+			return item.ExternalIDs[types.NetworkExternalID] == netName
+		})
+}
@@ -182,6 +182,12 @@ func (oc *BaseSecondaryLayer2NetworkController) cleanup(topotype, netName string
 		return fmt.Errorf("failed to get ops for deleting switches of network %s: %v", netName, err)
 	}
 
+	// delete the load balancers of the services exposed on the network
+	ops, err = deleteNetworkLoadBalancersOps(oc.nbClient, ops, netName)
+	if err != nil {
+		return fmt.Errorf("failed to get ops for deleting load balancers of network %s: %v", netName, err)
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed to deleting switches of network %s: %v", netName, err)
@@ -198,6 +204,12 @@ func (oc *BaseSecondaryLayer2NetworkController) Run() error {
 		return err
 	}
 
+	if err := oc.startServiceControllers(); err != nil {
+		return err
+	}
+
+	oc.startNetworkStatusReporter(oc.getNetworkStatus)
+
 	klog.Infof("Completing all the Watchers for network %s took %v", oc.GetNetworkName(), time.Since(start))
 
 	// controller is fully running and resource handlers have synced, update Topology version in OVN
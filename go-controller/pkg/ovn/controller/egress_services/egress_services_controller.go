@@ -78,6 +78,11 @@ type Controller struct {
 	nodesSynced cache.InformerSynced
 	nodesQueue  workqueue.RateLimitingInterface
 
+	// servicesInitialSync and nodesInitialSync track the processing of the
+	// egress services and nodes held when the workers start
+	servicesInitialSync util.InitialSync
+	nodesInitialSync    util.InitialSync
+
 	// An address set factory that creates address sets
 	addressSetFactory addressset.AddressSetFactory
 }
@@ -213,6 +218,12 @@ func (c *Controller) Run(threadiness int) {
 		klog.Errorf("Failed to init Egress Services cluster policies: %v", err)
 	}
 
+	if err = c.startInitialSync(); err != nil {
+		klog.Errorf("Failed to list the Egress Services and nodes to sync: %v", err)
+		c.servicesInitialSync.Start(nil)
+		c.nodesInitialSync.Start(nil)
+	}
+
 	wg := &sync.WaitGroup{}
 	for i := 0; i < threadiness; i++ {
 		wg.Add(1)
@@ -246,6 +257,38 @@ func (c *Controller) Run(threadiness int) {
 	wg.Wait()
 }
 
+// startInitialSync sets the egress services and nodes to process before the
+// initial sync of the controller is done
+func (c *Controller) startInitialSync() error {
+	services, err := c.serviceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	serviceKeys := []string{}
+	for _, service := range services {
+		if isEgressService(service) {
+			serviceKeys = append(serviceKeys, service.Namespace+"/"+service.Name)
+		}
+	}
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	nodeKeys := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeKeys = append(nodeKeys, node.Name)
+	}
+	c.servicesInitialSync.Start(serviceKeys)
+	c.nodesInitialSync.Start(nodeKeys)
+	return nil
+}
+
+// HasSynced returns whether the egress services and nodes held when the
+// controller started were processed
+func (c *Controller) HasSynced() bool {
+	return c.servicesInitialSync.HasSynced() && c.nodesInitialSync.HasSynced()
+}
+
 // This takes care of syncing stale data which we might have in OVN if
 // there's no ovnkube-master running for a while.
 // It deletes all logical router policies from OVN that belong to services which are no longer
@@ -144,6 +144,7 @@ func (c *Controller) processNextNodeWorkItem(wg *sync.WaitGroup) bool {
 	err := c.syncNode(key.(string))
 	if err == nil {
 		c.nodesQueue.Forget(key)
+		c.nodesInitialSync.Done(key.(string))
 		return true
 	}
 
@@ -155,6 +156,7 @@ func (c *Controller) processNextNodeWorkItem(wg *sync.WaitGroup) bool {
 	}
 
 	c.nodesQueue.Forget(key)
+	c.nodesInitialSync.Done(key.(string))
 	return true
 }
 
@@ -45,11 +45,7 @@ func (c *Controller) onServiceAdd(obj interface{}) {
 
 	service := obj.(*corev1.Service)
 	// We only care about new LoadBalancer services that have the egress-service config annotation
-	if !util.ServiceTypeHasLoadBalancer(service) || len(service.Status.LoadBalancer.Ingress) == 0 {
-		return
-	}
-
-	if !util.HasEgressSVCAnnotation(service) && !util.HasEgressSVCHostAnnotation(service) {
+	if !isEgressService(service) {
 		return
 	}
 
@@ -120,6 +116,7 @@ func (c *Controller) processNextServiceWorkItem(wg *sync.WaitGroup) bool {
 	err := c.syncService(key.(string))
 	if err == nil {
 		c.servicesQueue.Forget(key)
+		c.servicesInitialSync.Done(key.(string))
 		return true
 	}
 
@@ -131,9 +128,19 @@ func (c *Controller) processNextServiceWorkItem(wg *sync.WaitGroup) bool {
 	}
 
 	c.servicesQueue.Forget(key)
+	c.servicesInitialSync.Done(key.(string))
 	return true
 }
 
+// isEgressService returns whether the service is a LoadBalancer service with an
+// ingress IP and the egress-service config annotation
+func isEgressService(service *corev1.Service) bool {
+	if !util.ServiceTypeHasLoadBalancer(service) || len(service.Status.LoadBalancer.Ingress) == 0 {
+		return false
+	}
+	return util.HasEgressSVCAnnotation(service) || util.HasEgressSVCHostAnnotation(service)
+}
+
 func (c *Controller) syncService(key string) error {
 	c.Lock()
 	defer c.Unlock()
@@ -0,0 +1,547 @@
+package endpointslice_mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	maxRetries     = 10
+	controllerName = "ovn-endpointslice-mirror-controller"
+
+	// maxEndpointSliceNameLength is the maximum length of an EndpointSlice name (DNS subdomain)
+	maxEndpointSliceNameLength = 253
+	// mirrorNameHashLength is the length of the network hash suffixed to mirrored EndpointSlice names
+	mirrorNameHashLength = 8
+)
+
+// Controller mirrors the EndpointSlices of the services exposed on a secondary network
+// (see util.ServiceNetworkAnnotation). For every default network EndpointSlice of such a
+// service, a mirrored EndpointSlice is maintained with the same ports and endpoints, but
+// with the endpoint pods' IPs on the secondary network, as found in their
+// k8s.ovn.org/pod-networks annotation.
+//
+// Mirrored EndpointSlices are managed-by types.EndpointSliceMirrorControllerName, carry
+// their Service name in the types.LabelMirroredServiceName label instead of the
+// kubernetes.io/service-name one, so that kube-proxy and DNS ignore them, carry the network
+// name in the types.EndpointSliceNetworkAnnotation annotation and are owned by their
+// Service so they get garbage collected with it.
+type Controller struct {
+	kubeClient clientset.Interface
+
+	// netInfo is the secondary network whose services are mirrored
+	netInfo util.NetInfo
+	// name of the controller, unique per network
+	name string
+
+	serviceLister  corelisters.ServiceLister
+	servicesSynced cache.InformerSynced
+
+	endpointSliceLister  discoverylisters.EndpointSliceLister
+	endpointSlicesSynced cache.InformerSynced
+
+	podLister  corelisters.PodLister
+	podsSynced cache.InformerSynced
+
+	// services that need to be mirrored, by key
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController returns a new *Controller mirroring the EndpointSlices of the services
+// exposed on the given secondary network.
+func NewController(kubeClient clientset.Interface,
+	netInfo util.NetInfo,
+	serviceInformer coreinformers.ServiceInformer,
+	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
+	podInformer coreinformers.PodInformer,
+) (*Controller, error) {
+	name := controllerName + "-" + netInfo.GetNetworkName()
+	c := &Controller{
+		kubeClient: kubeClient,
+		netInfo:    netInfo,
+		name:       name,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemFastSlowRateLimiter(1*time.Second, 5*time.Second, 5),
+			name,
+		),
+	}
+
+	c.serviceLister = serviceInformer.Lister()
+	c.servicesSynced = serviceInformer.Informer().HasSynced
+	_, err := serviceInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onServiceAdd,
+		UpdateFunc: c.onServiceUpdate,
+		DeleteFunc: c.onServiceDelete,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	c.endpointSliceLister = endpointSliceInformer.Lister()
+	c.endpointSlicesSynced = endpointSliceInformer.Informer().HasSynced
+	_, err = endpointSliceInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onEndpointSliceAdd,
+		UpdateFunc: c.onEndpointSliceUpdate,
+		DeleteFunc: c.onEndpointSliceDelete,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	c.podLister = podInformer.Lister()
+	c.podsSynced = podInformer.Informer().HasSynced
+	_, err = podInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.onPodUpdate,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Run will not return until stopCh is closed. workers determines how many
+// services will be handled in parallel.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting controller %s", c.name)
+	defer klog.Infof("Shutting down controller %s", c.name)
+
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, c.servicesSynced, c.endpointSlicesSynced, c.podsSynced) {
+		return fmt.Errorf("error syncing cache")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	return nil
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncService(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).Infof("Error mirroring EndpointSlices of service %s on network %s, retrying: %v",
+			key, c.netInfo.GetNetworkName(), err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	klog.Warningf("Dropping service %q out of the queue of %s: %v", key, c.name, err)
+	c.queue.Forget(key)
+	utilruntime.HandleError(err)
+	return true
+}
+
+// syncService makes the mirrored EndpointSlices of the given service match its default
+// network EndpointSlices. Mirrored EndpointSlices are deleted if the service is gone or
+// is no longer exposed on this controller's network.
+func (c *Controller) syncService(key string) error {
+	startTime := time.Now()
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Mirroring EndpointSlices of service %s on network %s", key, c.netInfo.GetNetworkName())
+	defer func() {
+		klog.V(4).Infof("Finished mirroring EndpointSlices of service %s on network %s: %v",
+			key, c.netInfo.GetNetworkName(), time.Since(startTime))
+	}()
+
+	esLabelSelector := labels.Set(map[string]string{
+		discovery.LabelServiceName: name,
+	}).AsSelectorPreValidated()
+	endpointSlices, err := c.endpointSliceLister.EndpointSlices(namespace).List(esLabelSelector)
+	if err != nil {
+		return err
+	}
+	mirroredEndpointSlices, err := c.endpointSliceLister.EndpointSlices(namespace).List(MirroredEndpointSliceSelector(name))
+	if err != nil {
+		return err
+	}
+	// mirrored EndpointSlices created with the kubernetes.io/service-name label are
+	// listed twice, and updated below to drop it
+	endpointSlices = append(endpointSlices, mirroredEndpointSlices...)
+
+	sourceSlices := []*discovery.EndpointSlice{}
+	mirroredSlices := map[string]*discovery.EndpointSlice{}
+	for _, endpointSlice := range endpointSlices {
+		if !isMirroredEndpointSlice(endpointSlice) {
+			sourceSlices = append(sourceSlices, endpointSlice)
+		} else if c.isEndpointSliceOnNetwork(endpointSlice) {
+			mirroredSlices[endpointSlice.Name] = endpointSlice
+		}
+	}
+
+	service, err := c.serviceLister.Services(namespace).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	nadName := ""
+	if service != nil && err == nil {
+		nadName = c.getServiceNADName(service)
+	}
+	if nadName == "" {
+		sourceSlices = nil
+	}
+
+	for _, sourceSlice := range sourceSlices {
+		desired, err := c.mirrorEndpointSlice(service, sourceSlice, nadName)
+		if err != nil {
+			return err
+		}
+		existing, ok := mirroredSlices[desired.Name]
+		if !ok {
+			klog.V(5).Infof("Creating mirrored EndpointSlice %s/%s for network %s", namespace, desired.Name,
+				c.netInfo.GetNetworkName())
+			_, err = c.kubeClient.DiscoveryV1().EndpointSlices(namespace).Create(context.TODO(), desired, metav1.CreateOptions{})
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create mirrored EndpointSlice %s/%s: %w", namespace, desired.Name, err)
+			}
+			continue
+		}
+		delete(mirroredSlices, desired.Name)
+		if endpointSlicesEqual(existing, desired) {
+			continue
+		}
+		updated := existing.DeepCopy()
+		updated.Labels = desired.Labels
+		updated.Annotations = desired.Annotations
+		updated.OwnerReferences = desired.OwnerReferences
+		updated.AddressType = desired.AddressType
+		updated.Endpoints = desired.Endpoints
+		updated.Ports = desired.Ports
+		klog.V(5).Infof("Updating mirrored EndpointSlice %s/%s for network %s", namespace, desired.Name,
+			c.netInfo.GetNetworkName())
+		_, err = c.kubeClient.DiscoveryV1().EndpointSlices(namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update mirrored EndpointSlice %s/%s: %w", namespace, desired.Name, err)
+		}
+	}
+
+	// whatever is left has no source EndpointSlice anymore
+	for _, stale := range mirroredSlices {
+		klog.V(5).Infof("Deleting stale mirrored EndpointSlice %s/%s for network %s", namespace, stale.Name,
+			c.netInfo.GetNetworkName())
+		err = c.kubeClient.DiscoveryV1().EndpointSlices(namespace).Delete(context.TODO(), stale.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete mirrored EndpointSlice %s/%s: %w", namespace, stale.Name, err)
+		}
+	}
+	return nil
+}
+
+// mirrorEndpointSlice builds the mirrored EndpointSlice of the given default network
+// EndpointSlice. Endpoints whose pod has no IP of the slice's address family on the
+// secondary network yet are left out; they are added back once the pod is annotated.
+func (c *Controller) mirrorEndpointSlice(service *v1.Service, sourceSlice *discovery.EndpointSlice, nadName string) (*discovery.EndpointSlice, error) {
+	mirrored := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mirroredEndpointSliceName(sourceSlice.Name, c.netInfo.GetNetworkName()),
+			Namespace: sourceSlice.Namespace,
+			Labels: map[string]string{
+				types.LabelMirroredServiceName: service.Name,
+				discovery.LabelManagedBy:       types.EndpointSliceMirrorControllerName,
+			},
+			Annotations: map[string]string{
+				types.EndpointSliceNetworkAnnotation: c.netInfo.GetNetworkName(),
+				types.SourceEndpointSliceAnnotation:  sourceSlice.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(service, v1.SchemeGroupVersion.WithKind("Service")),
+			},
+		},
+		AddressType: sourceSlice.AddressType,
+		Endpoints:   []discovery.Endpoint{},
+		Ports:       sourceSlice.Ports,
+	}
+
+	for _, endpoint := range sourceSlice.Endpoints {
+		if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+			continue
+		}
+		pod, err := c.podLister.Pods(sourceSlice.Namespace).Get(endpoint.TargetRef.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		addresses := getPodNetworkAddresses(pod, nadName, sourceSlice.AddressType)
+		if len(addresses) == 0 {
+			klog.V(5).Infof("Pod %s/%s has no %s address on NAD %s yet", pod.Namespace, pod.Name,
+				sourceSlice.AddressType, nadName)
+			continue
+		}
+		mirroredEndpoint := *endpoint.DeepCopy()
+		mirroredEndpoint.Addresses = addresses
+		mirrored.Endpoints = append(mirrored.Endpoints, mirroredEndpoint)
+	}
+	return mirrored, nil
+}
+
+// getPodNetworkAddresses returns the pod IPs of the given address family on the given NAD.
+func getPodNetworkAddresses(pod *v1.Pod, nadName string, addressType discovery.AddressType) []string {
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+	if err != nil {
+		return nil
+	}
+	addresses := []string{}
+	for _, ip := range podAnnotation.IPs {
+		switch addressType {
+		case discovery.AddressTypeIPv4:
+			if !utilnet.IsIPv6(ip.IP) {
+				addresses = append(addresses, ip.IP.String())
+			}
+		case discovery.AddressTypeIPv6:
+			if utilnet.IsIPv6(ip.IP) {
+				addresses = append(addresses, ip.IP.String())
+			}
+		}
+	}
+	return addresses
+}
+
+// getServiceNADName returns the NAD the service is exposed on, if it belongs to this
+// controller's network, or an empty string otherwise.
+func (c *Controller) getServiceNADName(service *v1.Service) string {
+	if !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
+		return ""
+	}
+	nadName, ok := util.GetServiceNetworkNADName(service)
+	if !ok || !c.netInfo.HasNAD(nadName) {
+		return ""
+	}
+	return nadName
+}
+
+// isEndpointSliceOnNetwork returns true if the given mirrored EndpointSlice was mirrored
+// for this controller's network.
+func (c *Controller) isEndpointSliceOnNetwork(endpointSlice *discovery.EndpointSlice) bool {
+	return endpointSlice.Annotations[types.EndpointSliceNetworkAnnotation] == c.netInfo.GetNetworkName()
+}
+
+// isMirroredEndpointSlice returns true if the given EndpointSlice was created by a mirror controller.
+func isMirroredEndpointSlice(endpointSlice *discovery.EndpointSlice) bool {
+	return endpointSlice.Labels[discovery.LabelManagedBy] == types.EndpointSliceMirrorControllerName
+}
+
+// MirroredEndpointSliceSelector returns the selector of the EndpointSlices mirrored for the
+// given Service, on any network.
+func MirroredEndpointSliceSelector(serviceName string) labels.Selector {
+	return labels.Set(map[string]string{
+		discovery.LabelManagedBy:       types.EndpointSliceMirrorControllerName,
+		types.LabelMirroredServiceName: serviceName,
+	}).AsSelectorPreValidated()
+}
+
+// ServiceNameForEndpointSlice returns the name of the Service of the given EndpointSlice,
+// either a default network or a mirrored one.
+func ServiceNameForEndpointSlice(endpointSlice *discovery.EndpointSlice) string {
+	if isMirroredEndpointSlice(endpointSlice) && endpointSlice.Labels[types.LabelMirroredServiceName] != "" {
+		return endpointSlice.Labels[types.LabelMirroredServiceName]
+	}
+	return endpointSlice.Labels[discovery.LabelServiceName]
+}
+
+// mirroredEndpointSliceName returns a stable name for the EndpointSlice mirrored from the given
+// default network EndpointSlice on the given network.
+func mirroredEndpointSliceName(sourceName, netName string) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(netName)))[:mirrorNameHashLength]
+	maxSourceLength := maxEndpointSliceNameLength - mirrorNameHashLength - 1
+	if len(sourceName) > maxSourceLength {
+		sourceName = sourceName[:maxSourceLength]
+	}
+	return sourceName + "-" + hash
+}
+
+// endpointSlicesEqual compares the fields of a mirrored EndpointSlice that this controller manages.
+func endpointSlicesEqual(existing, desired *discovery.EndpointSlice) bool {
+	return existing.AddressType == desired.AddressType &&
+		reflect.DeepEqual(existing.Labels, desired.Labels) &&
+		reflect.DeepEqual(existing.Annotations, desired.Annotations) &&
+		reflect.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) &&
+		reflect.DeepEqual(existing.Endpoints, desired.Endpoints) &&
+		reflect.DeepEqual(existing.Ports, desired.Ports)
+}
+
+// handlers
+
+func (c *Controller) queueService(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
+
+// onServiceAdd queues the Service for mirroring if it selects a NAD.
+func (c *Controller) onServiceAdd(obj interface{}) {
+	service := obj.(*v1.Service)
+	if _, ok := util.GetServiceNetworkNADName(service); !ok {
+		return
+	}
+	c.queueService(service)
+}
+
+// onServiceUpdate queues the Service for mirroring if it selects or selected a NAD.
+func (c *Controller) onServiceUpdate(oldObj, newObj interface{}) {
+	oldService := oldObj.(*v1.Service)
+	newService := newObj.(*v1.Service)
+
+	// don't process resync or objects that are marked for deletion
+	if oldService.ResourceVersion == newService.ResourceVersion ||
+		!newService.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	_, oldOk := util.GetServiceNetworkNADName(oldService)
+	_, newOk := util.GetServiceNetworkNADName(newService)
+	if !oldOk && !newOk {
+		return
+	}
+	c.queueService(newService)
+}
+
+// onServiceDelete queues the Service so that its mirrored EndpointSlices are removed.
+// Garbage collection would eventually remove them too, through their owner reference.
+func (c *Controller) onServiceDelete(obj interface{}) {
+	service, ok := obj.(*v1.Service)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %#v", obj))
+			return
+		}
+		service, ok = tombstone.Obj.(*v1.Service)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a Service: %#v", obj))
+			return
+		}
+	}
+	if _, ok := util.GetServiceNetworkNADName(service); !ok {
+		return
+	}
+	c.queueService(service)
+}
+
+// onEndpointSliceAdd queues a sync for the relevant Service
+func (c *Controller) onEndpointSliceAdd(obj interface{}) {
+	c.queueServiceForEndpointSlice(obj.(*discovery.EndpointSlice))
+}
+
+// onEndpointSliceUpdate queues a sync for the relevant Service
+func (c *Controller) onEndpointSliceUpdate(oldObj, newObj interface{}) {
+	oldEndpointSlice := oldObj.(*discovery.EndpointSlice)
+	endpointSlice := newObj.(*discovery.EndpointSlice)
+
+	// don't process resync or objects that are marked for deletion
+	if oldEndpointSlice.ResourceVersion == endpointSlice.ResourceVersion ||
+		!endpointSlice.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	c.queueServiceForEndpointSlice(endpointSlice)
+}
+
+// onEndpointSliceDelete queues a sync for the relevant Service
+func (c *Controller) onEndpointSliceDelete(obj interface{}) {
+	endpointSlice, ok := obj.(*discovery.EndpointSlice)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %#v", obj))
+			return
+		}
+		endpointSlice, ok = tombstone.Obj.(*discovery.EndpointSlice)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a EndpointSlice: %#v", obj))
+			return
+		}
+	}
+	c.queueServiceForEndpointSlice(endpointSlice)
+}
+
+// queueServiceForEndpointSlice queues the Service of the given EndpointSlice, if the
+// EndpointSlice is either a default network one or one mirrored for this network.
+func (c *Controller) queueServiceForEndpointSlice(endpointSlice *discovery.EndpointSlice) {
+	if endpointSlice == nil {
+		return
+	}
+	if isMirroredEndpointSlice(endpointSlice) && !c.isEndpointSliceOnNetwork(endpointSlice) {
+		return
+	}
+	serviceName := ServiceNameForEndpointSlice(endpointSlice)
+	if serviceName == "" {
+		return
+	}
+	service, err := c.serviceLister.Services(endpointSlice.Namespace).Get(serviceName)
+	if err == nil && c.getServiceNADName(service) == "" && !isMirroredEndpointSlice(endpointSlice) {
+		// default network slice of a service that is not on this network, nothing to mirror
+		return
+	}
+	c.queue.Add(endpointSlice.Namespace + "/" + serviceName)
+}
+
+// onPodUpdate queues the services of the pod's namespace exposed on this network when the
+// pod's network annotation changes, since the mirrored endpoint addresses are taken from it.
+func (c *Controller) onPodUpdate(oldObj, newObj interface{}) {
+	oldPod := oldObj.(*v1.Pod)
+	newPod := newObj.(*v1.Pod)
+	if oldPod.Annotations[util.OvnPodAnnotationName] == newPod.Annotations[util.OvnPodAnnotationName] {
+		return
+	}
+	services, err := c.serviceLister.Services(newPod.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list services in namespace %s: %v", newPod.Namespace, err)
+		return
+	}
+	for _, service := range services {
+		if c.getServiceNADName(service) == "" {
+			continue
+		}
+		c.queueService(service)
+	}
+}
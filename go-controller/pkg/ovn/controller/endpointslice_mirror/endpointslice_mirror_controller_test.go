@@ -0,0 +1,220 @@
+package endpointslice_mirror
+
+import (
+	"context"
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/stretchr/testify/assert"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	utilpointer "k8s.io/utils/pointer"
+)
+
+const (
+	testNamespace = "testns"
+	testNetwork   = "blue"
+	testNAD       = testNamespace + "/" + testNetwork
+)
+
+type testController struct {
+	*Controller
+	client          *fake.Clientset
+	informerFactory informers.SharedInformerFactory
+}
+
+func newTestController(t *testing.T) *testController {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	netInfo := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: testNetwork},
+		Topology: types.Layer2Topology,
+		NADName:  testNAD,
+	})
+	netInfo.AddNAD(testNAD)
+	c, err := NewController(client, netInfo,
+		informerFactory.Core().V1().Services(),
+		informerFactory.Discovery().V1().EndpointSlices(),
+		informerFactory.Core().V1().Pods(),
+	)
+	assert.NoError(t, err)
+	return &testController{Controller: c, client: client, informerFactory: informerFactory}
+}
+
+func (c *testController) add(t *testing.T, objs ...interface{}) {
+	for _, obj := range objs {
+		var err error
+		switch o := obj.(type) {
+		case *v1.Service:
+			err = c.informerFactory.Core().V1().Services().Informer().GetStore().Add(o)
+		case *v1.Pod:
+			err = c.informerFactory.Core().V1().Pods().Informer().GetStore().Add(o)
+		case *discovery.EndpointSlice:
+			err = c.informerFactory.Discovery().V1().EndpointSlices().Informer().GetStore().Add(o)
+		}
+		assert.NoError(t, err)
+	}
+}
+
+func newService(annotations map[string]string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   testNamespace,
+			UID:         "svc-uid",
+			Annotations: annotations,
+		},
+		Spec: v1.ServiceSpec{
+			Type:       v1.ServiceTypeClusterIP,
+			ClusterIP:  "192.168.1.1",
+			ClusterIPs: []string{"192.168.1.1"},
+			Ports: []v1.ServicePort{{
+				Port:     80,
+				Protocol: v1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+func newPod(name, defaultIP, secondaryIP string) *v1.Pod {
+	annotations := map[string]string{
+		util.OvnPodAnnotationName: `{"default":{"ip_addresses":["` + defaultIP + `/24"],"mac_address":"0a:58:0a:80:00:05"},` +
+			`"` + testNAD + `":{"ip_addresses":["` + secondaryIP + `/24"],"mac_address":"0a:58:0a:64:c8:05"}}`,
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			Annotations: annotations,
+		},
+	}
+}
+
+func newSourceEndpointSlice(pods ...*v1.Pod) *discovery.EndpointSlice {
+	tcp := v1.ProtocolTCP
+	slice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-ab23",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				discovery.LabelServiceName: "foo",
+				discovery.LabelManagedBy:   "endpointslice-controller.k8s.io",
+			},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{{
+			Protocol: &tcp,
+			Port:     utilpointer.Int32(8080),
+		}},
+	}
+	for _, pod := range pods {
+		slice.Endpoints = append(slice.Endpoints, discovery.Endpoint{
+			Addresses:  []string{"10.128.0.5"},
+			Conditions: discovery.EndpointConditions{Ready: utilpointer.Bool(true)},
+			TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+		})
+	}
+	return slice
+}
+
+func TestSyncServiceMirrorsEndpointSlices(t *testing.T) {
+	c := newTestController(t)
+	pod := newPod("pod1", "10.128.0.5", "10.100.200.5")
+	source := newSourceEndpointSlice(pod)
+	c.add(t, newService(map[string]string{util.ServiceNetworkAnnotation: testNetwork}), pod, source)
+
+	assert.NoError(t, c.syncService(testNamespace+"/foo"))
+
+	mirrored, err := c.client.DiscoveryV1().EndpointSlices(testNamespace).Get(context.TODO(),
+		mirroredEndpointSliceName(source.Name, testNetwork), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, types.EndpointSliceMirrorControllerName, mirrored.Labels[discovery.LabelManagedBy])
+	assert.Equal(t, "foo", mirrored.Labels[types.LabelMirroredServiceName])
+	// kube-proxy and DNS must not pick the mirrored EndpointSlices up
+	assert.NotContains(t, mirrored.Labels, discovery.LabelServiceName)
+	assert.Equal(t, testNetwork, mirrored.Annotations[types.EndpointSliceNetworkAnnotation])
+	assert.Equal(t, source.Name, mirrored.Annotations[types.SourceEndpointSliceAnnotation])
+	assert.Len(t, mirrored.OwnerReferences, 1)
+	assert.Equal(t, source.Ports, mirrored.Ports)
+	assert.Len(t, mirrored.Endpoints, 1)
+	assert.Equal(t, []string{"10.100.200.5"}, mirrored.Endpoints[0].Addresses)
+}
+
+func TestSyncServiceDeletesStaleMirroredEndpointSlices(t *testing.T) {
+	c := newTestController(t)
+	pod := newPod("pod1", "10.128.0.5", "10.100.200.5")
+	source := newSourceEndpointSlice(pod)
+	stale := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mirroredEndpointSliceName(source.Name, testNetwork),
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				types.LabelMirroredServiceName: "foo",
+				discovery.LabelManagedBy:       types.EndpointSliceMirrorControllerName,
+			},
+			Annotations: map[string]string{types.EndpointSliceNetworkAnnotation: testNetwork},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+	}
+	otherNetwork := stale.DeepCopy()
+	otherNetwork.Name = mirroredEndpointSliceName(source.Name, "red")
+	otherNetwork.Annotations[types.EndpointSliceNetworkAnnotation] = "red"
+	for _, slice := range []*discovery.EndpointSlice{stale, otherNetwork} {
+		_, err := c.client.DiscoveryV1().EndpointSlices(testNamespace).Create(context.TODO(), slice, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	// the service is no longer exposed on the secondary network
+	c.add(t, newService(nil), pod, source, stale, otherNetwork)
+
+	assert.NoError(t, c.syncService(testNamespace+"/foo"))
+
+	slices, err := c.client.DiscoveryV1().EndpointSlices(testNamespace).List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, slices.Items, 1)
+	assert.Equal(t, otherNetwork.Name, slices.Items[0].Name)
+}
+
+func TestSyncServiceDropsServiceNameLabelOfMirroredEndpointSlices(t *testing.T) {
+	c := newTestController(t)
+	pod := newPod("pod1", "10.128.0.5", "10.100.200.5")
+	source := newSourceEndpointSlice(pod)
+	legacy := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mirroredEndpointSliceName(source.Name, testNetwork),
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				discovery.LabelServiceName: "foo",
+				discovery.LabelManagedBy:   types.EndpointSliceMirrorControllerName,
+			},
+			Annotations: map[string]string{types.EndpointSliceNetworkAnnotation: testNetwork},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+	}
+	_, err := c.client.DiscoveryV1().EndpointSlices(testNamespace).Create(context.TODO(), legacy, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	c.add(t, newService(map[string]string{util.ServiceNetworkAnnotation: testNetwork}), pod, source, legacy)
+
+	assert.NoError(t, c.syncService(testNamespace+"/foo"))
+
+	mirrored, err := c.client.DiscoveryV1().EndpointSlices(testNamespace).Get(context.TODO(), legacy.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, mirrored.Labels, discovery.LabelServiceName)
+	assert.Equal(t, "foo", mirrored.Labels[types.LabelMirroredServiceName])
+	assert.Equal(t, []string{"10.100.200.5"}, mirrored.Endpoints[0].Addresses)
+}
+
+func TestMirroredEndpointSliceName(t *testing.T) {
+	name := mirroredEndpointSliceName("foo-ab23", testNetwork)
+	assert.Equal(t, name, mirroredEndpointSliceName("foo-ab23", testNetwork))
+	assert.NotEqual(t, name, mirroredEndpointSliceName("foo-ab23", "red"))
+
+	long := string(make([]byte, 300))
+	assert.Len(t, mirroredEndpointSliceName(long, testNetwork), maxEndpointSliceNameLength)
+}
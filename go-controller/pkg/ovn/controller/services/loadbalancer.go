@@ -416,18 +416,28 @@ func DeleteLBs(nbClient libovsdbclient.Client, uuids []string) error {
 	return nil
 }
 
-// getLBs returns a slice of load balancers found in OVN.
-func getLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap) ([]*LB, error) {
-	_, out, err := _getLBsCommon(nbClient, allTemplates, false)
+// getLBs returns a slice of load balancers of the given network found in OVN.
+func getLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap, netName string) ([]*LB, error) {
+	_, out, err := _getLBsCommon(nbClient, allTemplates, netName, false)
 	return out, err
 }
 
-// getServiceLBs returns a set of services as well as a slice of load balancers found in OVN.
-func getServiceLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap) (sets.Set[string], []*LB, error) {
-	return _getLBsCommon(nbClient, allTemplates, true)
+// getServiceLBs returns a set of services as well as a slice of load balancers of the given
+// network found in OVN.
+func getServiceLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap, netName string) (sets.Set[string], []*LB, error) {
+	return _getLBsCommon(nbClient, allTemplates, netName, true)
 }
 
-func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, withServiceOwner bool) (sets.Set[string], []*LB, error) {
+// getLoadBalancerNetworkName returns the name of the network a load balancer was created for.
+// Load balancers without a network external ID belong to the default network.
+func getLoadBalancerNetworkName(lb *nbdb.LoadBalancer) string {
+	if netName, ok := lb.ExternalIDs[types.NetworkExternalID]; ok {
+		return netName
+	}
+	return types.DefaultNetworkName
+}
+
+func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, netName string, withServiceOwner bool) (sets.Set[string], []*LB, error) {
 	lbs, err := libovsdbops.ListLoadBalancers(nbClient)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not list load_balancer: %w", err)
@@ -442,6 +452,11 @@ func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, wit
 			continue
 		}
 
+		// Skip load balancers of other networks
+		if getLoadBalancerNetworkName(lb) != netName {
+			continue
+		}
+
 		if withServiceOwner {
 			service, ok := lb.ExternalIDs[types.LoadBalancerOwnerExternalID]
 			if !ok {
@@ -29,6 +29,11 @@ type nodeTracker struct {
 
 	// resyncFn is the function to call so that all service are resynced
 	resyncFn func(nodes []nodeInfo)
+
+	// netInfo is the network whose node switches are tracked
+	netInfo util.NetInfo
+	// topology of the secondary network, empty for the default network
+	topology string
 }
 
 type nodeInfo struct {
@@ -75,9 +80,11 @@ func (ni *nodeInfo) nodeSubnets() []net.IPNet {
 	return out
 }
 
-func newNodeTracker(nodeInformer coreinformers.NodeInformer) (*nodeTracker, error) {
+func newNodeTracker(nodeInformer coreinformers.NodeInformer, netInfo util.NetInfo, topology string) (*nodeTracker, error) {
 	nt := &nodeTracker{
-		nodes: map[string]nodeInfo{},
+		nodes:    map[string]nodeInfo{},
+		netInfo:  netInfo,
+		topology: topology,
 	}
 
 	_, err := nodeInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
@@ -189,6 +196,10 @@ func (nt *nodeTracker) removeNode(nodeName string) {
 // The gateway router will exist sometime after the L3Gateway annotation is set.
 func (nt *nodeTracker) updateNode(node *v1.Node) {
 	klog.V(2).Infof("Processing possible switch / router updates for node %s", node.Name)
+	if nt.netInfo.IsSecondary() {
+		nt.updateSecondaryNetworkNode(node)
+		return
+	}
 	hsn, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
 	if err != nil || hsn == nil {
 		// usually normal; means the node's gateway hasn't been initialized yet
@@ -227,6 +238,29 @@ func (nt *nodeTracker) updateNode(node *v1.Node) {
 	)
 }
 
+// updateSecondaryNetworkNode is called when a node's switch on a secondary network may have
+// changed. Secondary networks have neither gateway routers nor node port vips, so only the
+// switch the pods of the node are connected to is tracked.
+func (nt *nodeTracker) updateSecondaryNetworkNode(node *v1.Node) {
+	switch nt.topology {
+	case types.Layer3Topology:
+		hsn, err := util.ParseNodeHostSubnetAnnotation(node, nt.netInfo.GetNetworkName())
+		if err != nil || hsn == nil {
+			klog.Infof("Node %s has invalid / no HostSubnet annotations for network %s (probably waiting on initialization): %v",
+				node.Name, nt.netInfo.GetNetworkName(), err)
+			nt.removeNode(node.Name)
+			return
+		}
+		nt.updateNodeInfo(node.Name, nt.netInfo.GetPrefix()+node.Name, "", "", []net.IP{}, hsn)
+	case types.Layer2Topology:
+		nt.updateNodeInfo(node.Name, nt.netInfo.GetPrefix()+types.OVNLayer2Switch, "", "", []net.IP{}, nil)
+	case types.LocalnetTopology:
+		nt.updateNodeInfo(node.Name, nt.netInfo.GetPrefix()+types.OVNLocalnetSwitch, "", "", []net.IP{}, nil)
+	default:
+		klog.Errorf("Unsupported topology %q for services on network %s", nt.topology, nt.netInfo.GetNetworkName())
+	}
+}
+
 // allNodes returns a list of all nodes (and their relevant information)
 func (nt *nodeTracker) allNodes() []nodeInfo {
 	out := make([]nodeInfo, 0, len(nt.nodes))
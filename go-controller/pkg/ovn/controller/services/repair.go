@@ -38,14 +38,18 @@ type repair struct {
 	unsyncedServices sets.Set[string]
 
 	nbClient libovsdbclient.Client
+
+	// netName is the name of the network whose load balancers are repaired
+	netName string
 }
 
 // NewRepair creates a controller that periodically ensures that there is no stale data in OVN
-func newRepair(serviceLister corelisters.ServiceLister, nbClient libovsdbclient.Client) *repair {
+func newRepair(serviceLister corelisters.ServiceLister, nbClient libovsdbclient.Client, netName string) *repair {
 	return &repair{
 		serviceLister:    serviceLister,
 		unsyncedServices: sets.Set[string]{},
 		nbClient:         nbClient,
+		netName:          netName,
 	}
 }
 
@@ -80,7 +84,7 @@ func (r *repair) runBeforeSync(useTemplates bool) {
 	}
 
 	// Find all load-balancers associated with Services
-	existingLBs, err := getLBs(r.nbClient, allTemplates)
+	existingLBs, err := getLBs(r.nbClient, allTemplates, r.netName)
 	if err != nil {
 		klog.Errorf("Unable to get service lbs for repair: %v", err)
 	}
@@ -133,6 +137,11 @@ func (r *repair) runBeforeSync(useTemplates bool) {
 	}
 	klog.V(2).Infof("Deleted %d stale Chassis Template Vars", len(staleTemplateNames))
 
+	// Legacy reject ACLs were only ever created for the default network.
+	if r.netName != types.DefaultNetworkName {
+		return
+	}
+
 	// Remove existing reject rules. They are not used anymore
 	// given the introduction of idling loadbalancers
 	p := func(item *nbdb.ACL) bool {
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// filterEndpointSlicesForNetwork returns the EndpointSlices mirrored for the given
+// secondary network, i.e. the ones holding the secondary network IPs of the endpoints.
+func filterEndpointSlicesForNetwork(endpointSlices []*discovery.EndpointSlice, netName string) []*discovery.EndpointSlice {
+	out := make([]*discovery.EndpointSlice, 0, len(endpointSlices))
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.Labels[discovery.LabelManagedBy] != types.EndpointSliceMirrorControllerName {
+			continue
+		}
+		if endpointSlice.Annotations[types.EndpointSliceNetworkAnnotation] != netName {
+			continue
+		}
+		out = append(out, endpointSlice)
+	}
+	return out
+}
+
+// buildSecondaryNetworkServiceLBConfigs generates the abstract load balancer configurations
+// for a service exposed on a secondary network.
+//
+// Secondary networks have neither gateway routers nor a host network, so only the
+// ClusterIPs are load balanced: NodePorts, ExternalIPs and LoadBalancer IPs keep
+// being handled on the default network. All configs are cluster-wide.
+func buildSecondaryNetworkServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.EndpointSlice) []lbConfig {
+	configs := make([]lbConfig, 0, len(service.Spec.Ports))
+	vips := util.GetClusterIPs(service)
+	for _, svcPort := range service.Spec.Ports {
+		configs = append(configs, lbConfig{
+			protocol: svcPort.Protocol,
+			inport:   svcPort.Port,
+			vips:     vips,
+			eps:      util.GetLbEndpoints(endpointSlices, svcPort, service.Spec.PublishNotReadyAddresses),
+		})
+	}
+	return configs
+}
+
+// buildSecondaryNetworkClusterLBs expands the cluster-wide configs of a service exposed
+// on a secondary network to one OVN LB per protocol, attached to every switch of the network.
+//
+// The LBs are named after the network and carry its name in their external IDs so that
+// they are never mistaken for the default network LBs of the same service.
+func buildSecondaryNetworkClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeInfo, netInfo util.NetInfo) []LB {
+	lbs := buildClusterLBs(service, configs, nodeInfos, false)
+	for i := range lbs {
+		lbs[i].Name = netInfo.GetPrefix() + lbs[i].Name
+		lbs[i].ExternalIDs[types.NetworkExternalID] = netInfo.GetNetworkName()
+		// layer2 and localnet networks have a single switch shared by all nodes
+		lbs[i].Switches = sets.List(sets.New(lbs[i].Switches...))
+	}
+	return lbs
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/stretchr/testify/assert"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilpointer "k8s.io/utils/pointer"
+)
+
+func Test_filterEndpointSlicesForNetwork(t *testing.T) {
+	newSlice := func(name, managedBy, network string) *discovery.EndpointSlice {
+		slice := &discovery.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "testns",
+				Labels: map[string]string{
+					discovery.LabelServiceName: "foo",
+				},
+				Annotations: map[string]string{},
+			},
+		}
+		if managedBy != "" {
+			slice.Labels[discovery.LabelManagedBy] = managedBy
+		}
+		if network != "" {
+			slice.Annotations[types.EndpointSliceNetworkAnnotation] = network
+		}
+		return slice
+	}
+
+	slices := []*discovery.EndpointSlice{
+		newSlice("default", "endpointslice-controller.k8s.io", ""),
+		newSlice("blue", types.EndpointSliceMirrorControllerName, "blue"),
+		newSlice("red", types.EndpointSliceMirrorControllerName, "red"),
+		newSlice("spoofed", "someone-else", "blue"),
+	}
+
+	filtered := filterEndpointSlicesForNetwork(slices, "blue")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "blue", filtered[0].Name)
+	assert.Empty(t, filterEndpointSlicesForNetwork(slices, "green"))
+}
+
+func Test_buildSecondaryNetworkClusterLBs(t *testing.T) {
+	tcp := v1.ProtocolTCP
+	netInfo := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: "blue"},
+		Topology: types.Layer2Topology,
+		NADName:  "testns/blue",
+	})
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "testns",
+			Annotations: map[string]string{util.ServiceNetworkAnnotation: "blue"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:       v1.ServiceTypeNodePort,
+			ClusterIP:  "192.168.1.1",
+			ClusterIPs: []string{"192.168.1.1"},
+			Ports: []v1.ServicePort{{
+				Name:     "tcp-example",
+				Port:     80,
+				Protocol: v1.ProtocolTCP,
+				NodePort: 30080,
+			}},
+		},
+	}
+	slices := []*discovery.EndpointSlice{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-ab23-4f8a2c1d",
+			Namespace: "testns",
+			Labels: map[string]string{
+				types.LabelMirroredServiceName: "foo",
+				discovery.LabelManagedBy:       types.EndpointSliceMirrorControllerName,
+			},
+			Annotations: map[string]string{types.EndpointSliceNetworkAnnotation: "blue"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{{
+			Name:     utilpointer.String("tcp-example"),
+			Protocol: &tcp,
+			Port:     utilpointer.Int32(8080),
+		}},
+		Endpoints: []discovery.Endpoint{{
+			Conditions: discovery.EndpointConditions{Ready: utilpointer.Bool(true)},
+			Addresses:  []string{"10.100.200.5"},
+		}},
+	}}
+
+	configs := buildSecondaryNetworkServiceLBConfigs(service, slices)
+	// the node port is not load balanced on the secondary network
+	assert.Len(t, configs, 1)
+	assert.Equal(t, []string{"192.168.1.1"}, configs[0].vips)
+	assert.Equal(t, []string{"10.100.200.5"}, configs[0].eps.V4IPs)
+
+	nodeInfos := []nodeInfo{
+		{name: "node-a", switchName: netInfo.GetPrefix() + types.OVNLayer2Switch},
+		{name: "node-b", switchName: netInfo.GetPrefix() + types.OVNLayer2Switch},
+	}
+	lbs := buildSecondaryNetworkClusterLBs(service, configs, nodeInfos, netInfo)
+	assert.Len(t, lbs, 1)
+	lb := lbs[0]
+	assert.Equal(t, "blue_Service_testns/foo_TCP_cluster", lb.Name)
+	assert.Equal(t, "blue", lb.ExternalIDs[types.NetworkExternalID])
+	assert.Equal(t, "testns/foo", lb.ExternalIDs[types.LoadBalancerOwnerExternalID])
+	assert.Equal(t, []string{"blue_" + types.OVNLayer2Switch}, lb.Switches)
+	assert.Empty(t, lb.Routers)
+	assert.Empty(t, lb.Groups)
+	assert.Equal(t, []LBRule{{
+		Source:  Addr{IP: "192.168.1.1", Port: 80},
+		Targets: []Addr{{IP: "10.100.200.5", Port: 8080}},
+	}}, lb.Rules)
+}
+
+func TestServiceControllerKeyMirroredEndpointSlice(t *testing.T) {
+	slice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-ab23-4f8a2c1d",
+			Namespace: "testns",
+			Labels: map[string]string{
+				types.LabelMirroredServiceName: "foo",
+				discovery.LabelManagedBy:       types.EndpointSliceMirrorControllerName,
+			},
+		},
+	}
+	key, err := ServiceControllerKey(slice)
+	assert.NoError(t, err)
+	assert.Equal(t, "testns/foo", key)
+
+	// the mirror label is ignored on EndpointSlices managed by someone else
+	slice.Labels[discovery.LabelManagedBy] = "someone-else"
+	_, err = ServiceControllerKey(slice)
+	assert.ErrorIs(t, err, NoServiceLabelError)
+}
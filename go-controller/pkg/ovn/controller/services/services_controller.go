@@ -10,6 +10,7 @@ import (
 	globalconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/endpointslice_mirror"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"golang.org/x/time/rate"
@@ -54,13 +55,48 @@ func NewController(client clientset.Interface,
 	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
 	nodeInformer coreinformers.NodeInformer,
 	recorder record.EventRecorder,
+) (*Controller, error) {
+	return newNetworkController(client, nbClient, &util.DefaultNetInfo{}, "", serviceInformer, endpointSliceInformer,
+		nodeInformer, recorder)
+}
+
+// NewSecondaryNetworkController returns a new *Controller that programs the load balancers
+// of the services exposed on the given secondary network via the service-network annotation.
+// Endpoints are taken from the EndpointSlices mirrored for that network.
+func NewSecondaryNetworkController(client clientset.Interface,
+	nbClient libovsdbclient.Client,
+	netInfo util.NetInfo,
+	topology string,
+	serviceInformer coreinformers.ServiceInformer,
+	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
+	nodeInformer coreinformers.NodeInformer,
+	recorder record.EventRecorder,
+) (*Controller, error) {
+	return newNetworkController(client, nbClient, netInfo, topology, serviceInformer, endpointSliceInformer,
+		nodeInformer, recorder)
+}
+
+func newNetworkController(client clientset.Interface,
+	nbClient libovsdbclient.Client,
+	netInfo util.NetInfo,
+	topology string,
+	serviceInformer coreinformers.ServiceInformer,
+	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
+	nodeInformer coreinformers.NodeInformer,
+	recorder record.EventRecorder,
 ) (*Controller, error) {
 	klog.V(4).Info("Creating event broadcaster")
 
+	name := controllerName
+	if netInfo.IsSecondary() {
+		name = controllerName + "-" + netInfo.GetNetworkName()
+	}
 	c := &Controller{
 		client:           client,
 		nbClient:         nbClient,
-		queue:            workqueue.NewNamedRateLimitingQueue(newRatelimiter(100), controllerName),
+		netInfo:          netInfo,
+		name:             name,
+		queue:            workqueue.NewNamedRateLimitingQueue(newRatelimiter(100), name),
 		workerLoopPeriod: time.Second,
 		alreadyApplied:   map[string][]LB{},
 		nodeIPv4Template: makeTemplate(makeLBNodeIPTemplateName(v1.IPv4Protocol)),
@@ -97,11 +133,11 @@ func NewController(client clientset.Interface,
 	c.eventRecorder = recorder
 
 	// repair controller
-	c.repair = newRepair(serviceInformer.Lister(), nbClient)
+	c.repair = newRepair(serviceInformer.Lister(), nbClient, netInfo.GetNetworkName())
 
 	// load balancers need to be applied to nodes, so
 	// we need to watch Node objects for changes.
-	c.nodeTracker, err = newNodeTracker(nodeInformer)
+	c.nodeTracker, err = newNodeTracker(nodeInformer, netInfo, topology)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +155,11 @@ type Controller struct {
 	nbClient      libovsdbclient.Client
 	eventRecorder record.EventRecorder
 
+	// netInfo is the network the load balancers are programmed on
+	netInfo util.NetInfo
+	// name of the controller, unique per network
+	name string
+
 	// serviceLister is able to list/get services and is populated by the shared informer passed to
 	serviceLister corelisters.ServiceLister
 	// servicesSynced returns true if the service shared informer has been synced at least once.
@@ -173,6 +214,9 @@ type Controller struct {
 
 	// 'true' if Chassis_Template_Var is supported.
 	useTemplates bool
+
+	// initialSync tracks the processing of the services held when the workers start
+	initialSync util.InitialSync
 }
 
 // Run will not return until stopCh is closed. workers determines how many
@@ -181,15 +225,15 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	defer utilruntime.HandleCrash()
 	defer c.queue.ShutDown()
 
-	klog.Infof("Starting controller %s", controllerName)
-	defer klog.Infof("Shutting down controller %s", controllerName)
+	klog.Infof("Starting controller %s", c.name)
+	defer klog.Infof("Shutting down controller %s", c.name)
 
 	c.useLBGroups = useLBGroups
 	c.useTemplates = useTemplates
 
 	// Wait for the caches to be synced
 	klog.Info("Waiting for informer caches to sync")
-	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.servicesSynced, c.endpointSlicesSynced, c.nodesSynced) {
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, c.servicesSynced, c.endpointSlicesSynced, c.nodesSynced) {
 		return fmt.Errorf("error syncing cache")
 	}
 
@@ -204,6 +248,10 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 		return fmt.Errorf("error initializing alreadyApplied cache: %w", err)
 	}
 
+	if err := c.startInitialSync(); err != nil {
+		return err
+	}
+
 	// Start the workers after the repair loop to avoid races
 	klog.Info("Starting workers")
 	for i := 0; i < workers; i++ {
@@ -214,6 +262,35 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	return nil
 }
 
+// startInitialSync sets the services to process before the initial sync of
+// the controller is done. The services are listed once the caches are synced
+// and before the workers start, so that all of them are queued by the informer
+// and none of them is processed before it is tracked.
+func (c *Controller) startInitialSync() error {
+	services, err := c.serviceLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing services: %w", err)
+	}
+	keys := make([]string, 0, len(services))
+	for _, service := range services {
+		key, err := cache.MetaNamespaceKeyFunc(service)
+		if err != nil {
+			return fmt.Errorf("error getting the key of service %s/%s: %w", service.Namespace, service.Name, err)
+		}
+		keys = append(keys, key)
+	}
+	c.initialSync.Start(keys)
+	return nil
+}
+
+// HasSynced returns whether the services held when the controller started were
+// processed, successfully or for the last time, that is whether the load
+// balancers of the services that existed on startup are programmed. The workers
+// process them in the background, so the caller of Run cannot tell otherwise.
+func (c *Controller) HasSynced() bool {
+	return c.initialSync.HasSynced()
+}
+
 // worker runs a worker thread that just dequeues items, processes them, and
 // marks them done. You may run as many of these in parallel as you wish; the
 // workqueue guarantees that they will not end up processing the same service
@@ -244,6 +321,7 @@ func (c *Controller) handleErr(err error, key interface{}) {
 	if err == nil {
 		metrics.GetConfigDurationRecorder().End("service", ns, name)
 		c.queue.Forget(key)
+		c.initialSync.Done(key.(string))
 		return
 	}
 
@@ -258,6 +336,7 @@ func (c *Controller) handleErr(err error, key interface{}) {
 	klog.Warningf("Dropping service %q out of the queue: %v", key, err)
 	metrics.GetConfigDurationRecorder().End("service", ns, name)
 	c.queue.Forget(key)
+	c.initialSync.Done(key.(string))
 	utilruntime.HandleError(err)
 }
 
@@ -282,7 +361,7 @@ func (c *Controller) initTopLevelCache() error {
 	}
 
 	// Then list all load balancers and their respective services.
-	services, lbs, err := getServiceLBs(c.nbClient, allTemplates)
+	services, lbs, err := getServiceLBs(c.nbClient, allTemplates, c.netInfo.GetNetworkName())
 	if err != nil {
 		return fmt.Errorf("failed to load balancers: %w", err)
 	}
@@ -336,7 +415,9 @@ func (c *Controller) syncService(key string) error {
 	// Delete the Service's LB(s) from OVN if:
 	// - the Service was deleted from the cache (doesn't exist in Kubernetes anymore)
 	// - the Service mutated to a new service Type that we don't handle (ExternalName, Headless)
-	if err != nil || service == nil || !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
+	// - the Service is not (or no longer) exposed on this controller's network
+	if err != nil || service == nil || !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) ||
+		!c.isServiceOnNetwork(service) {
 		service = &v1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: namespace,
@@ -379,10 +460,13 @@ func (c *Controller) syncService(key string) error {
 
 	klog.V(5).Infof("Service %s retrieved from lister: %v", service.Name, service)
 
-	// Get the endpoint slices associated to the Service
+	// Get the endpoint slices associated to the Service, the mirrored ones on secondary networks
 	esLabelSelector := labels.Set(map[string]string{
 		discovery.LabelServiceName: name,
 	}).AsSelectorPreValidated()
+	if c.netInfo.IsSecondary() {
+		esLabelSelector = endpointslice_mirror.MirroredEndpointSliceSelector(name)
+	}
 	endpointSlices, err := c.endpointSliceLister.EndpointSlices(namespace).List(esLabelSelector)
 	if err != nil {
 		// Since we're getting stuff from a local cache, it is basically impossible to get this error.
@@ -392,14 +476,25 @@ func (c *Controller) syncService(key string) error {
 	}
 
 	// Build the abstract LB configs for this service
-	perNodeConfigs, templateConfigs, clusterConfigs := buildServiceLBConfigs(service, endpointSlices,
-		c.useLBGroups, c.useTemplates)
+	var perNodeConfigs, templateConfigs, clusterConfigs []lbConfig
+	if c.netInfo.IsSecondary() {
+		endpointSlices = filterEndpointSlicesForNetwork(endpointSlices, c.netInfo.GetNetworkName())
+		clusterConfigs = buildSecondaryNetworkServiceLBConfigs(service, endpointSlices)
+	} else {
+		perNodeConfigs, templateConfigs, clusterConfigs = buildServiceLBConfigs(service, endpointSlices,
+			c.useLBGroups, c.useTemplates)
+	}
 	klog.V(5).Infof("Built service %s LB cluster-wide configs %#v", key, clusterConfigs)
 	klog.V(5).Infof("Built service %s LB per-node configs %#v", key, perNodeConfigs)
 	klog.V(5).Infof("Built service %s LB template configs %#v", key, templateConfigs)
 
 	// Convert the LB configs in to load-balancer objects
-	clusterLBs := buildClusterLBs(service, clusterConfigs, c.nodeInfos, c.useLBGroups)
+	var clusterLBs []LB
+	if c.netInfo.IsSecondary() {
+		clusterLBs = buildSecondaryNetworkClusterLBs(service, clusterConfigs, c.nodeInfos, c.netInfo)
+	} else {
+		clusterLBs = buildClusterLBs(service, clusterConfigs, c.nodeInfos, c.useLBGroups)
+	}
 	templateLBs := buildTemplateLBs(service, templateConfigs, c.nodeInfos,
 		c.nodeIPv4Template, c.nodeIPv6Template)
 	perNodeLBs := buildPerNodeLBs(service, perNodeConfigs, c.nodeInfos)
@@ -492,6 +587,17 @@ func (c *Controller) syncNodeInfos(nodeInfos []nodeInfo) {
 	}
 }
 
+// isServiceOnNetwork returns true if the service load balancers have to be programmed
+// on this controller's network. The default network handles every service, while a
+// secondary network only handles the services selecting one of its NADs.
+func (c *Controller) isServiceOnNetwork(service *v1.Service) bool {
+	if !c.netInfo.IsSecondary() {
+		return true
+	}
+	nadName, ok := util.GetServiceNetworkNADName(service)
+	return ok && c.netInfo.HasNAD(nadName)
+}
+
 // RequestFullSync re-syncs every service that currently exists
 func (c *Controller) RequestFullSync(nodeInfos []nodeInfo) {
 	klog.Info("Full service sync requested")
@@ -628,8 +734,8 @@ func ServiceControllerKey(endpointSlice *discovery.EndpointSlice) (string, error
 	if endpointSlice == nil {
 		return "", fmt.Errorf("nil EndpointSlice passed to serviceControllerKey()")
 	}
-	serviceName, ok := endpointSlice.Labels[discovery.LabelServiceName]
-	if !ok || serviceName == "" {
+	serviceName := endpointslice_mirror.ServiceNameForEndpointSlice(endpointSlice)
+	if serviceName == "" {
 		return "", fmt.Errorf("%w: endpointSlice: %s/%s", NoServiceLabelError, endpointSlice.Namespace,
 			endpointSlice.Name)
 	}
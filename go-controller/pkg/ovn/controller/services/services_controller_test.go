@@ -694,3 +694,32 @@ func temporarilyEnableGomegaMaxLengthFormat() {
 func restoreGomegaMaxLengthFormat(originalLength int) {
 	format.MaxLength = originalLength
 }
+
+func TestHasSynced(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	controller, err := newController()
+	if err != nil {
+		t.Fatalf("Error creating controller: %v", err)
+	}
+	defer controller.close()
+
+	g.Expect(controller.HasSynced()).To(gomega.BeFalse(), "synced before the controller started")
+
+	svc1 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc1", Namespace: "testns"}}
+	svc2 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc2", Namespace: "testns"}}
+	g.Expect(controller.serviceStore.Add(svc1)).To(gomega.Succeed())
+	g.Expect(controller.serviceStore.Add(svc2)).To(gomega.Succeed())
+	g.Expect(controller.startInitialSync()).To(gomega.Succeed())
+	g.Expect(controller.HasSynced()).To(gomega.BeFalse(), "synced before the services were processed")
+
+	controller.handleErr(nil, "testns/svc1")
+	// a service created after the controller started is not waited for
+	controller.handleErr(nil, "testns/svc3")
+	for i := 0; i < maxRetries; i++ {
+		controller.handleErr(fmt.Errorf("failed to sync"), "testns/svc2")
+		g.Expect(controller.HasSynced()).To(gomega.BeFalse(), "synced while a service is retried")
+	}
+	// dropping the service out of the queue completes its processing
+	controller.handleErr(fmt.Errorf("failed to sync"), "testns/svc2")
+	g.Expect(controller.HasSynced()).To(gomega.BeTrue())
+}
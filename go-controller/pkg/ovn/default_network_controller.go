@@ -69,6 +69,11 @@ type DefaultNetworkController struct {
 	egressQoSNodeSynced cache.InformerSynced
 	egressQoSNodeQueue  workqueue.RateLimitingInterface
 
+	// egressQoSInitialSync and egressQoSNodeInitialSync track the processing of
+	// the EgressQoSes and nodes held when the EgressQoS workers start
+	egressQoSInitialSync     util.InitialSync
+	egressQoSNodeInitialSync util.InitialSync
+
 	// network policies map, key should be retrieved with getPolicyKey(policy *knet.NetworkPolicy).
 	// network policies that failed to be created will also be added here, and can be retried or cleaned up later.
 	// network policy is only deleted from this map after successful cleanup.
@@ -403,6 +408,9 @@ func (oc *DefaultNetworkController) Init() error {
 // Run starts the actual watching.
 func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 	oc.syncPeriodic()
+	if config.NBAudit.Interval > 0 {
+		oc.runDBAudit()
+	}
 	klog.Infof("Starting all the Watchers...")
 	start := time.Now()
 
@@ -553,6 +561,19 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 	return nil
 }
 
+// HasSynced returns whether the controllers Run starts in the background, the
+// services, Egress Services and EgressQoS controllers, have processed the
+// objects they held when they started. Run returns once the handlers of the
+// other objects synced the existing ones, but before these controllers did: the
+// NB database rebuild waits for this before comparing the database with its
+// snapshot.
+func (oc *DefaultNetworkController) HasSynced() bool {
+	if !oc.svcController.HasSynced() || !oc.egressSvcController.HasSynced() {
+		return false
+	}
+	return !config.OVNKubernetesFeature.EnableEgressQoS || oc.egressQoSHasSynced()
+}
+
 func WithSyncDurationMetric(resourceName string, f func() error) error {
 	start := time.Now()
 	defer func() {
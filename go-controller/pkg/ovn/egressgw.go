@@ -350,7 +350,7 @@ func (oc *DefaultNetworkController) deletePodGWRoute(routeInfo *externalRouteInf
 		}
 	}
 
-	portPrefix, err := oc.extSwitchPrefix(node)
+	portPrefix, err := oc.extSwitchPrefix(node, gw)
 	if err != nil {
 		return err
 	}
@@ -477,13 +477,6 @@ func (oc *DefaultNetworkController) addGWRoutesForPod(gateways []*gatewayInfo, p
 	gr := util.GetGatewayRouterFromNode(node)
 
 	routesAdded := 0
-	portPrefix, err := oc.extSwitchPrefix(node)
-	if err != nil {
-		klog.Infof("Failed to find ext switch prefix for %s %v", node, err)
-		return err
-	}
-
-	port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
 	routeInfo, err := oc.ensureRouteInfoLocked(podNsName)
 	if err != nil {
 		return fmt.Errorf("failed to ensure routeInfo for %s, error: %v", podNsName, err)
@@ -504,6 +497,12 @@ func (oc *DefaultNetworkController) addGWRoutesForPod(gateways []*gatewayInfo, p
 					}
 					mask := util.GetIPFullMask(podIP)
 
+					portPrefix, err := oc.extSwitchPrefix(node, gw)
+					if err != nil {
+						klog.Infof("Failed to find ext switch prefix for %s %v", node, err)
+						return err
+					}
+					port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
 					if err := oc.createBFDStaticRoute(gateway.bfdEnabled, gw, podIP, gr, port, mask); err != nil {
 						return err
 					}
@@ -860,9 +859,12 @@ func (oc *DefaultNetworkController) cleanUpBFDEntry(gatewayIP, gatewayRouter, pr
 }
 
 // extSwitchPrefix returns the prefix of the external switch to use for
-// external gateway routes. In case no second bridge is configured, we
+// external gateway routes to gw. In case gw is on the subnet of one of the
+// additional named gateway bridges of the node, we use the switch of that
+// bridge, so that namespaces select the bridge their traffic egresses through
+// with their external gateways. In case no second bridge is configured, we
 // use the default one and the prefix is empty.
-func (oc *DefaultNetworkController) extSwitchPrefix(nodeName string) (string, error) {
+func (oc *DefaultNetworkController) extSwitchPrefix(nodeName, gw string) (string, error) {
 	node, err := oc.watchFactory.GetNode(nodeName)
 	if err != nil {
 		return "", errors.Wrapf(err, "extSwitchPrefix: failed to find node %s", nodeName)
@@ -872,6 +874,15 @@ func (oc *DefaultNetworkController) extSwitchPrefix(nodeName string) (string, er
 		return "", errors.Wrapf(err, "extSwitchPrefix: failed to parse l3 gateway annotation for node %s", nodeName)
 	}
 
+	if gwIP := net.ParseIP(gw); gwIP != nil {
+		for name, bridge := range l3GatewayConfig.Bridges {
+			for _, bridgeIP := range bridge.IPAddresses {
+				if bridgeIP.Contains(gwIP) {
+					return util.GetGatewayBridgeSwitchPrefix(name), nil
+				}
+			}
+		}
+	}
 	if l3GatewayConfig.EgressGWInterfaceID != "" {
 		return types.EgressGWSwitchPrefix, nil
 	}
@@ -939,13 +950,12 @@ func (oc *DefaultNetworkController) cleanExGwECMPRoutes() {
 			// prefix will signify secondary exgw bridge, or empty if normal setup
 			// have to determine if a node changed while master was down and if the route swapped from
 			// the default bridge to a new secondary bridge (or vice versa)
-			prefix, err := oc.extSwitchPrefix(node)
+			prefix, err := oc.extSwitchPrefix(node, ovnRoute.nextHop)
 			if err != nil {
 				// we shouldn't continue in this case, because we cant be sure this is a route we want to remove
 				klog.Errorf("Cannot sync exgw route: %+v, unable to determine exgw switch prefix: %v",
 					ovnRoute, err)
-			} else if (prefix != "" && !strings.Contains(ovnRoute.outport, prefix)) ||
-				(prefix == "" && strings.Contains(ovnRoute.outport, types.EgressGWSwitchPrefix)) {
+			} else if ovnRoute.outport != prefix+types.GWRouterToExtSwitchPrefix+ovnRoute.router {
 				continue
 			}
 
@@ -978,7 +988,7 @@ func (oc *DefaultNetworkController) cleanExGwECMPRoutes() {
 				// prefix will signify secondary exgw bridge, or empty if normal setup
 				// have to determine if a node changed while master was down and if the route swapped from
 				// the default bridge to a new secondary bridge (or vice versa)
-				prefix, err := oc.extSwitchPrefix(node)
+				prefix, err := oc.extSwitchPrefix(node, ovnRoute.nextHop)
 				if err != nil {
 					// we shouldn't continue in this case, because we cant be sure this is a route we want to remove
 					klog.Errorf("Cannot sync exgw bfd: %+v, unable to determine exgw switch prefix: %v",
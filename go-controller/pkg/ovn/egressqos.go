@@ -249,6 +249,12 @@ func (oc *DefaultNetworkController) runEgressQoSController(threadiness int, stop
 		klog.Errorf("Failed to delete stale EgressQoS entries: %v", err)
 	}
 
+	if err = oc.startEgressQoSInitialSync(); err != nil {
+		klog.Errorf("Failed to list the EgressQoSes and nodes to sync: %v", err)
+		oc.egressQoSInitialSync.Start(nil)
+		oc.egressQoSNodeInitialSync.Start(nil)
+	}
+
 	wg := &sync.WaitGroup{}
 	for i := 0; i < threadiness; i++ {
 		wg.Add(1)
@@ -291,6 +297,36 @@ func (oc *DefaultNetworkController) runEgressQoSController(threadiness int, stop
 	wg.Wait()
 }
 
+// startEgressQoSInitialSync sets the EgressQoSes and nodes to process before
+// the initial sync of the EgressQoS controller is done
+func (oc *DefaultNetworkController) startEgressQoSInitialSync() error {
+	egressQoSes, err := oc.egressQoSLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	egressQoSKeys := make([]string, 0, len(egressQoSes))
+	for _, eq := range egressQoSes {
+		egressQoSKeys = append(egressQoSKeys, eq.Namespace+"/"+eq.Name)
+	}
+	nodes, err := oc.egressQoSNodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	nodeKeys := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeKeys = append(nodeKeys, node.Name)
+	}
+	oc.egressQoSInitialSync.Start(egressQoSKeys)
+	oc.egressQoSNodeInitialSync.Start(nodeKeys)
+	return nil
+}
+
+// egressQoSHasSynced returns whether the EgressQoSes and nodes held when the
+// EgressQoS controller started were processed
+func (oc *DefaultNetworkController) egressQoSHasSynced() bool {
+	return oc.egressQoSInitialSync.HasSynced() && oc.egressQoSNodeInitialSync.HasSynced()
+}
+
 // onEgressQoSAdd queues the EgressQoS for processing.
 func (oc *DefaultNetworkController) onEgressQoSAdd(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
@@ -346,6 +382,7 @@ func (oc *DefaultNetworkController) processNextEgressQoSWorkItem(wg *sync.WaitGr
 	err := oc.syncEgressQoS(key.(string))
 	if err == nil {
 		oc.egressQoSQueue.Forget(key)
+		oc.egressQoSInitialSync.Done(key.(string))
 		return true
 	}
 
@@ -357,6 +394,7 @@ func (oc *DefaultNetworkController) processNextEgressQoSWorkItem(wg *sync.WaitGr
 	}
 
 	oc.egressQoSQueue.Forget(key)
+	oc.egressQoSInitialSync.Done(key.(string))
 	return true
 }
 
@@ -884,6 +922,7 @@ func (oc *DefaultNetworkController) processNextEgressQoSNodeWorkItem(wg *sync.Wa
 	err := oc.syncEgressQoSNode(key.(string))
 	if err == nil {
 		oc.egressQoSNodeQueue.Forget(key)
+		oc.egressQoSNodeInitialSync.Done(key.(string))
 		return true
 	}
 
@@ -895,6 +934,7 @@ func (oc *DefaultNetworkController) processNextEgressQoSNodeWorkItem(wg *sync.Wa
 	}
 
 	oc.egressQoSNodeQueue.Forget(key)
+	oc.egressQoSNodeInitialSync.Done(key.(string))
 	return true
 }
 
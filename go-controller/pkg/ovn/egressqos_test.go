@@ -414,6 +414,66 @@ var _ = ginkgo.Describe("OVN EgressQoS Operations", func() {
 			fmt.Sprintf("(ip6.dst == 2001:0db8:85a3:0000:0000:8a2e:0370:7335/128) && (ip4.src == $%s || ip6.src == $%s)", asv4, asv6)),
 	)
 
+	ginkgo.It("should report the EgressQoSes held on startup as synced once their QoS rules are created", func() {
+		app.Action = func(ctx *cli.Context) error {
+			namespaceT := *newNamespace("namespace1")
+
+			node1Switch := &nbdb.LogicalSwitch{
+				UUID: "node1-UUID",
+				Name: node1Name,
+			}
+
+			dbSetup := libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					node1Switch,
+				},
+			}
+
+			fakeOVN.startWithDBSetup(dbSetup,
+				&v1.NamespaceList{
+					Items: []v1.Namespace{
+						namespaceT,
+					},
+				},
+				&v1.NodeList{
+					Items: []v1.Node{
+						{ObjectMeta: metav1.ObjectMeta{Name: node1Name}},
+					},
+				},
+			)
+
+			eq := newEgressQoSObject("default", namespaceT.Name, []egressqosapi.EgressQoSRule{
+				{
+					DstCIDR: pointer.String("1.2.3.4/32"),
+					DSCP:    50,
+				},
+			})
+			_, err := fakeOVN.fakeClient.EgressQoSClient.K8sV1().EgressQoSes(namespaceT.Name).Create(context.TODO(), eq, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Expect(fakeOVN.controller.egressQoSHasSynced()).To(gomega.BeFalse())
+			fakeOVN.InitAndRunEgressQoSController()
+			gomega.Eventually(fakeOVN.controller.egressQoSHasSynced).Should(gomega.BeTrue())
+
+			// the QoS rules of the EgressQoS are created once it is synced
+			qos1 := &nbdb.QoS{
+				Direction:   nbdb.QoSDirectionToLport,
+				Match:       fmt.Sprintf("(ip4.dst == 1.2.3.4/32) && ip4.src == $%s", asv4),
+				Priority:    EgressQoSFlowStartPriority,
+				Action:      map[string]int{nbdb.QoSActionDSCP: 50},
+				ExternalIDs: map[string]string{"EgressQoS": namespaceT.Name},
+				UUID:        "qos1-UUID",
+			}
+			node1Switch.QOSRules = []string{qos1.UUID}
+			gomega.Expect(fakeOVN.nbClient).To(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{qos1, node1Switch}))
+
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	ginkgo.It("should respond to node events correctly", func() {
 		app.Action = func(ctx *cli.Context) error {
 			namespaceT := *newNamespace("namespace1")
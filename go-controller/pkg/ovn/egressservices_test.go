@@ -410,6 +410,92 @@ var _ = ginkgo.Describe("OVN Egress Service Operations", func() {
 		})
 	})
 
+	ginkgo.Context("on startup sync", func() {
+		ginkgo.It("should report the egress services held on startup as synced once their policies are created", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespaceT := *newNamespace("testns")
+				config.IPv6Mode = true
+				node1 := nodeFor(node1Name, node1IPv4, node1IPv6, node1IPv4Subnet, node1IPv6Subnet)
+				node1.Labels = map[string]string{"house": "Gryffindor"}
+
+				clusterRouter := &nbdb.LogicalRouter{
+					Name: types.OVNClusterRouter,
+					UUID: types.OVNClusterRouter + "-UUID",
+				}
+
+				dbSetup := libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						clusterRouter,
+					},
+				}
+
+				svc1 := svcFor("testns", "svc1", map[string]string{
+					util.EgressSVCAnnotation: "{\"nodeSelector\":{\"matchLabels\":{\"house\": \"Gryffindor\"}}}",
+				})
+
+				v4EpSlice := discovery.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "svc1-ipv4-epslice",
+						Namespace: "testns",
+						Labels: map[string]string{
+							discovery.LabelServiceName: "svc1",
+						},
+					},
+					AddressType: discovery.AddressTypeIPv4,
+					Endpoints: []discovery.Endpoint{
+						{
+							Addresses: []string{"10.128.1.5"},
+						},
+					},
+				}
+
+				fakeOVN.startWithDBSetup(dbSetup,
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							namespaceT,
+						},
+					},
+					&v1.NodeList{
+						Items: []v1.Node{
+							*node1,
+						},
+					},
+					&v1.ServiceList{
+						Items: []v1.Service{
+							svc1,
+						},
+					},
+					&discovery.EndpointSliceList{
+						Items: []discovery.EndpointSlice{
+							v4EpSlice,
+						},
+					},
+				)
+
+				gomega.Expect(fakeOVN.controller.egressSvcController.HasSynced()).To(gomega.BeFalse())
+				fakeOVN.InitAndRunEgressSVCController()
+				gomega.Eventually(fakeOVN.controller.egressSvcController.HasSynced).Should(gomega.BeTrue())
+
+				// the policies of the service are created once it is synced
+				v4lrp1 := lrpForEgressSvcEndpoint("v4lrp1-UUID", "testns/svc1", "10.128.1.5", "10.128.1.2")
+				clusterRouter.Policies = []string{"v4lrp1-UUID"}
+				expectedDatabaseState := []libovsdbtest.TestData{
+					clusterRouter,
+					v4lrp1,
+				}
+				for _, lrp := range getDefaultNoReroutePolicies(controllerName) {
+					expectedDatabaseState = append(expectedDatabaseState, lrp)
+					clusterRouter.Policies = append(clusterRouter.Policies, lrp.UUID)
+				}
+				gomega.Expect(fakeOVN.nbClient).To(libovsdbtest.HaveData(expectedDatabaseState))
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("on services changes", func() {
 		ginkgo.It("should create/update/delete service host annotations", func() {
 			app.Action = func(ctx *cli.Context) error {
@@ -0,0 +1,212 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/pkg/errors"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilnet "k8s.io/utils/net"
+)
+
+func getGatewayBridgeAddrSetDbIDs(nodeName, bridgeName, controller string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetGatewayBridge, controller,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey:    nodeName,
+			libovsdbops.GatewayBridgeKey: bridgeName,
+		})
+}
+
+// syncGatewayBridges connects the gateway router of the node to the external
+// switches of its additional named gateway bridges, and routes the destinations
+// of each bridge through it. The pod traffic to these destinations is SNATed to
+// the IP of the bridge, the SNAT being restricted to the destinations with
+// allowed_ext_ips. The bridges that are no longer configured on the node are
+// removed.
+func (oc *DefaultNetworkController) syncGatewayBridges(nodeName string, hostSubnets []*net.IPNet,
+	l3GatewayConfig *util.L3GatewayConfig) error {
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+
+	names := make([]string, 0, len(l3GatewayConfig.Bridges))
+	for name := range l3GatewayConfig.Bridges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	desiredSwitches := sets.New[string]()
+	desiredRoutes := sets.New[string]()
+	desiredNATs := sets.New[string]()
+	desiredAddrSets := sets.New[string]()
+	for _, name := range names {
+		bridge := l3GatewayConfig.Bridges[name]
+		prefix := util.GetGatewayBridgeSwitchPrefix(name)
+		if err := oc.addExternalSwitch(prefix,
+			bridge.InterfaceID,
+			nodeName,
+			gatewayRouter,
+			bridge.MACAddress.String(),
+			util.GetGatewayBridgePhysicalNetworkName(name),
+			bridge.IPAddresses,
+			bridge.VLANID); err != nil {
+			return err
+		}
+		desiredSwitches.Insert(externalSwitchName(prefix, nodeName))
+
+		externalRouterPort := prefix + types.GWRouterToExtSwitchPrefix + gatewayRouter
+		for _, route := range bridge.Routes {
+			lrsr := nbdb.LogicalRouterStaticRoute{
+				IPPrefix:   route.Destination.String(),
+				Nexthop:    route.NextHop.String(),
+				OutputPort: &externalRouterPort,
+			}
+			p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+				return item.OutputPort != nil && *item.OutputPort == *lrsr.OutputPort && item.IPPrefix == lrsr.IPPrefix &&
+					libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
+			}
+			err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, gatewayRouter, &lrsr,
+				p, &lrsr.Nexthop)
+			if err != nil {
+				return fmt.Errorf("error creating static route %+v in GR %s: %v", lrsr, gatewayRouter, err)
+			}
+			desiredRoutes.Insert(externalRouterPort + "/" + lrsr.IPPrefix)
+		}
+
+		if len(bridge.Routes) == 0 {
+			continue
+		}
+		v4AddrSet, v6AddrSet := addressset.GetDbObjsForAS(getGatewayBridgeAddrSetDbIDs(nodeName, name, oc.controllerName), nil)
+		for _, hostSubnet := range hostSubnets {
+			isIPv6 := utilnet.IsIPv6CIDR(hostSubnet)
+			addrSet := v4AddrSet
+			if isIPv6 {
+				addrSet = v6AddrSet
+			}
+			for _, route := range bridge.Routes {
+				if utilnet.IsIPv6CIDR(route.Destination) == isIPv6 {
+					addrSet.Addresses = append(addrSet.Addresses, route.Destination.String())
+				}
+			}
+			if len(addrSet.Addresses) == 0 {
+				continue
+			}
+			bridgeIP, err := util.MatchFirstIPNetFamily(isIPv6, bridge.IPAddresses)
+			if err != nil {
+				return fmt.Errorf("failed to SNAT the traffic of gateway bridge %s of node %s: %v", name, nodeName, err)
+			}
+			// create the address set and the SNAT referencing it in the same
+			// transaction, as the address set may only have a named UUID
+			ops, err := libovsdbops.CreateOrUpdateAddressSetsOps(oc.nbClient, nil, addrSet)
+			if err != nil {
+				return fmt.Errorf("failed to create the address set of the destinations of gateway bridge %s of node %s: %v",
+					name, nodeName, err)
+			}
+			nat := libovsdbops.BuildSNAT(&bridgeIP.IP, hostSubnet, "", map[string]string{libovsdbops.GatewayBridgeKey.String(): name})
+			nat.AllowedExtIPs = &addrSet.UUID
+			ops, err = libovsdbops.CreateOrUpdateNATsOps(oc.nbClient, ops, &logicalRouter, nat)
+			if err != nil {
+				return fmt.Errorf("failed to update SNAT rule of gateway bridge %s on router %s: %v", name, gatewayRouter, err)
+			}
+			if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+				return fmt.Errorf("failed to update SNAT rule of gateway bridge %s on router %s: %v", name, gatewayRouter, err)
+			}
+			desiredAddrSets.Insert(addrSet.Name)
+			desiredNATs.Insert(gatewayBridgeSNATKey(name, nat))
+		}
+	}
+
+	// remove the routes, SNATs and switches of the bridges, or of the routes,
+	// that were removed from the node. The source IP routes of the external
+	// gateways through the bridges are left to the external gateway sync.
+	routePredicate := func(item *nbdb.LogicalRouterStaticRoute) bool {
+		return item.OutputPort != nil && strings.HasPrefix(*item.OutputPort, types.GatewayBridgeSwitchPrefix) &&
+			libovsdbops.PolicyEqualPredicate(item.Policy, nil) && !desiredRoutes.Has(*item.OutputPort+"/"+item.IPPrefix)
+	}
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(oc.nbClient, gatewayRouter, routePredicate); err != nil {
+		return fmt.Errorf("failed to delete stale gateway bridge routes of router %s: %v", gatewayRouter, err)
+	}
+
+	routerNATs, err := libovsdbops.GetRouterNATs(oc.nbClient, &logicalRouter)
+	if err != nil {
+		return fmt.Errorf("failed to get the NATs of router %s: %v", gatewayRouter, err)
+	}
+	staleNATs := []*nbdb.NAT{}
+	for _, nat := range routerNATs {
+		if name, ok := nat.ExternalIDs[libovsdbops.GatewayBridgeKey.String()]; ok && !desiredNATs.Has(gatewayBridgeSNATKey(name, nat)) {
+			staleNATs = append(staleNATs, nat)
+		}
+	}
+	if len(staleNATs) > 0 {
+		if err := libovsdbops.DeleteNATs(oc.nbClient, &logicalRouter, staleNATs...); err != nil {
+			return fmt.Errorf("failed to delete stale gateway bridge SNATs of router %s: %v", gatewayRouter, err)
+		}
+	}
+
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetGatewayBridge, oc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: nodeName})
+	asPredicate := libovsdbops.GetPredicate[*nbdb.AddressSet](predicateIDs, func(item *nbdb.AddressSet) bool {
+		return !desiredAddrSets.Has(item.Name)
+	})
+	if err := libovsdbops.DeleteAddressSetsWithPredicate(oc.nbClient, asPredicate); err != nil {
+		return fmt.Errorf("failed to delete stale gateway bridge address sets of node %s: %v", nodeName, err)
+	}
+
+	return oc.deleteGatewayBridgeSwitches(nodeName, desiredSwitches)
+}
+
+// cleanupGatewayBridges removes the external switches and the address sets of
+// the additional gateway bridges of a deleted node
+func (oc *DefaultNetworkController) cleanupGatewayBridges(nodeName string) error {
+	if err := oc.deleteGatewayBridgeSwitches(nodeName, nil); err != nil {
+		return err
+	}
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetGatewayBridge, oc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: nodeName})
+	asPredicate := libovsdbops.GetPredicate[*nbdb.AddressSet](predicateIDs, nil)
+	if err := libovsdbops.DeleteAddressSetsWithPredicate(oc.nbClient, asPredicate); err != nil {
+		return fmt.Errorf("failed to delete the gateway bridge address sets of node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+func gatewayBridgeSNATKey(bridgeName string, nat *nbdb.NAT) string {
+	return bridgeName + "/" + nat.ExternalIP + "/" + nat.LogicalIP
+}
+
+// deleteGatewayBridgeSwitches deletes the external switches of the additional
+// gateway bridges of the node that are not in keep, and their gateway router
+// ports if the gateway router still exists
+func (oc *DefaultNetworkController) deleteGatewayBridgeSwitches(nodeName string, keep sets.Set[string]) error {
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	suffix := "-" + types.ExternalSwitchPrefix + nodeName
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(oc.nbClient, func(item *nbdb.LogicalSwitch) bool {
+		return strings.HasPrefix(item.Name, types.GatewayBridgeSwitchPrefix) && strings.HasSuffix(item.Name, suffix) &&
+			!keep.Has(item.Name)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find the gateway bridge switches of node %s: %v", nodeName, err)
+	}
+	for _, sw := range switches {
+		prefix := strings.TrimSuffix(sw.Name, types.ExternalSwitchPrefix+nodeName)
+		logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+		logicalRouterPort := nbdb.LogicalRouterPort{Name: prefix + types.GWRouterToExtSwitchPrefix + gatewayRouter}
+		err := libovsdbops.DeleteLogicalRouterPorts(oc.nbClient, &logicalRouter, &logicalRouterPort)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete port %s on router %s: %v", logicalRouterPort.Name, gatewayRouter, err)
+		}
+		if err := libovsdbops.DeleteLogicalSwitch(oc.nbClient, sw.Name); err != nil {
+			return fmt.Errorf("failed to delete external switch %s: %v", sw.Name, err)
+		}
+	}
+	return nil
+}
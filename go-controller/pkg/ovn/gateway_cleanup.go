@@ -74,7 +74,11 @@ func (oc *DefaultNetworkController) gatewayCleanup(nodeName string) error {
 		return fmt.Errorf("failed to delete external switch %s: %v", exGWexternalSwitch, err)
 	}
 
-	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes. It will be a no-op for any other mode.
+	if err := oc.cleanupGatewayBridges(nodeName); err != nil {
+		return err
+	}
+
+	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes and the NoOverlayPolicy.
 	oc.delPbrAndNatRules(nodeName, nil)
 	return nil
 }
@@ -219,7 +223,7 @@ func (oc *DefaultNetworkController) multiJoinSwitchGatewayCleanup(nodeName strin
 		return fmt.Errorf("failed to delete external switch %s: %v", extSwitchName, err)
 	}
 
-	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes. It will be a no-op for any other mode.
+	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes and the NoOverlayPolicy.
 	oc.delPbrAndNatRules(nodeName, nil)
 	return nil
 }
@@ -228,7 +232,7 @@ func (oc *DefaultNetworkController) multiJoinSwitchGatewayCleanup(nodeName strin
 // Specify priorities to only delete specific types
 func (oc *DefaultNetworkController) removeLRPolicies(nodeName string, priorities []string) {
 	if len(priorities) == 0 {
-		priorities = []string{types.NodeSubnetPolicyPriority}
+		priorities = []string{types.NodeSubnetPolicyPriority, types.NoOverlayPolicyPriority}
 	}
 
 	intPriorities := sets.Set[int]{}
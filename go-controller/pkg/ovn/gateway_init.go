@@ -261,6 +261,10 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 		}
 	}
 
+	if err := oc.syncGatewayBridges(nodeName, hostSubnets, l3GatewayConfig); err != nil {
+		return err
+	}
+
 	externalRouterPort := types.GWRouterToExtSwitchPrefix + gatewayRouter
 
 	nextHops := l3GatewayConfig.NextHops
@@ -549,6 +553,39 @@ func (oc *DefaultNetworkController) addPolicyBasedRoutes(nodeName, mgmtPortIP st
 	return nil
 }
 
+// addNoOverlayPolicyBasedRoutes reroutes the traffic of the pods of the node
+// headed to the pods of the other nodes to the management port of the node,
+// for the host to route it through the node network instead of the overlay
+func (oc *DefaultNetworkController) addNoOverlayPolicyBasedRoutes(nodeName string, hostSubnet *net.IPNet, mgmtPortIP net.IP) error {
+	var l3Prefix string
+	if utilnet.IsIPv6CIDR(hostSubnet) {
+		l3Prefix = "ip6"
+	} else {
+		l3Prefix = "ip4"
+	}
+
+	var clusterSubnets []string
+	for _, clusterSubnet := range config.Default.ClusterSubnets {
+		if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) == utilnet.IsIPv6CIDR(hostSubnet) {
+			clusterSubnets = append(clusterSubnets, clusterSubnet.CIDR.String())
+		}
+	}
+	if len(clusterSubnets) == 0 {
+		return nil
+	}
+
+	// the traffic of the host, coming from the management port, is already
+	// routed by the host
+	matchStr := fmt.Sprintf(`inport == "%s%s" && %s.src != %s && %s.dst == {%s} && %s.dst != %s /* %s */`,
+		types.RouterToSwitchPrefix, nodeName, l3Prefix, mgmtPortIP, l3Prefix, strings.Join(clusterSubnets, ", "),
+		l3Prefix, hostSubnet, nodeName)
+	if err := oc.syncPolicyBasedRoutes(nodeName, sets.New(matchStr), types.NoOverlayPolicyPriority, mgmtPortIP.String()); err != nil {
+		return fmt.Errorf("unable to sync no-overlay policies, err: %v", err)
+	}
+
+	return nil
+}
+
 // This function syncs logical router policies given various criteria
 // This function compares the following ovn-nbctl output:
 
@@ -571,7 +608,7 @@ func (oc *DefaultNetworkController) addPolicyBasedRoutes(nodeName, mgmtPortIP st
 // 		0f5af297-74c8-4551-b10e-afe3b74bb000,ip4.src == 10.244.0.2  && ip4.dst != 10.244.0.0/16 /* inter-ovn-worker2 */,169.254.0.1
 
 // The function checks to see if the mgmtPort IP has changed, or if match criteria has changed
-// and removes stale policies for a node for the NodeSubnetPolicy in SGW.
+// and removes stale policies for a node for the NodeSubnetPolicy in SGW and the NoOverlayPolicy.
 // TODO: Fix the MGMTPortPolicy's and InterNodePolicy's ip4.src fields if the mgmtPort IP has changed in LGW.
 // It also adds new policies for a node at a specific priority.
 // This is ugly (since the node is encoded as a comment in the match),
@@ -584,7 +621,7 @@ func (oc *DefaultNetworkController) syncPolicyBasedRoutes(nodeName string, match
 	// create a map to track matches found
 	matchTracker := sets.New(sets.List(matches)...)
 
-	if priority == types.NodeSubnetPolicyPriority {
+	if priority == types.NodeSubnetPolicyPriority || priority == types.NoOverlayPolicyPriority {
 		policies, err := oc.findPolicyBasedRoutes(priority)
 		if err != nil {
 			return fmt.Errorf("unable to list policies, err: %v", err)
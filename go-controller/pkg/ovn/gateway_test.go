@@ -13,7 +13,9 @@ import (
 	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
@@ -1067,6 +1069,121 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			expectedDatabaseState = append(expectedDatabaseState, ignoreRoute4)
 			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 		})
+
+		ginkgo.It("connects and removes the additional gateway bridges of a node", func() {
+			gr := types.GWRouterPrefix + nodeName
+			gatewayRouter := &nbdb.LogicalRouter{
+				UUID: gr + "-UUID",
+				Name: gr,
+			}
+			fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					gatewayRouter,
+				},
+			})
+
+			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
+			vlanID := uint(100)
+			l3GatewayConfig := &util.L3GatewayConfig{
+				Mode: config.GatewayModeShared,
+				Bridges: map[string]*util.L3GatewayBridgeConfig{
+					"storage": {
+						InterfaceID: "breth1_" + nodeName,
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: ovntest.MustParseIPNets("192.168.10.5/24"),
+						VLANID:      &vlanID,
+						Routes: []config.GatewayBridgeRoute{
+							{
+								Destination: ovntest.MustParseIPNet("172.16.0.0/16"),
+								NextHop:     ovntest.MustParseIP("192.168.10.1"),
+							},
+						},
+					},
+				},
+			}
+
+			err := fakeOvn.controller.syncGatewayBridges(nodeName, hostSubnets, l3GatewayConfig)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			prefix := util.GetGatewayBridgeSwitchPrefix("storage")
+			externalSwitch := prefix + types.ExternalSwitchPrefix + nodeName
+			externalRouterPort := prefix + types.GWRouterToExtSwitchPrefix + gr
+			externalSwitchPortToRouter := prefix + types.EXTSwitchToGWRouterPrefix + gr
+			addrSet, _ := addressset.GetDbObjsForAS(getGatewayBridgeAddrSetDbIDs(nodeName, "storage",
+				DefaultNetworkControllerName), nil)
+			addrSet.UUID = "gateway-bridge-as-UUID"
+			addrSet.Addresses = []string{"172.16.0.0/16"}
+			intVlanID := int(vlanID)
+			localnetPort := &nbdb.LogicalSwitchPort{
+				UUID:      "breth1_" + nodeName + "-UUID",
+				Name:      "breth1_" + nodeName,
+				Addresses: []string{"unknown"},
+				Type:      "localnet",
+				Options: map[string]string{
+					"network_name": types.PhysicalNetworkGatewayBridgePrefix + "storage",
+				},
+				TagRequest: &intVlanID,
+			}
+			routerSwitchPort := &nbdb.LogicalSwitchPort{
+				UUID: externalSwitchPortToRouter + "-UUID",
+				Name: externalSwitchPortToRouter,
+				Type: "router",
+				Options: map[string]string{
+					"router-port": externalRouterPort,
+				},
+				Addresses: []string{"11:22:33:44:55:77"},
+			}
+			expectedDatabaseState := []libovsdbtest.TestData{
+				&nbdb.LogicalRouter{
+					UUID:         gr + "-UUID",
+					Name:         gr,
+					Ports:        []string{externalRouterPort + "-UUID"},
+					StaticRoutes: []string{"gateway-bridge-route-UUID"},
+					Nat:          []string{"gateway-bridge-nat-UUID"},
+				},
+				&nbdb.LogicalRouterPort{
+					UUID: externalRouterPort + "-UUID",
+					Name: externalRouterPort,
+					MAC:  "11:22:33:44:55:77",
+					ExternalIDs: map[string]string{
+						"gateway-physical-ip": "yes",
+					},
+					Networks: []string{"192.168.10.5/24"},
+				},
+				&nbdb.LogicalRouterStaticRoute{
+					UUID:       "gateway-bridge-route-UUID",
+					IPPrefix:   "172.16.0.0/16",
+					Nexthop:    "192.168.10.1",
+					OutputPort: &externalRouterPort,
+				},
+				addrSet,
+				&nbdb.NAT{
+					UUID:          "gateway-bridge-nat-UUID",
+					ExternalIP:    "192.168.10.5",
+					LogicalIP:     "10.130.0.0/23",
+					Options:       map[string]string{"stateless": "false"},
+					Type:          nbdb.NATTypeSNAT,
+					ExternalIDs:   map[string]string{libovsdbops.GatewayBridgeKey.String(): "storage"},
+					AllowedExtIPs: &addrSet.UUID,
+				},
+				localnetPort,
+				routerSwitchPort,
+				&nbdb.LogicalSwitch{
+					UUID:  externalSwitch + "-UUID",
+					Name:  externalSwitch,
+					Ports: []string{"breth1_" + nodeName + "-UUID", externalSwitchPortToRouter + "-UUID"},
+				},
+			}
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+			ginkgo.By("removing the gateway bridge from the node")
+			l3GatewayConfig.Bridges = nil
+			err = fakeOvn.controller.syncGatewayBridges(nodeName, hostSubnets, l3GatewayConfig)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			// since test server doesn't garbage-collect de-referenced switch ports, they will stay in the db
+			expectedDatabaseState = []libovsdbtest.TestData{gatewayRouter, localnetPort, routerSwitchPort}
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+		})
 	})
 
 	ginkgo.Context("Gateway Create Operations Local Gateway Mode", func() {
@@ -35,6 +35,8 @@ type Interface interface {
 	ForEach(func(net.IP))
 	CIDR() net.IPNet
 	Has(ip net.IP) bool
+	Free() int
+	Used() int
 }
 
 var (
@@ -168,6 +168,26 @@ func (manager *LogicalSwitchManager) GetSwitchSubnets(switchName string) []*net.
 	return nil
 }
 
+// GetSwitchIPUsage returns the IP address usage of every host subnet of the given switch
+func (manager *LogicalSwitchManager) GetSwitchIPUsage(switchName string) []util.SubnetIPUsage {
+	manager.RLock()
+	defer manager.RUnlock()
+	lsi, ok := manager.cache[switchName]
+	if !ok {
+		return nil
+	}
+	usage := make([]util.SubnetIPUsage, 0, len(lsi.ipams))
+	for _, ipam := range lsi.ipams {
+		cidr := ipam.CIDR()
+		usage = append(usage, util.SubnetIPUsage{
+			Subnet:    cidr.String(),
+			Allocated: ipam.Used(),
+			Available: ipam.Free(),
+		})
+	}
+	return usage
+}
+
 // AllocateUntilFull used for unit testing only, allocates the rest of the switch subnet
 func (manager *LogicalSwitchManager) AllocateUntilFull(switchName string) error {
 	manager.RLock()
@@ -106,6 +106,36 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+		ginkgo.It("reports the IP usage of each subnet", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				testNode := testNodeSubnetData{
+					switchName: "testNode1",
+					subnets: []string{
+						"10.1.1.0/24",
+					},
+				}
+
+				err = lsManager.AddSwitch(testNode.switchName, "", ovntest.MustParseIPNets(testNode.subnets...))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = lsManager.AllocateNextIPs(testNode.switchName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// the gateway and management port IPs are reserved
+				usage := lsManager.GetSwitchIPUsage(testNode.switchName)
+				gomega.Expect(usage).To(gomega.HaveLen(1))
+				gomega.Expect(usage[0].Subnet).To(gomega.Equal("10.1.1.0/24"))
+				gomega.Expect(usage[0].Allocated).To(gomega.Equal(3))
+				gomega.Expect(usage[0].Available).To(gomega.Equal(251))
+
+				gomega.Expect(lsManager.GetSwitchIPUsage("unknown")).To(gomega.BeEmpty())
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 		ginkgo.It("creates IPAM for each subnet and reserves IPs correctly when HybridOverlay is enabled and address is passed", func() {
 			app.Action = func(ctx *cli.Context) error {
 				_, err := config.InitConfig(ctx, fexec, nil)
@@ -3,6 +3,7 @@ package ovn
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -269,6 +270,11 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 		if !utilnet.IsIPv6CIDR(hostSubnet) {
 			v4Subnet = hostSubnet
 		}
+		if config.Default.NoOverlay {
+			if err := oc.addNoOverlayPolicyBasedRoutes(node.Name, hostSubnet, mgmtIfAddr.IP); err != nil {
+				return err
+			}
+		}
 		if config.Gateway.Mode == config.GatewayModeLocal {
 			lrsr := nbdb.LogicalRouterStaticRoute{
 				Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
@@ -645,6 +651,23 @@ func (oc *DefaultNetworkController) syncNodes(kNodes []interface{}) error {
 		}
 	}
 
+	if !config.Default.NoOverlay {
+		// remove the policies of no-overlay if it was disabled
+		noOverlayPriority, _ := strconv.Atoi(types.NoOverlayPolicyPriority)
+		p := func(item *nbdb.LogicalRouterPolicy) bool {
+			return item.Priority == noOverlayPriority
+		}
+		policies, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(oc.nbClient, p)
+		if err != nil {
+			return fmt.Errorf("failed to find no-overlay policies: %v", err)
+		}
+		if len(policies) > 0 {
+			if err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, types.OVNClusterRouter, p); err != nil {
+				return fmt.Errorf("failed to delete no-overlay policies: %v", err)
+			}
+		}
+	}
+
 	if err := oc.syncChassis(nodes); err != nil {
 		return fmt.Errorf("failed to sync chassis: error: %v", err)
 	}
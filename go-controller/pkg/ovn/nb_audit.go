@@ -0,0 +1,473 @@
+package ovn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	kapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// dbOrphan is an NB or SB database object owned by the default network
+// controller whose Kubernetes owner no longer exists
+type dbOrphan struct {
+	table string
+	uuid  string
+	// ownerType is the owner type the object is created for, as in its
+	// libovsdbops.OwnerTypeKey external ID when it has one
+	ownerType string
+	// owner is the Kubernetes object the database object claims to belong to
+	owner *kapi.ObjectReference
+	// model is the database object itself, or its name for port groups
+	model interface{}
+}
+
+func (o dbOrphan) String() string {
+	owner := o.owner.Name
+	if o.owner.Namespace != "" {
+		owner = o.owner.Namespace + "/" + o.owner.Name
+	}
+	return fmt.Sprintf("%s %s (owner %s %s)", o.table, o.uuid, o.owner.Kind, owner)
+}
+
+// nbAuditedACLs are the ACL types whose owner is checked by the audit, along
+// with the kind of their owner
+var nbAuditedACLs = []struct {
+	idsType *libovsdbops.ObjectIDsType
+	kind    string
+}{
+	{libovsdbops.ACLNetworkPolicy, "NetworkPolicy"},
+	{libovsdbops.ACLNetpolNamespace, "Namespace"},
+	{libovsdbops.ACLMulticastNamespace, "Namespace"},
+	{libovsdbops.ACLNetpolNode, "Node"},
+	{libovsdbops.ACLEgressFirewall, "EgressFirewall"},
+}
+
+// nbAuditedAddressSets are the address set types whose owner is checked by
+// the audit, along with the kind of their owner. The pod selector, DNS, egress
+// IP and egress service address sets are shared or cluster wide and left out.
+var nbAuditedAddressSets = []struct {
+	idsType *libovsdbops.ObjectIDsType
+	kind    string
+}{
+	{libovsdbops.AddressSetNamespace, "Namespace"},
+	{libovsdbops.AddressSetEgressQoS, "Namespace"},
+	{libovsdbops.AddressSetHybridNodeRoute, "Node"},
+	{libovsdbops.AddressSetGatewayBridge, "Node"},
+}
+
+// runDBAudit adds a goroutine that periodically audits the NB and SB
+// databases for objects whose Kubernetes owner no longer exists. An object is
+// only reported, and deleted if configured, when it is found by two
+// consecutive audits so that objects whose owner is being deleted are left to
+// their handlers.
+func (oc *DefaultNetworkController) runDBAudit() {
+	interval := time.Duration(config.NBAudit.Interval) * time.Minute
+	klog.Infof("Starting NB and SB database audit every %v", interval)
+	oc.wg.Add(1)
+	go func() {
+		defer oc.wg.Done()
+		auditTicker := time.NewTicker(interval)
+		defer auditTicker.Stop()
+		suspects := sets.New[string]()
+		for {
+			select {
+			case <-auditTicker.C:
+				var err error
+				if suspects, err = oc.auditDBs(suspects); err != nil {
+					klog.Errorf("Failed to audit the NB and SB databases: %v", err)
+				}
+			case <-oc.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// auditDBs finds the orphaned NB and SB objects and reports, or deletes, the
+// ones that were already found by the previous audit, whose UUIDs are
+// suspects. It returns the UUIDs of the orphans found by this audit that are
+// left in the databases.
+func (oc *DefaultNetworkController) auditDBs(suspects sets.Set[string]) (sets.Set[string], error) {
+	nbOrphans, err := oc.findNBOrphans()
+	if err != nil {
+		return suspects, err
+	}
+	sbOrphans, err := oc.findSBOrphans()
+	if err != nil {
+		return suspects, err
+	}
+	found := sets.New[string]()
+	nbConfirmed, nbCounts := confirmOrphans(nbOrphans, suspects, found)
+	sbConfirmed, sbCounts := confirmOrphans(sbOrphans, suspects, found)
+	metrics.RecordNBOrphanedObjects(nbCounts)
+	metrics.RecordSBOrphanedObjects(sbCounts)
+	klog.Infof("Database audit found %d orphaned NB objects and %d orphaned SB objects", len(nbConfirmed), len(sbConfirmed))
+
+	for _, orphan := range nbConfirmed {
+		klog.Warningf("Orphaned NB object %s", orphan)
+		oc.recorder.Eventf(orphan.owner, kapi.EventTypeWarning, "OrphanedNBObject",
+			"%s %s belongs to this %s which no longer exists", orphan.table, orphan.uuid, orphan.owner.Kind)
+	}
+	for _, orphan := range sbConfirmed {
+		klog.Warningf("Orphaned SB object %s", orphan)
+		oc.recorder.Eventf(orphan.owner, kapi.EventTypeWarning, "OrphanedSBObject",
+			"%s %s belongs to this %s which no longer exists", orphan.table, orphan.uuid, orphan.owner.Kind)
+	}
+	if !config.NBAudit.DeleteOrphans {
+		return found, nil
+	}
+
+	if len(nbConfirmed) > 0 {
+		ops, err := oc.deleteNBOrphansOps(nbConfirmed)
+		if err != nil {
+			return found, fmt.Errorf("failed to build ops to delete orphaned NB objects: %v", err)
+		}
+		if _, err = libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+			return found, fmt.Errorf("failed to delete orphaned NB objects: %v", err)
+		}
+		for table, ownerCounts := range nbCounts {
+			for ownerType, count := range ownerCounts {
+				metrics.RecordNBOrphanedObjectsDeleted(table, ownerType, count)
+			}
+		}
+		metrics.RecordNBOrphanedObjects(nil)
+		for _, orphan := range nbConfirmed {
+			found.Delete(orphan.uuid)
+		}
+		klog.Infof("Database audit deleted %d orphaned NB objects", len(nbConfirmed))
+	}
+
+	if len(sbConfirmed) > 0 {
+		if err := oc.deleteSBOrphans(sbConfirmed); err != nil {
+			return found, fmt.Errorf("failed to delete orphaned SB objects: %v", err)
+		}
+		for table, ownerCounts := range sbCounts {
+			for ownerType, count := range ownerCounts {
+				metrics.RecordSBOrphanedObjectsDeleted(table, ownerType, count)
+			}
+		}
+		metrics.RecordSBOrphanedObjects(nil)
+		for _, orphan := range sbConfirmed {
+			found.Delete(orphan.uuid)
+		}
+		klog.Infof("Database audit deleted %d orphaned SB objects", len(sbConfirmed))
+	}
+	return found, nil
+}
+
+// confirmOrphans adds the UUIDs of the orphans to found and returns the ones
+// that are suspects, along with their number by table then owner type
+func confirmOrphans(orphans []dbOrphan, suspects, found sets.Set[string]) ([]dbOrphan, map[string]map[string]int) {
+	confirmed := []dbOrphan{}
+	counts := map[string]map[string]int{}
+	for _, orphan := range orphans {
+		found.Insert(orphan.uuid)
+		if !suspects.Has(orphan.uuid) {
+			continue
+		}
+		confirmed = append(confirmed, orphan)
+		if counts[orphan.table] == nil {
+			counts[orphan.table] = map[string]int{}
+		}
+		counts[orphan.table][orphan.ownerType]++
+	}
+	return confirmed, counts
+}
+
+// findNBOrphans returns the NB objects owned by the default network
+// controller whose Kubernetes owner no longer exists
+func (oc *DefaultNetworkController) findNBOrphans() ([]dbOrphan, error) {
+	orphans := []dbOrphan{}
+	// owners caches whether the owners checked so far are orphaned
+	owners := map[kapi.ObjectReference]bool{}
+	isOrphaned := func(owner *kapi.ObjectReference) bool {
+		orphaned, ok := owners[*owner]
+		if !ok {
+			orphaned = oc.isOrphanOwnerDeleted(owner)
+			owners[*owner] = orphaned
+		}
+		return orphaned
+	}
+
+	// port groups are found through the ACLs they are created with
+	orphanedPGs := map[string]dbOrphan{}
+	for _, audited := range nbAuditedACLs {
+		if audited.idsType == libovsdbops.ACLEgressFirewall && !config.OVNKubernetesFeature.EnableEgressFirewall {
+			continue
+		}
+		predicateIDs := libovsdbops.NewDbObjectIDs(audited.idsType, oc.controllerName, nil)
+		acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find ACLs: %v", err)
+		}
+		for _, acl := range acls {
+			ownerType := acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()]
+			owner, err := nbOwnerReference(audited.kind, acl.ExternalIDs[libovsdbops.ObjectNameKey.String()])
+			if err != nil {
+				klog.Warningf("Skipping audit of ACL %s: %v", acl.UUID, err)
+				continue
+			}
+			if !isOrphaned(owner) {
+				continue
+			}
+			orphans = append(orphans, dbOrphan{nbdb.ACLTable, acl.UUID, ownerType, owner, acl})
+			for _, pgName := range nbOwnedPortGroups(audited.idsType, owner) {
+				orphanedPGs[pgName] = dbOrphan{nbdb.PortGroupTable, "", ownerType, owner, pgName}
+			}
+		}
+	}
+	if len(orphanedPGs) > 0 {
+		pgs, err := libovsdbops.FindPortGroupsWithPredicate(oc.nbClient, func(pg *nbdb.PortGroup) bool {
+			_, ok := orphanedPGs[pg.Name]
+			return ok
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot find port groups: %v", err)
+		}
+		for _, pg := range pgs {
+			orphan := orphanedPGs[pg.Name]
+			orphan.uuid = pg.UUID
+			orphans = append(orphans, orphan)
+		}
+	}
+
+	for _, audited := range nbAuditedAddressSets {
+		predicateIDs := libovsdbops.NewDbObjectIDs(audited.idsType, oc.controllerName, nil)
+		addrSets, err := libovsdbops.FindAddressSetsWithPredicate(oc.nbClient,
+			libovsdbops.GetPredicate[*nbdb.AddressSet](predicateIDs, nil))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find address sets: %v", err)
+		}
+		for _, addrSet := range addrSets {
+			owner, err := nbOwnerReference(audited.kind, addrSet.ExternalIDs[libovsdbops.ObjectNameKey.String()])
+			if err != nil {
+				klog.Warningf("Skipping audit of address set %s: %v", addrSet.UUID, err)
+				continue
+			}
+			if isOrphaned(owner) {
+				orphans = append(orphans, dbOrphan{nbdb.AddressSetTable, addrSet.UUID,
+					addrSet.ExternalIDs[libovsdbops.OwnerTypeKey.String()], owner, addrSet})
+			}
+		}
+	}
+
+	if !config.OVNKubernetesFeature.EnableEgressIP {
+		return orphans, nil
+	}
+	// egress IP objects predate DbObjectIDs and only carry the EgressIP name
+	lrps, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(oc.nbClient, func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.Priority == types.EgressIPReroutePriority && item.ExternalIDs["name"] != ""
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot find egress IP logical router policies: %v", err)
+	}
+	for _, lrp := range lrps {
+		owner := &kapi.ObjectReference{Kind: "EgressIP", Name: lrp.ExternalIDs["name"]}
+		if isOrphaned(owner) {
+			orphans = append(orphans, dbOrphan{nbdb.LogicalRouterPolicyTable, lrp.UUID, owner.Kind, owner, lrp})
+		}
+	}
+	nats, err := libovsdbops.FindNATsWithPredicate(oc.nbClient, func(item *nbdb.NAT) bool {
+		return item.Type == nbdb.NATTypeSNAT && item.ExternalIDs["name"] != ""
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot find egress IP NATs: %v", err)
+	}
+	for _, nat := range nats {
+		owner := &kapi.ObjectReference{Kind: "EgressIP", Name: nat.ExternalIDs["name"]}
+		if isOrphaned(owner) {
+			orphans = append(orphans, dbOrphan{nbdb.NATTable, nat.UUID, owner.Kind, owner, nat})
+		}
+	}
+	return orphans, nil
+}
+
+// findSBOrphans returns the SB chassis whose node no longer exists. The
+// chassis are registered by ovn-controller and deleted along with their node,
+// one left behind keeps the other chassis tunneling to a node that is gone.
+func (oc *DefaultNetworkController) findSBOrphans() ([]dbOrphan, error) {
+	chassisList, err := libovsdbops.ListChassis(oc.sbClient)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find chassis: %v", err)
+	}
+	orphans := []dbOrphan{}
+	for _, chassis := range chassisList {
+		if chassis.Hostname == "" {
+			continue
+		}
+		owner := &kapi.ObjectReference{Kind: "Node", Name: chassis.Hostname}
+		if oc.isOrphanOwnerDeleted(owner) {
+			orphans = append(orphans, dbOrphan{sbdb.ChassisTable, chassis.UUID, owner.Kind, owner, chassis})
+		}
+	}
+	return orphans, nil
+}
+
+// nbOwnerReference returns the reference to the Kubernetes object of the
+// given kind an NB object belongs to, out of the object name external ID
+func nbOwnerReference(kind, objectName string) (*kapi.ObjectReference, error) {
+	if objectName == "" {
+		return nil, fmt.Errorf("missing %s external ID", libovsdbops.ObjectNameKey)
+	}
+	switch kind {
+	case "NetworkPolicy":
+		namespace, name, err := parseACLPolicyKey(objectName)
+		if err != nil {
+			return nil, err
+		}
+		return &kapi.ObjectReference{Kind: kind, Namespace: namespace, Name: name}, nil
+	case "EgressFirewall":
+		// there can only be one egress firewall per namespace, named "default"
+		return &kapi.ObjectReference{Kind: kind, Namespace: objectName, Name: "default"}, nil
+	default:
+		return &kapi.ObjectReference{Kind: kind, Name: objectName}, nil
+	}
+}
+
+// nbOwnedPortGroups returns the port groups created along with the ACLs of
+// the given type for the owner
+func nbOwnedPortGroups(aclType *libovsdbops.ObjectIDsType, owner *kapi.ObjectReference) []string {
+	switch aclType {
+	case libovsdbops.ACLNetworkPolicy:
+		pgName, _ := getNetworkPolicyPGName(owner.Namespace, owner.Name)
+		return []string{pgName}
+	case libovsdbops.ACLNetpolNamespace:
+		return []string{
+			defaultDenyPortGroupName(owner.Name, ingressDefaultDenySuffix),
+			defaultDenyPortGroupName(owner.Name, egressDefaultDenySuffix),
+		}
+	case libovsdbops.ACLMulticastNamespace:
+		return []string{getMulticastPortGroupName(owner.Name)}
+	}
+	return nil
+}
+
+// isOrphanOwnerDeleted returns whether the Kubernetes owner of database objects no
+// longer exists. Errors other than not found are logged and the owner is
+// considered to exist.
+func (oc *DefaultNetworkController) isOrphanOwnerDeleted(owner *kapi.ObjectReference) bool {
+	var err error
+	switch owner.Kind {
+	case "Namespace":
+		_, err = oc.watchFactory.GetNamespace(owner.Name)
+	case "Node":
+		_, err = oc.watchFactory.GetNode(owner.Name)
+	case "NetworkPolicy":
+		_, err = oc.watchFactory.GetNetworkPolicy(owner.Namespace, owner.Name)
+	case "EgressFirewall":
+		_, err = oc.watchFactory.GetEgressFirewall(owner.Namespace, owner.Name)
+	case "EgressIP":
+		_, err = oc.watchFactory.GetEgressIP(owner.Name)
+	default:
+		return false
+	}
+	if err != nil && !kerrors.IsNotFound(err) {
+		klog.Warningf("Failed to get %s %s/%s for the database audit: %v", owner.Kind, owner.Namespace, owner.Name, err)
+		return false
+	}
+	return kerrors.IsNotFound(err)
+}
+
+// deleteNBOrphansOps returns the ops to delete the orphaned NB objects
+func (oc *DefaultNetworkController) deleteNBOrphansOps(orphans []dbOrphan) ([]ovsdb.Operation, error) {
+	acls := []*nbdb.ACL{}
+	aclUUIDs := sets.New[string]()
+	pgNames := sets.New[string]()
+	addrSets := []*nbdb.AddressSet{}
+	lrpUUIDs := sets.New[string]()
+	natUUIDs := sets.New[string]()
+	for _, orphan := range orphans {
+		switch m := orphan.model.(type) {
+		case *nbdb.ACL:
+			acls = append(acls, m)
+			aclUUIDs.Insert(m.UUID)
+		case string:
+			pgNames.Insert(m)
+		case *nbdb.AddressSet:
+			addrSets = append(addrSets, m)
+		case *nbdb.LogicalRouterPolicy:
+			lrpUUIDs.Insert(m.UUID)
+		case *nbdb.NAT:
+			natUUIDs.Insert(m.UUID)
+		}
+	}
+
+	var ops []ovsdb.Operation
+	var err error
+	if len(acls) > 0 {
+		// ACLs are garbage collected once no port group or switch refers to
+		// them, the port groups being deleted take theirs along
+		pgs, err := libovsdbops.FindPortGroupsWithPredicate(oc.nbClient, func(pg *nbdb.PortGroup) bool {
+			return !pgNames.Has(pg.Name) && aclUUIDs.HasAny(pg.ACLs...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pg := range pgs {
+			ops, err = libovsdbops.DeleteACLsFromPortGroupOps(oc.nbClient, ops, pg.Name, acls...)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ops, err = libovsdbops.RemoveACLsFromLogicalSwitchesWithPredicateOps(oc.nbClient, ops,
+			func(sw *nbdb.LogicalSwitch) bool { return aclUUIDs.HasAny(sw.ACLs...) }, acls...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ops, err = libovsdbops.DeletePortGroupsOps(oc.nbClient, ops, sets.List(pgNames)...)
+	if err != nil {
+		return nil, err
+	}
+	ops, err = libovsdbops.DeleteAddressSetsOps(oc.nbClient, ops, addrSets...)
+	if err != nil {
+		return nil, err
+	}
+	if len(lrpUUIDs) > 0 {
+		ops, err = libovsdbops.DeleteLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, types.OVNClusterRouter,
+			func(item *nbdb.LogicalRouterPolicy) bool { return lrpUUIDs.Has(item.UUID) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(natUUIDs) > 0 {
+		ops, err = libovsdbops.DeleteNATsWithPredicateOps(oc.nbClient, ops,
+			func(item *nbdb.NAT) bool { return natUUIDs.Has(item.UUID) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ops, nil
+}
+
+// deleteSBOrphans deletes the orphaned SB chassis, along with their private
+// chassis and chassis template variables as syncChassis does
+func (oc *DefaultNetworkController) deleteSBOrphans(orphans []dbOrphan) error {
+	chassis := []*sbdb.Chassis{}
+	templateVars := []*nbdb.ChassisTemplateVar{}
+	for _, orphan := range orphans {
+		if m, ok := orphan.model.(*sbdb.Chassis); ok {
+			chassis = append(chassis, m)
+			templateVars = append(templateVars, &nbdb.ChassisTemplateVar{Chassis: m.Name})
+		}
+	}
+	if err := libovsdbops.DeleteChassis(oc.sbClient, chassis...); err != nil {
+		return err
+	}
+	if !oc.svcTemplateSupport {
+		return nil
+	}
+	return libovsdbops.DeleteChassisTemplateVar(oc.nbClient, templateVars...)
+}
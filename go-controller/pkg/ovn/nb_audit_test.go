@@ -0,0 +1,129 @@
+package ovn
+
+import (
+	"net"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var _ = ginkgo.Describe("OVN NB and SB database audit", func() {
+	const (
+		existingNamespace = "namespace1"
+		deletedNamespace  = "namespace2"
+		deletedEgressIP   = "egressip1"
+		existingNode      = "node1"
+		deletedNode       = "node2"
+	)
+	var (
+		app     *cli.App
+		fakeOvn *FakeOVN
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableEgressIP = true
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+
+		fakeOvn = NewFakeOVN(false)
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOvn.shutdown()
+	})
+
+	ginkgo.It("reports and deletes the objects whose owner no longer exists", func() {
+		app.Action = func(ctx *cli.Context) error {
+			existingData := getMulticastPolicyExpectedData(existingNamespace, nil)
+			existingV4AS, _ := buildNamespaceAddressSets(existingNamespace, []net.IP{net.ParseIP("10.128.1.3")})
+			existingData = append(existingData, existingV4AS)
+
+			orphanedData := getMulticastPolicyExpectedData(deletedNamespace, nil)
+			orphanedV4AS, _ := buildNamespaceAddressSets(deletedNamespace, []net.IP{net.ParseIP("10.128.2.3")})
+			orphanedLRP := &nbdb.LogicalRouterPolicy{
+				UUID:        "reroute-UUID",
+				Priority:    types.EgressIPReroutePriority,
+				Match:       "ip4.src == 10.128.2.3",
+				Action:      nbdb.LogicalRouterPolicyActionReroute,
+				Nexthops:    []string{"100.64.0.2"},
+				ExternalIDs: map[string]string{"name": deletedEgressIP},
+			}
+			orphanedNAT := &nbdb.NAT{
+				UUID:        "egressip-nat-UUID",
+				Type:        nbdb.NATTypeSNAT,
+				ExternalIP:  "192.168.126.101",
+				LogicalIP:   "10.128.2.3",
+				ExternalIDs: map[string]string{"name": deletedEgressIP},
+			}
+			orphanedData = append(orphanedData, orphanedV4AS, orphanedLRP, orphanedNAT)
+
+			clusterRouter := &nbdb.LogicalRouter{
+				UUID:     types.OVNClusterRouter + "-UUID",
+				Name:     types.OVNClusterRouter,
+				Policies: []string{orphanedLRP.UUID},
+			}
+			gatewayRouter := &nbdb.LogicalRouter{
+				UUID: types.GWRouterPrefix + "node1-UUID",
+				Name: types.GWRouterPrefix + "node1",
+				Nat:  []string{orphanedNAT.UUID},
+			}
+			initialData := append(append([]libovsdb.TestData{}, existingData...), orphanedData...)
+			initialData = append(initialData, clusterRouter, gatewayRouter)
+
+			existingChassis := &sbdb.Chassis{UUID: "chassis1-UUID", Name: "chassis1", Hostname: existingNode}
+			orphanedChassis := &sbdb.Chassis{UUID: "chassis2-UUID", Name: "chassis2", Hostname: deletedNode}
+			orphanedChassisPrivate := &sbdb.ChassisPrivate{UUID: "chassis2-private-UUID", Name: orphanedChassis.Name}
+
+			fakeOvn.startWithDBSetup(
+				libovsdb.TestSetup{
+					NBData: initialData,
+					SBData: []libovsdb.TestData{existingChassis, orphanedChassis, orphanedChassisPrivate},
+				},
+				&v1.NamespaceList{Items: []v1.Namespace{*newNamespace(existingNamespace)}},
+				&v1.NodeList{Items: []v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: existingNode}}}})
+
+			// orphans are only reported once found by two consecutive audits
+			suspects, err := fakeOvn.controller.auditDBs(sets.New[string]())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(suspects).To(gomega.HaveLen(len(orphanedData) + 1))
+			gomega.Consistently(fakeOvn.fakeRecorder.Events).ShouldNot(gomega.Receive())
+
+			_, err = fakeOvn.controller.auditDBs(suspects)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for range orphanedData {
+				gomega.Eventually(fakeOvn.fakeRecorder.Events).Should(gomega.Receive(gomega.ContainSubstring("OrphanedNBObject")))
+			}
+			gomega.Eventually(fakeOvn.fakeRecorder.Events).Should(gomega.Receive(gomega.ContainSubstring("OrphanedSBObject")))
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(initialData))
+			gomega.Eventually(fakeOvn.sbClient).Should(libovsdb.HaveData(existingChassis, orphanedChassis, orphanedChassisPrivate))
+
+			config.NBAudit.DeleteOrphans = true
+			_, err = fakeOvn.controller.auditDBs(suspects)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			clusterRouter.Policies = nil
+			gatewayRouter.Nat = nil
+			// since test server doesn't garbage-collect de-referenced acls, they will stay in the db
+			expectedData := append(existingData, orphanedData[0], orphanedData[1], clusterRouter, gatewayRouter)
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData))
+			gomega.Eventually(fakeOvn.sbClient).Should(libovsdb.HaveData(existingChassis))
+			return nil
+		}
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
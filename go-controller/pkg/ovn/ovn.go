@@ -23,6 +23,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -400,7 +401,9 @@ func shouldUpdateNode(node, oldNode *kapi.Node) (bool, error) {
 	return true, nil
 }
 
-func newServiceController(client clientset.Interface, nbClient libovsdbclient.Client, recorder record.EventRecorder) (*svccontroller.Controller, informers.SharedInformerFactory, error) {
+// newServiceInformerFactory creates the informer factory used by the service related controllers.
+// If withMirrored is false, EndpointSlices mirrored for secondary networks are filtered out.
+func newServiceInformerFactory(client clientset.Interface, withMirrored bool) informers.SharedInformerFactory {
 	// Create our own informers to start compartmentalizing the code
 	// filter server side the things we don't care about
 	noProxyName, err := labels.NewRequirement("service.kubernetes.io/service-proxy-name", selection.DoesNotExist, nil)
@@ -416,10 +419,23 @@ func newServiceController(client clientset.Interface, nbClient libovsdbclient.Cl
 	labelSelector := labels.NewSelector()
 	labelSelector = labelSelector.Add(*noProxyName, *noHeadlessEndpoints)
 
-	svcFactory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+	if !withMirrored {
+		notMirrored, err := labels.NewRequirement(discovery.LabelManagedBy, selection.NotEquals,
+			[]string{ovntypes.EndpointSliceMirrorControllerName})
+		if err != nil {
+			panic(err)
+		}
+		labelSelector = labelSelector.Add(*notMirrored)
+	}
+
+	return informers.NewSharedInformerFactoryWithOptions(client, 0,
 		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
 			options.LabelSelector = labelSelector.String()
 		}))
+}
+
+func newServiceController(client clientset.Interface, nbClient libovsdbclient.Client, recorder record.EventRecorder) (*svccontroller.Controller, informers.SharedInformerFactory, error) {
+	svcFactory := newServiceInformerFactory(client, false)
 
 	controller, err := svccontroller.NewController(
 		client,
@@ -297,6 +297,12 @@ func (oc *SecondaryLayer3NetworkController) Cleanup(netName string) error {
 		return fmt.Errorf("failed to get ops for deleting switches of network %s: %v", netName, err)
 	}
 
+	// delete the load balancers of the services exposed on the network
+	ops, err = deleteNetworkLoadBalancersOps(oc.nbClient, ops, netName)
+	if err != nil {
+		return fmt.Errorf("failed to get ops for deleting load balancers of network %s: %v", netName, err)
+	}
+
 	// now delete cluster router
 	ops, err = libovsdbops.DeleteLogicalRoutersWithPredicateOps(oc.nbClient, ops,
 		func(item *nbdb.LogicalRouter) bool {
@@ -325,6 +331,12 @@ func (oc *SecondaryLayer3NetworkController) Run() error {
 		return err
 	}
 
+	if err := oc.startServiceControllers(); err != nil {
+		return err
+	}
+
+	oc.startNetworkStatusReporter(oc.getNetworkStatus)
+
 	klog.Infof("Completing all the Watchers for network %s took %v", oc.GetNetworkName(), time.Since(start))
 
 	// controller is fully running and resource handlers have synced, update Topology version in OVN
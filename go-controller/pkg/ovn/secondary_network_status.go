@@ -0,0 +1,222 @@
+package ovn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// networkStatusSyncPeriod is the period at which the network status is built and, when it
+// has changed, reported on the NADs
+const networkStatusSyncPeriod = 30 * time.Second
+
+// startNetworkStatusReporter periodically reports the status built by getStatus in the
+// network status annotation of every NAD of this network it has changed for.
+func (bsnc *BaseSecondaryNetworkController) startNetworkStatusReporter(getStatus func() (*util.NetworkStatus, error)) {
+	if bsnc.kube == nil || bsnc.kube.NADClient == nil {
+		return
+	}
+	bsnc.networkStatusesLock.Lock()
+	bsnc.networkStatuses = map[string]string{}
+	bsnc.networkStatusesLock.Unlock()
+	bsnc.wg.Add(1)
+	go func() {
+		defer bsnc.wg.Done()
+		wait.Until(func() {
+			if err := bsnc.reportNetworkStatus(getStatus); err != nil {
+				klog.Warningf("Failed to report the status of network %s: %v", bsnc.GetNetworkName(), err)
+			}
+		}, networkStatusSyncPeriod, bsnc.stopChan)
+	}()
+}
+
+// reportNetworkStatus builds the network status and sets it on the NADs of this network it
+// has changed for.
+func (bsnc *BaseSecondaryNetworkController) reportNetworkStatus(getStatus func() (*util.NetworkStatus, error)) error {
+	status, err := getStatus()
+	if err != nil {
+		return err
+	}
+	value, err := util.MarshalNetworkStatus(status)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, nadName := range bsnc.GetNADs() {
+		bsnc.networkStatusesLock.Lock()
+		reported, ok := bsnc.networkStatuses[nadName]
+		epoch := bsnc.networkStatusesEpoch
+		bsnc.networkStatusesLock.Unlock()
+		if ok && reported == value {
+			continue
+		}
+		namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			// the status may have been reported before a restart, or the NAD
+			// re-added, check the NAD before patching it
+			annotations, err := bsnc.kube.GetAnnotationsOnNAD(namespace, name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get net-attach-def %s: %w", nadName, err))
+				continue
+			}
+			if annotations[util.OvnNetworkStatusAnnotation] == value {
+				bsnc.setReportedNetworkStatus(nadName, value, epoch)
+				continue
+			}
+		}
+		err = bsnc.kube.SetAnnotationsOnNAD(namespace, name, map[string]interface{}{
+			util.OvnNetworkStatusAnnotation: value,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to set the network status on net-attach-def %s: %w", nadName, err))
+			continue
+		}
+		bsnc.setReportedNetworkStatus(nadName, value, epoch)
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// setReportedNetworkStatus records the status reported on the given NAD, unless the NAD
+// was added to or deleted from the network since the given epoch.
+func (bsnc *BaseSecondaryNetworkController) setReportedNetworkStatus(nadName, value string, epoch uint64) {
+	bsnc.networkStatusesLock.Lock()
+	defer bsnc.networkStatusesLock.Unlock()
+	if bsnc.networkStatuses != nil && bsnc.networkStatusesEpoch == epoch {
+		bsnc.networkStatuses[nadName] = value
+	}
+}
+
+// AddNAD adds the given NAD to the network and forgets the status last reported on it, so
+// that the status replaces the error the NAD may have been annotated with meanwhile.
+func (bsnc *BaseSecondaryNetworkController) AddNAD(nadName string) {
+	bsnc.networkStatusesLock.Lock()
+	defer bsnc.networkStatusesLock.Unlock()
+	bsnc.NetInfo.AddNAD(nadName)
+	delete(bsnc.networkStatuses, nadName)
+	bsnc.networkStatusesEpoch++
+}
+
+// DeleteNAD deletes the given NAD from the network and forgets the status last reported on it
+func (bsnc *BaseSecondaryNetworkController) DeleteNAD(nadName string) {
+	bsnc.networkStatusesLock.Lock()
+	defer bsnc.networkStatusesLock.Unlock()
+	bsnc.NetInfo.DeleteNAD(nadName)
+	delete(bsnc.networkStatuses, nadName)
+	bsnc.networkStatusesEpoch++
+}
+
+// newNetworkStatus returns the network status holding the configuration of this network
+func (bsnc *BaseSecondaryNetworkController) newNetworkStatus() *util.NetworkStatus {
+	status := &util.NetworkStatus{
+		Network:  bsnc.GetNetworkName(),
+		Topology: bsnc.TopologyType(),
+	}
+	for _, subnet := range bsnc.Subnets() {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			status.Subnets = append(status.Subnets, subnet)
+		}
+	}
+	return status
+}
+
+// getManagedNodes returns the nodes managed by OVN, sorted by name
+func (bsnc *BaseSecondaryNetworkController) getManagedNodes() ([]*kapi.Node, error) {
+	nodes, err := bsnc.watchFactory.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	managedNodes := make([]*kapi.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if util.NoHostSubnet(node) {
+			continue
+		}
+		managedNodes = append(managedNodes, node)
+	}
+	sort.Slice(managedNodes, func(i, j int) bool { return managedNodes[i].Name < managedNodes[j].Name })
+	return managedNodes, nil
+}
+
+// getNetworkStatus returns the status of the layer3 network: the node subnets allocated by
+// cluster manager, the IP usage of every node switch and whether each node switch is
+// programmed and connected to the cluster router.
+func (oc *SecondaryLayer3NetworkController) getNetworkStatus() (*util.NetworkStatus, error) {
+	nodes, err := oc.getManagedNodes()
+	if err != nil {
+		return nil, err
+	}
+	status := oc.newNetworkStatus()
+	for _, node := range nodes {
+		nodeStatus := util.NodeNetworkStatus{Name: node.Name}
+		hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, oc.GetNetworkName())
+		if err != nil || len(hostSubnets) == 0 {
+			nodeStatus.Message = "waiting for the node subnet allocation"
+			status.Nodes = append(status.Nodes, nodeStatus)
+			continue
+		}
+		for _, hostSubnet := range hostSubnets {
+			nodeStatus.Subnets = append(nodeStatus.Subnets, hostSubnet.String())
+		}
+
+		switchName := oc.GetNetworkScopedName(node.Name)
+		_, addNodeFailed := oc.addNodeFailed.Load(node.Name)
+		_, routerPortFailed := oc.nodeClusterRouterPortFailed.Load(node.Name)
+		_, switchFound := oc.lsManager.GetUUID(switchName)
+		switch {
+		case addNodeFailed:
+			nodeStatus.Message = "failed to create the node logical switch"
+		case routerPortFailed:
+			nodeStatus.Message = "failed to connect the node logical switch to the cluster router"
+		case !switchFound:
+			nodeStatus.Message = "waiting for the node logical switch"
+		default:
+			nodeStatus.Ready = true
+		}
+		status.Nodes = append(status.Nodes, nodeStatus)
+
+		for _, usage := range oc.lsManager.GetSwitchIPUsage(switchName) {
+			usage.Node = node.Name
+			status.IPUsage = append(status.IPUsage, usage)
+		}
+	}
+	return status, nil
+}
+
+// getNetworkStatus returns the status of the layer2 or localnet network: the IP usage of
+// the network switch, shared by all nodes, and whether it is programmed.
+func (oc *BaseSecondaryLayer2NetworkController) getNetworkStatus() (*util.NetworkStatus, error) {
+	nodes, err := oc.getManagedNodes()
+	if err != nil {
+		return nil, err
+	}
+	switchName := oc.GetNetworkScopedName(types.OVNLayer2Switch)
+	if oc.TopologyType() == types.LocalnetTopology {
+		switchName = oc.GetNetworkScopedName(types.OVNLocalnetSwitch)
+	}
+	_, switchFound := oc.lsManager.GetUUID(switchName)
+
+	status := oc.newNetworkStatus()
+	status.IPUsage = oc.lsManager.GetSwitchIPUsage(switchName)
+	for _, node := range nodes {
+		nodeStatus := util.NodeNetworkStatus{Name: node.Name, Ready: switchFound}
+		if !switchFound {
+			nodeStatus.Message = "waiting for the network logical switch"
+		}
+		status.Nodes = append(status.Nodes, nodeStatus)
+	}
+	return status, nil
+}
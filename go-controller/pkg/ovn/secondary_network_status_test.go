@@ -0,0 +1,111 @@
+package ovn
+
+import (
+	"context"
+	"errors"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgo.Describe("Secondary network status", func() {
+	const (
+		nadNamespace = "ns1"
+		nadName      = "nad1"
+		nadKey       = nadNamespace + "/" + nadName
+	)
+
+	var (
+		fakeNADClient *nadfake.Clientset
+		bsnc          *BaseSecondaryNetworkController
+		status        *util.NetworkStatus
+	)
+
+	getStatus := func() (*util.NetworkStatus, error) {
+		return status, nil
+	}
+
+	getReportedStatus := func() *util.NetworkStatus {
+		nad, err := fakeNADClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nadNamespace).Get(
+			context.TODO(), nadName, metav1.GetOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		reported, err := util.ParseNetworkStatusAnnotation(nad)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return reported
+	}
+
+	ginkgo.BeforeEach(func() {
+		fakeNADClient = nadfake.NewSimpleClientset()
+		_, err := fakeNADClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nadNamespace).Create(context.TODO(),
+			&nettypes.NetworkAttachmentDefinition{ObjectMeta: metav1.ObjectMeta{Name: nadName, Namespace: nadNamespace}},
+			metav1.CreateOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		bsnc = &BaseSecondaryNetworkController{
+			BaseNetworkController: BaseNetworkController{
+				CommonNetworkControllerInfo: CommonNetworkControllerInfo{
+					kube: &kube.KubeOVN{NADClient: fakeNADClient},
+				},
+				NetInfo: util.NewNetInfo(&ovncnitypes.NetConf{NetConf: cnitypes.NetConf{Name: "network1"}}),
+			},
+			networkStatuses: map[string]string{},
+		}
+		status = &util.NetworkStatus{Network: "network1", Topology: "layer3"}
+	})
+
+	ginkgo.It("reports the status again once the error of the NAD clears", func() {
+		bsnc.AddNAD(nadKey)
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		gomega.Expect(getReportedStatus()).To(gomega.Equal(status))
+
+		// the NAD is updated with an invalid configuration: it is removed from the network
+		// and the network controller manager reports the error on it
+		bsnc.DeleteNAD(nadKey)
+		networkError, err := util.MarshalNetworkStatus(&util.NetworkStatus{Error: errors.New("invalid").Error()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = bsnc.kube.SetAnnotationsOnNAD(nadNamespace, nadName, map[string]interface{}{
+			util.OvnNetworkStatusAnnotation: networkError,
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		gomega.Expect(getReportedStatus().Error).To(gomega.Equal("invalid"))
+
+		// the configuration is fixed: the NAD is added back to the network with the same status
+		bsnc.AddNAD(nadKey)
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		gomega.Expect(getReportedStatus()).To(gomega.Equal(status))
+	})
+
+	ginkgo.It("does not report an unchanged status again", func() {
+		bsnc.AddNAD(nadKey)
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		fakeNADClient.ClearActions()
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		gomega.Expect(fakeNADClient.Actions()).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("does not report the status already set on the NAD again after a restart", func() {
+		value, err := util.MarshalNetworkStatus(status)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = bsnc.kube.SetAnnotationsOnNAD(nadNamespace, nadName, map[string]interface{}{
+			util.OvnNetworkStatusAnnotation: value,
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		fakeNADClient.ClearActions()
+
+		bsnc.AddNAD(nadKey)
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		gomega.Expect(bsnc.reportNetworkStatus(getStatus)).To(gomega.Succeed())
+		actions := fakeNADClient.Actions()
+		gomega.Expect(actions).To(gomega.HaveLen(1))
+		gomega.Expect(actions[0].GetVerb()).To(gomega.Equal("get"))
+	})
+})
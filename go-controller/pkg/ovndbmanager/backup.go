@@ -0,0 +1,169 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// backupTimeFormat is the timestamp format in backup file names; it sorts
+// chronologically
+const backupTimeFormat = "20060102T150405Z"
+
+// runDBBackups periodically writes a standalone backup of the db to the
+// configured backup directory until stopCh is closed
+func runDBBackups(db, serverSock string, stopCh <-chan struct{}) {
+	interval := time.Duration(config.OvnDBBackup.Interval) * time.Minute
+	klog.Infof("Starting backups of db %s to %s every %v", db, config.OvnDBBackup.Dir, interval)
+	wait.Until(func() {
+		if err := backupDB(db, serverSock); err != nil {
+			klog.Errorf("Failed to back up db %s: %v", db, err)
+		}
+	}, interval, stopCh)
+}
+
+// backupDB takes a consistent snapshot of the db through its server in the
+// standalone format, so that it can be restored regardless of the RAFT state,
+// and removes the backups beyond the retention
+func backupDB(db, serverSock string) error {
+	dbProperties, err := util.GetOvsDbProperties(db)
+	if err != nil {
+		return err
+	}
+	// every member of a RAFT cluster would back up the same data, leave it
+	// to the leader
+	if _, _, err := util.RunOVSDBTool("db-is-standalone", db); err != nil {
+		isLeader, err := isRaftLeader(dbProperties)
+		if err != nil {
+			return err
+		}
+		if !isLeader {
+			klog.V(5).Infof("Skipping backup of db %s: not the RAFT leader", db)
+			return nil
+		}
+	}
+
+	snapshot, stderr, err := util.RunOVSDBClient("backup", serverSock, dbProperties.DbName)
+	if err != nil {
+		return fmt.Errorf("failed to get a snapshot of %s, stderr: %q, error: %w", dbProperties.DbName, stderr, err)
+	}
+	backupFile, err := writeBackup(config.OvnDBBackup.Dir, db, snapshot, time.Now())
+	if err != nil {
+		return err
+	}
+	klog.Infof("Backed up db %s to %s", db, backupFile)
+	return pruneBackups(config.OvnDBBackup.Dir, db, int(config.OvnDBBackup.Retention))
+}
+
+// isRaftLeader returns whether the local server is the leader of the db RAFT cluster
+func isRaftLeader(db *util.OvsDbProperties) (bool, error) {
+	out, stderr, err := db.AppCtl(5, "cluster/status", db.DbName)
+	if err != nil {
+		return false, fmt.Errorf("%w: unable to get cluster status for: %s, stderr: %v, err: %v", DBError, db.DbName, stderr, err)
+	}
+	return strings.Contains(out, "Role: leader"), nil
+}
+
+// backupFilePrefix returns the prefix of the backup file names of the db,
+// the base name of the db file without its extension
+func backupFilePrefix(db string) string {
+	dbFile := filepath.Base(db)
+	return strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) + "-"
+}
+
+// writeBackup writes the snapshot of the db to a timestamped file in dir. The
+// file is written under a temporary name first so that a partially written
+// backup is never mistaken for a complete one.
+func writeBackup(dir, db, snapshot string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory %s: %v", dir, err)
+	}
+	backupFile := filepath.Join(dir, backupFilePrefix(db)+now.UTC().Format(backupTimeFormat)+".db")
+	tmpFile := backupFile + ".tmp"
+	// the client output is trimmed, restore the newline that ends the record
+	if err := os.WriteFile(tmpFile, []byte(snapshot+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write backup %s: %v", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, backupFile); err != nil {
+		return "", fmt.Errorf("failed to rename backup %s to %s: %v", tmpFile, backupFile, err)
+	}
+	return backupFile, nil
+}
+
+// pruneBackups removes the oldest backups of the db in dir so that at most
+// retention of them are left
+func pruneBackups(dir, db string, retention int) error {
+	backups, err := filepath.Glob(filepath.Join(dir, backupFilePrefix(db)+"*.db"))
+	if err != nil {
+		return err
+	}
+	if len(backups) <= retention {
+		return nil
+	}
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-retention] {
+		if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old backup %s: %v", backup, err)
+		}
+		klog.Infof("Removed old backup %s", backup)
+	}
+	return nil
+}
+
+// RestoreDB replaces the content of the running db with the backup. The db
+// RAFT cluster, if any, must have quorum; the restored content is replicated
+// to all of its members.
+func RestoreDB(db, backupFile string) error {
+	dbProperties, err := util.GetOvsDbProperties(db)
+	if err != nil {
+		return err
+	}
+	serverSock := nbdbServerSock
+	if dbProperties.DbName == "OVN_Southbound" {
+		serverSock = sbdbServerSock
+	}
+	f, err := os.Open(backupFile)
+	if err != nil {
+		return fmt.Errorf("failed to open backup %s: %v", backupFile, err)
+	}
+	defer f.Close()
+
+	_, stderr, err := util.RunOVSDBClientWithStdin(f, "restore", serverSock, dbProperties.DbName)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s from %s, stderr: %q, error: %w", dbProperties.DbName, backupFile, stderr, err)
+	}
+	klog.Infof("Restored db %s from %s", db, backupFile)
+	return nil
+}
+
+// CreateClusterFromBackup creates a new single member RAFT cluster for the db
+// out of the backup, for when the cluster lost quorum. The db server must be
+// stopped; the current db file is kept next to it. The other members must
+// have their db file removed so that they join the new cluster on restart.
+func CreateClusterFromBackup(db, backupFile, localAddress string) error {
+	if _, err := os.Stat(backupFile); err != nil {
+		return fmt.Errorf("failed to find backup %s: %v", backupFile, err)
+	}
+	if _, err := os.Stat(db); err == nil {
+		oldDB := db + "." + time.Now().UTC().Format(backupTimeFormat) + ".old"
+		if err := os.Rename(db, oldDB); err != nil {
+			return fmt.Errorf("failed to move db %s out of the way: %v", db, err)
+		}
+		klog.Infof("Moved the current db %s to %s", db, oldDB)
+	}
+	_, stderr, err := util.RunOVSDBTool("create-cluster", db, backupFile, localAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster for %s from %s, stderr: %q, error: %w", db, backupFile, stderr, err)
+	}
+	klog.Infof("Created a new cluster for db %s at %s from %s", db, localAddress, backupFile)
+	return nil
+}
@@ -0,0 +1,112 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestIsRaftLeader(t *testing.T) {
+	tests := []struct {
+		desc        string
+		res         *mockRes
+		isLeader    bool
+		errorString string
+	}{
+		{
+			desc: "Test error: unable to get cluster status",
+			res: &mockRes{
+				stderr: "failure",
+				err:    fmt.Errorf("failure"),
+			},
+			errorString: "unable to get cluster status for",
+		},
+		{
+			desc: "Leader",
+			res: &mockRes{
+				res: fmt.Sprintf(status_template, "OVN_Northbound", serverAddress, "leader", "1000", servers),
+			},
+			isLeader: true,
+		},
+		{
+			desc: "Follower",
+			res: &mockRes{
+				res: fmt.Sprintf(status_template, "OVN_Northbound", serverAddress, "follower", "1000", servers),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			db := &util.OvsDbProperties{
+				DbName: "OVN_Northbound",
+				AppCtl: func(timeout int, args ...string) (string, string, error) {
+					if key := keyForArgs(args...); key != keyForArgs("cluster/status", "OVN_Northbound") {
+						t.Fatalf("Unexpected call %s", key)
+					}
+					return tc.res.res, tc.res.stderr, tc.res.err
+				},
+			}
+			isLeader, err := isRaftLeader(db)
+			if tc.errorString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errorString) {
+					t.Fatalf("Expected error containing %q, got %v", tc.errorString, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if isLeader != tc.isLeader {
+				t.Fatalf("Expected leader %v, got %v", tc.isLeader, isLeader)
+			}
+		})
+	}
+}
+
+func TestWriteAndPruneBackups(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// backups of another db must not be pruned
+	sbBackup, err := writeBackup(dir, util.OvnSbdbLocation, "OVSDB JSON", start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var nbBackups []string
+	for i := 0; i < 4; i++ {
+		backup, err := writeBackup(dir, util.OvnNbdbLocation, fmt.Sprintf("OVSDB JSON %d", i), start.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		nbBackups = append(nbBackups, backup)
+	}
+	if expected := filepath.Join(dir, "ovnnb_db-20230501T100000Z.db"); nbBackups[0] != expected {
+		t.Fatalf("Expected backup %s, got %s", expected, nbBackups[0])
+	}
+	content, err := os.ReadFile(nbBackups[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(content) != "OVSDB JSON 0\n" {
+		t.Fatalf("Unexpected backup content %q", content)
+	}
+
+	if err := pruneBackups(dir, util.OvnNbdbLocation, 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, backup := range append(nbBackups, sbBackup) {
+		_, err := os.Stat(backup)
+		shouldExist := i >= 2
+		if shouldExist && err != nil {
+			t.Fatalf("Expected backup %s to exist: %v", backup, err)
+		}
+		if !shouldExist && !os.IsNotExist(err) {
+			t.Fatalf("Expected backup %s to be pruned", backup)
+		}
+	}
+}
@@ -55,6 +55,18 @@ func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
 		}
 		ensureOvnDBState(util.OvnSbdbLocation, kclient, stopCh)
 	}()
+
+	if config.OvnDBBackup.Dir != "" {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			runDBBackups(util.OvnNbdbLocation, nbdbServerSock, stopCh)
+		}()
+		go func() {
+			defer wg.Done()
+			runDBBackups(util.OvnSbdbLocation, sbdbServerSock, stopCh)
+		}()
+	}
 	<-stopCh
 	klog.Info("Shutting down db checker")
 	wg.Wait()
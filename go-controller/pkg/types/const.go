@@ -15,6 +15,9 @@ const (
 	// access to physical/external network
 	PhysicalNetworkName     = "physnet"
 	PhysicalNetworkExGwName = "exgwphysnet"
+	// PhysicalNetworkGatewayBridgePrefix is the prefix of the physical network
+	// names of the additional named gateway bridges
+	PhysicalNetworkGatewayBridgePrefix = "gwbrphysnet-"
 
 	// LocalNetworkName is the name that maps to an OVS bridge that provides
 	// access to local service
@@ -43,6 +46,7 @@ const (
 	EXTSwitchToGWRouterPrefix    = "etor-"
 	GWRouterToExtSwitchPrefix    = "rtoe-"
 	EgressGWSwitchPrefix         = "exgw-"
+	GatewayBridgeSwitchPrefix    = "gwbr-"
 
 	NodeLocalSwitch = "node_local_switch"
 
@@ -71,6 +75,7 @@ const (
 	// priority of logical router policies on the OVNClusterRouter
 	EgressFirewallStartPriority           = 10000
 	MinimumReservedEgressFirewallPriority = 2000
+	NoOverlayPolicyPriority               = "1006"
 	MGMTPortPolicyPriority                = "1005"
 	NodeSubnetPolicyPriority              = "1004"
 	InterNodePolicyPriority               = "1003"
@@ -170,6 +175,8 @@ const (
 	NADExternalID = OvnK8sPrefix + "/" + "nad"
 	// key for topology type external-id, only used for secondary network logical entities
 	TopologyExternalID = OvnK8sPrefix + "/" + "topology"
+	// key for the creation time external-id of the logical switch port of a pod, copied to its port binding
+	LSPCreatedExternalID = OvnK8sPrefix + "/" + "lsp-created"
 	// key for topology version external-id
 	TopologyVersionExternalID = "k8s-ovn-topo-version"
 	// key for load_balancer kind external-id
@@ -177,6 +184,20 @@ const (
 	// key for load_balancer service external-id
 	LoadBalancerOwnerExternalID = OvnK8sPrefix + "/" + "owner"
 
+	// EndpointSliceMirrorControllerName is the value of the managed-by label set on the
+	// EndpointSlices mirrored with the secondary network IPs of the endpoint pods
+	EndpointSliceMirrorControllerName = "endpointslice-mirror-controller.k8s.ovn.org"
+	// LabelMirroredServiceName is the label set on mirrored EndpointSlices holding the name
+	// of their Service. Mirrored EndpointSlices don't have the kubernetes.io/service-name
+	// label so that kube-proxy and DNS never use the secondary network IPs.
+	LabelMirroredServiceName = OvnK8sPrefix + "/" + "service-name"
+	// SourceEndpointSliceAnnotation is the annotation set on mirrored EndpointSlices holding
+	// the name of the default network EndpointSlice they were mirrored from
+	SourceEndpointSliceAnnotation = OvnK8sPrefix + "/" + "source-endpointslice"
+	// EndpointSliceNetworkAnnotation is the annotation set on mirrored EndpointSlices
+	// holding the name of the secondary network the endpoint IPs belong to
+	EndpointSliceNetworkAnnotation = OvnK8sPrefix + "/" + "endpointslice-network"
+
 	// different secondary network topology type defined in CNI netconf
 	Layer3Topology   = "layer3"
 	Layer2Topology   = "layer2"
@@ -3,7 +3,12 @@ package util
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kapi "k8s.io/api/core/v1"
 )
 
 /*
@@ -24,6 +29,15 @@ Example:
                 	"sandboxId": "35b82dbe2c39768d9874861aee38cf569766d4855b525ae02bff2bfbda73392a"
 				}
             }
+    The Pod is connected to a Scalable Function (SF) rather than a VF when "sfId" is set in place of "vfId":
+        k8s.ovn.org/dpu.connection-details: |
+            {"default":
+				{
+                	"pfId": "0",
+                	"sfId": "5",
+                	"sandboxId": "35b82dbe2c39768d9874861aee38cf569766d4855b525ae02bff2bfbda73392a"
+				}
+            }
 
 Annotation: "k8s.ovn.org/dpu.connection-status"
 Applied on: Pods
@@ -34,9 +48,16 @@ Example:
             {"default":
 				{
 					"status": “Ready”,
-					"reason": ""
+					"reason": "",
+					"timestamp": "2023-03-02T10:04:05Z"
 				}
 			}
+
+Node condition: "DPUReady"
+Applied on: Nodes
+Used for: convey the health of ovnkube-node running on the DPU of a node to the host. It is heartbeated by ovnkube-node
+on the DPU, its message gives the number of pod representors plugged into OVS and it is False when OVS hardware
+offload is disabled or OVS cannot be reached on the DPU.
 */
 
 const (
@@ -45,18 +66,50 @@ const (
 
 	DPUConnectionStatusReady = "Ready"
 	DPUConnectionStatusError = "Error"
+
+	// DPUReadyCondition is the node condition reporting the health of ovnkube-node running on the DPU of the node
+	DPUReadyCondition kapi.NodeConditionType = "DPUReady"
+
+	DPUReadyReasonReady             = "DPUReady"
+	DPUReadyReasonHwOffloadDisabled = "HwOffloadDisabled"
+	DPUReadyReasonOVSUnreachable    = "OVSUnreachable"
+	DPUReadyReasonHeartbeatMissed   = "HeartbeatMissed"
+	DPUReadyReasonStatusNeverPosted = "StatusNeverPosted"
+	DPUStatusHeartbeatInterval      = 30 * time.Second
+	// DPUStatusLeaseDuration is the time after which ovnkube-node on the DPU of a node is considered unreachable
+	// when it did not renew the DPU status lease of the node
+	DPUStatusLeaseDuration = 3 * DPUStatusHeartbeatInterval
 )
 
 type DPUConnectionDetails struct {
-	PfId         string `json:"pfId"`
-	VfId         string `json:"vfId"`
+	PfId string `json:"pfId"`
+	// VfId is the index of the VF of the Pod, unless it is connected to a SF
+	VfId string `json:"vfId,omitempty"`
+	// SfId is the SF number of the SF of the Pod, when it is connected to a SF
+	SfId         string `json:"sfId,omitempty"`
 	SandboxId    string `json:"sandboxId"`
 	VfNetdevName string `json:"vfNetdevName,omitempty"`
 }
 
+// IsSF returns true if the connection details describe a Scalable Function rather than a Virtual Function
+func (dcd *DPUConnectionDetails) IsSF() bool {
+	return dcd.SfId != ""
+}
+
 type DPUConnectionStatus struct {
 	Status string `json:"Status"`
 	Reason string `json:"Reason,omitempty"`
+	// Timestamp is the time the status was set at, in RFC 3339 format
+	Timestamp string `json:"Timestamp,omitempty"`
+}
+
+// NewDPUConnectionStatus returns a DPU connection status set now
+func NewDPUConnectionStatus(status, reason string) *DPUConnectionStatus {
+	return &DPUConnectionStatus{
+		Status:    status,
+		Reason:    reason,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
 }
 
 // UnmarshalPodDPUConnDetailsAllNetworks returns the DPUConnectionDetails map of all networks from the given Pod annotation
@@ -173,3 +226,35 @@ func UnmarshalPodDPUConnStatus(annotations map[string]string, netName string) (*
 	}
 	return &scs, nil
 }
+
+// DPUStatusLeaseName returns the name of the lease ovnkube-node on the DPU of the node renews every
+// DPUStatusHeartbeatInterval, in the OVN config namespace
+func DPUStatusLeaseName(nodeName string) string {
+	return "ovnkube-dpu-" + nodeName
+}
+
+// CheckNodeDPUReady returns an error describing why the DPU of the node is not ready, when its ovnkube-node never
+// posted the DPU status of the node, stopped renewing its DPU status lease or reported the DPU as not ready, or nil
+// otherwise. The lease is nil if it does not exist.
+func CheckNodeDPUReady(node *kapi.Node, lease *coordinationv1.Lease, now time.Time) error {
+	neverPosted := fmt.Errorf("%s: ovnkube-node on the DPU of node %s never posted its status",
+		DPUReadyReasonStatusNeverPosted, node.Name)
+	if lease == nil || lease.Spec.RenewTime == nil {
+		return neverPosted
+	}
+	if now.Sub(lease.Spec.RenewTime.Time) > DPUStatusLeaseDuration {
+		return fmt.Errorf("%s: ovnkube-node on the DPU of node %s is unreachable, its last heartbeat was at %s",
+			DPUReadyReasonHeartbeatMissed, node.Name, lease.Spec.RenewTime.UTC().Format(time.RFC3339))
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != DPUReadyCondition {
+			continue
+		}
+		if condition.Status != kapi.ConditionTrue {
+			return fmt.Errorf("%s: the DPU of node %s is not ready since %s: %s", condition.Reason, node.Name,
+				condition.LastTransitionTime.UTC().Format(time.RFC3339), condition.Message)
+		}
+		return nil
+	}
+	return neverPosted
+}
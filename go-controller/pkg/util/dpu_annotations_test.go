@@ -1,8 +1,14 @@
 package util
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("DPU Annotations test", func() {
@@ -149,4 +155,63 @@ var _ = Describe("DPU Annotations test", func() {
 			})
 		})
 	})
+
+	Describe("DPUReady node condition", func() {
+		var node *kapi.Node
+		var lease *coordinationv1.Lease
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			node = &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			renewTime := metav1.NewMicroTime(now.Add(-DPUStatusHeartbeatInterval))
+			lease = &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: DPUStatusLeaseName(node.Name)},
+				Spec:       coordinationv1.LeaseSpec{RenewTime: &renewTime},
+			}
+		})
+
+		It("Reports a DPU that never posted its status", func() {
+			err := CheckNodeDPUReady(node, nil, now)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring(DPUReadyReasonStatusNeverPosted))
+
+			err = CheckNodeDPUReady(node, lease, now)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring(DPUReadyReasonStatusNeverPosted))
+		})
+
+		It("Reports a DPU that stopped renewing its lease", func() {
+			node.Status.Conditions = []kapi.NodeCondition{{
+				Type:   DPUReadyCondition,
+				Status: kapi.ConditionTrue,
+			}}
+			renewTime := metav1.NewMicroTime(now.Add(-4 * DPUStatusHeartbeatInterval))
+			lease.Spec.RenewTime = &renewTime
+			err := CheckNodeDPUReady(node, lease, now)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring(DPUReadyReasonHeartbeatMissed))
+		})
+
+		It("Reports a DPU that is not ready", func() {
+			node.Status.Conditions = []kapi.NodeCondition{{
+				Type:    DPUReadyCondition,
+				Status:  kapi.ConditionFalse,
+				Reason:  DPUReadyReasonHwOffloadDisabled,
+				Message: "OVS hardware offload is disabled",
+			}}
+			err := CheckNodeDPUReady(node, lease, now)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring(DPUReadyReasonHwOffloadDisabled))
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("OVS hardware offload is disabled"))
+		})
+
+		It("Does not report a ready DPU", func() {
+			node.Status.Conditions = []kapi.NodeCondition{{
+				Type:   DPUReadyCondition,
+				Status: kapi.ConditionTrue,
+			}}
+			gomega.Expect(CheckNodeDPUReady(node, lease, now)).To(gomega.Succeed())
+		})
+	})
 })
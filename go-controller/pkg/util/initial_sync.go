@@ -0,0 +1,43 @@
+package util
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// InitialSync tracks the initial sync of a queue based controller: the
+// processing of the keys of the objects it holds when its workers start.
+// The HasSynced of the informers only tells that the caches are filled, while
+// the objects are programmed later by the workers in the background; this
+// tells when the state of the objects that existed on startup is programmed.
+// The zero value is ready to use.
+type InitialSync struct {
+	lock    sync.Mutex
+	started bool
+	pending sets.Set[string]
+}
+
+// Start sets the keys the controller has to process for its initial sync. It
+// is called once the caches of the controller are synced, before its workers
+// start.
+func (s *InitialSync) Start(keys []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.started = true
+	s.pending = sets.New[string](keys...)
+}
+
+// Done records that the key was processed, successfully or for the last time
+func (s *InitialSync) Done(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending.Delete(key)
+}
+
+// HasSynced returns whether all the keys of the initial sync were processed
+func (s *InitialSync) HasSynced() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.started && s.pending.Len() == 0
+}
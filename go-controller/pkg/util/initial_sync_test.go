@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestInitialSync(t *testing.T) {
+	tests := []struct {
+		desc       string
+		start      bool
+		keys       []string
+		done       []string
+		wantSynced bool
+	}{
+		{
+			desc:       "not synced before it is started",
+			wantSynced: false,
+		},
+		{
+			desc:       "not synced before it is started, even once keys are done",
+			done:       []string{"ns/a"},
+			wantSynced: false,
+		},
+		{
+			desc:       "synced once started without keys",
+			start:      true,
+			wantSynced: true,
+		},
+		{
+			desc:       "not synced while a key is pending",
+			start:      true,
+			keys:       []string{"ns/a", "ns/b"},
+			done:       []string{"ns/a", "ns/c"},
+			wantSynced: false,
+		},
+		{
+			desc:       "synced once all the keys are done",
+			start:      true,
+			keys:       []string{"ns/a", "ns/b"},
+			done:       []string{"ns/b", "ns/a"},
+			wantSynced: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := &InitialSync{}
+			if tt.start {
+				s.Start(tt.keys)
+			}
+			for _, key := range tt.done {
+				s.Done(key)
+			}
+			if synced := s.HasSynced(); synced != tt.wantSynced {
+				t.Errorf("got synced %v, want %v", synced, tt.wantSynced)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	egressfirewallclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned"
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
+	hybridoverlaypeerclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/hybridoverlaypeer/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
@@ -41,6 +42,8 @@ type OVNClientset struct {
 	CloudNetworkClient    ocpcloudnetworkclientset.Interface
 	EgressQoSClient       egressqosclientset.Interface
 	NetworkAttchDefClient networkattchmentdefclientset.Interface
+	// HybridOverlayPeerClient is only used by nodes
+	HybridOverlayPeerClient hybridoverlaypeerclientset.Interface
 }
 
 // OVNMasterClientset
@@ -53,7 +56,9 @@ type OVNMasterClientset struct {
 }
 
 type OVNNodeClientset struct {
-	KubeClient kubernetes.Interface
+	KubeClient              kubernetes.Interface
+	EgressIPClient          egressipclientset.Interface
+	HybridOverlayPeerClient hybridoverlaypeerclientset.Interface
 }
 
 type OVNClusterManagerClientset struct {
@@ -80,13 +85,16 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 
 func (cs *OVNClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient: cs.KubeClient,
+		KubeClient:              cs.KubeClient,
+		EgressIPClient:          cs.EgressIPClient,
+		HybridOverlayPeerClient: cs.HybridOverlayPeerClient,
 	}
 }
 
 func (cs *OVNMasterClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient: cs.KubeClient,
+		KubeClient:     cs.KubeClient,
+		EgressIPClient: cs.EgressIPClient,
 	}
 }
 
@@ -194,14 +202,19 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	hybridOverlayPeerClientset, err := hybridoverlaypeerclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
 
 	return &OVNClientset{
-		KubeClient:            kclientset,
-		EgressIPClient:        egressIPClientset,
-		EgressFirewallClient:  egressFirewallClientset,
-		CloudNetworkClient:    cloudNetworkClientset,
-		EgressQoSClient:       egressqosClientset,
-		NetworkAttchDefClient: networkAttchmntDefClientset,
+		KubeClient:              kclientset,
+		EgressIPClient:          egressIPClientset,
+		EgressFirewallClient:    egressFirewallClientset,
+		CloudNetworkClient:      cloudNetworkClientset,
+		EgressQoSClient:         egressqosClientset,
+		NetworkAttchDefClient:   networkAttchmntDefClientset,
+		HybridOverlayPeerClient: hybridOverlayPeerClientset,
 	}, nil
 }
 
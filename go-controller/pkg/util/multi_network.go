@@ -30,6 +30,7 @@ type NetInfo interface {
 	AddNAD(nadName string)
 	DeleteNAD(nadName string)
 	HasNAD(nadName string) bool
+	GetNADs() []string
 }
 
 type DefaultNetInfo struct{}
@@ -65,6 +66,11 @@ func (nInfo *DefaultNetInfo) HasNAD(nadName string) bool {
 	panic("unexpected call for default network")
 }
 
+// GetNADs returns all the NADs of the network, no op for default network
+func (nInfo *DefaultNetInfo) GetNADs() []string {
+	panic("unexpected call for default network")
+}
+
 // SecondaryNetInfo holds the network name information for secondary network if non-nil
 type SecondaryNetInfo struct {
 	// network name
@@ -106,6 +112,17 @@ func (nInfo *SecondaryNetInfo) HasNAD(nadName string) bool {
 	return ok
 }
 
+// GetNADs returns the sorted names of all the NADs of the network
+func (nInfo *SecondaryNetInfo) GetNADs() []string {
+	nadNames := []string{}
+	nInfo.nadNames.Range(func(key, value interface{}) bool {
+		nadNames = append(nadNames, key.(string))
+		return true
+	})
+	sort.Strings(nadNames)
+	return nadNames
+}
+
 // NetConfInfo is structure which holds specific per-network configuration
 type NetConfInfo interface {
 	CompareNetConf(NetConfInfo) bool
@@ -556,6 +573,9 @@ func NewNetInfo(netconf *ovncnitypes.NetConf) NetInfo {
 func ParseNetConf(netattachdef *nettypes.NetworkAttachmentDefinition) (*ovncnitypes.NetConf, error) {
 	netconf, err := config.ParseNetConf([]byte(netattachdef.Spec.Config))
 	if err != nil {
+		if errors.Is(err, config.ErrorAttachDefNotOvnManaged) {
+			return nil, ErrorAttachDefNotOvnManaged
+		}
 		return nil, fmt.Errorf("error parsing Network Attachment Definition %s/%s: %v", netattachdef.Namespace, netattachdef.Name, err)
 	}
 	// skip non-OVN NAD
@@ -45,6 +45,7 @@ type NetLinkOps interface {
 	NeighDel(neigh *netlink.Neigh) error
 	NeighList(linkIndex, family int) ([]netlink.Neigh, error)
 	ConntrackDeleteFilter(table netlink.ConntrackTableType, family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error)
+	ConntrackTableList(table netlink.ConntrackTableType, family netlink.InetFamily) ([]*netlink.ConntrackFlow, error)
 }
 
 type defaultNetLinkOps struct {
@@ -163,6 +164,10 @@ func (defaultNetLinkOps) ConntrackDeleteFilter(table netlink.ConntrackTableType,
 	return netlink.ConntrackDeleteFilter(table, family, filter)
 }
 
+func (defaultNetLinkOps) ConntrackTableList(table netlink.ConntrackTableType, family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+	return netlink.ConntrackTableList(table, family)
+}
+
 func getFamily(ip net.IP) int {
 	if utilnet.IsIPv6(ip) {
 		return netlink.FAMILY_V6
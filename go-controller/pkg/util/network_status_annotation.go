@@ -0,0 +1,98 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+)
+
+// This handles the annotation reporting the status of a secondary network on its
+// net-attach-defs. The annotation is written by the network controller manager on every
+// net-attach-def of the network, and is read-only for users. It looks like:
+//
+//   annotations:
+//     k8s.ovn.org/network-status: |
+//       {
+//         "network": "l3-network",
+//         "topology": "layer3",
+//         "subnets": ["10.128.0.0/16/24"],
+//         "ipUsage": [
+//           {"subnet": "10.128.1.0/24", "node": "node1", "allocated": 5, "available": 249}
+//         ],
+//         "nodes": [
+//           {"name": "node1", "subnets": ["10.128.1.0/24"], "ready": true},
+//           {"name": "node2", "ready": false, "message": "waiting for the node subnet allocation"}
+//         ]
+//       }
+//
+// If the net-attach-def could not be processed, only the error is reported:
+//
+//   annotations:
+//     k8s.ovn.org/network-status: |
+//       {
+//         "error": "error parsing configuration: ..."
+//       }
+
+const (
+	// OvnNetworkStatusAnnotation is the net-attach-def annotation holding the status of its network
+	OvnNetworkStatusAnnotation = "k8s.ovn.org/network-status"
+)
+
+// NetworkStatus is the status of a secondary network
+type NetworkStatus struct {
+	// Network is the name of the network
+	Network string `json:"network,omitempty"`
+	// Topology is the topology of the network
+	Topology string `json:"topology,omitempty"`
+	// Subnets are the subnets of the network, as configured
+	Subnets []string `json:"subnets,omitempty"`
+	// Error is set if the net-attach-def could not be processed
+	Error string `json:"error,omitempty"`
+	// IPUsage is the IP address usage of every logical switch subnet of the network
+	IPUsage []SubnetIPUsage `json:"ipUsage,omitempty"`
+	// Nodes is the status of the network on every node
+	Nodes []NodeNetworkStatus `json:"nodes,omitempty"`
+}
+
+// SubnetIPUsage is the IP address usage of a logical switch subnet
+type SubnetIPUsage struct {
+	Subnet string `json:"subnet"`
+	// Node is set for the per-node subnets of layer3 networks
+	Node      string `json:"node,omitempty"`
+	Allocated int    `json:"allocated"`
+	Available int    `json:"available"`
+}
+
+// NodeNetworkStatus is the status of a network on a node
+type NodeNetworkStatus struct {
+	Name string `json:"name"`
+	// Subnets are the node subnets allocated to the node, for layer3 networks
+	Subnets []string `json:"subnets,omitempty"`
+	// Ready is true once the network is programmed for the node
+	Ready bool `json:"ready"`
+	// Message explains why the network is not ready on the node
+	Message string `json:"message,omitempty"`
+}
+
+// MarshalNetworkStatus returns the network status annotation value
+func MarshalNetworkStatus(status *NetworkStatus) (string, error) {
+	bytes, err := json.Marshal(status)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal network status %+v: %v", status, err)
+	}
+	return string(bytes), nil
+}
+
+// ParseNetworkStatusAnnotation returns the network status reported on the given net-attach-def
+func ParseNetworkStatusAnnotation(nad *nettypes.NetworkAttachmentDefinition) (*NetworkStatus, error) {
+	annotation, ok := nad.Annotations[OvnNetworkStatusAnnotation]
+	if !ok {
+		return nil, newAnnotationNotSetError("could not find %q annotation", OvnNetworkStatusAnnotation)
+	}
+	status := &NetworkStatus{}
+	if err := json.Unmarshal([]byte(annotation), status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %q annotation %q: %v", OvnNetworkStatusAnnotation, annotation, err)
+	}
+	return status, nil
+}
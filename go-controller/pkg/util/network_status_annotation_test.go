@@ -0,0 +1,45 @@
+package util
+
+import (
+	"testing"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetworkStatusAnnotation(t *testing.T) {
+	nad := &nettypes.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "l3-network",
+			Namespace:   "ns1",
+			Annotations: map[string]string{},
+		},
+	}
+	_, err := ParseNetworkStatusAnnotation(nad)
+	assert.True(t, IsAnnotationNotSetError(err))
+
+	status := &NetworkStatus{
+		Network:  "l3-network",
+		Topology: "layer3",
+		Subnets:  []string{"10.128.0.0/16/24"},
+		IPUsage: []SubnetIPUsage{
+			{Subnet: "10.128.1.0/24", Node: "node1", Allocated: 5, Available: 249},
+		},
+		Nodes: []NodeNetworkStatus{
+			{Name: "node1", Subnets: []string{"10.128.1.0/24"}, Ready: true},
+			{Name: "node2", Message: "waiting for the node subnet allocation"},
+		},
+	}
+	value, err := MarshalNetworkStatus(status)
+	assert.NoError(t, err)
+	nad.Annotations[OvnNetworkStatusAnnotation] = value
+
+	parsed, err := ParseNetworkStatusAnnotation(nad)
+	assert.NoError(t, err)
+	assert.Equal(t, status, parsed)
+
+	nad.Annotations[OvnNetworkStatusAnnotation] = "{"
+	_, err = ParseNetworkStatusAnnotation(nad)
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 // This handles the annotations used by the node to pass information about its local
@@ -27,7 +28,15 @@ import (
 //           "ip-addresses": ["169.254.33.2/24"],
 //           "next-hops": ["169.254.33.1"],
 //           "node-port-enable": "true",
-//           "vlan-id": "0"
+//           "vlan-id": "0",
+//           "bridges": {
+//             "storage": {
+//               "interface-id": "breth1_ip-10-0-129-64.us-east-2.compute.internal",
+//               "mac-address": "f2:20:a0:3c:26:4d",
+//               "ip-addresses": ["192.168.10.5/24"],
+//               "routes": [{"destination": "10.50.0.0/16", "next-hop": "192.168.10.1"}]
+//             }
+//           }
 //
 //           # backward-compat
 //           "ip-address": "169.254.33.2/24",
@@ -87,22 +96,48 @@ type L3GatewayConfig struct {
 	NextHops            []net.IP
 	NodePortEnable      bool
 	VLANID              *uint
+	// Bridges are the additional named gateway bridges of the node
+	Bridges map[string]*L3GatewayBridgeConfig
+}
+
+// L3GatewayBridgeConfig is the configuration of an additional named gateway
+// bridge of a node
+type L3GatewayBridgeConfig struct {
+	InterfaceID string
+	MACAddress  net.HardwareAddr
+	IPAddresses []*net.IPNet
+	VLANID      *uint
+	Routes      []config.GatewayBridgeRoute
+}
+
+type l3GatewayBridgeConfigJSON struct {
+	InterfaceID string                     `json:"interface-id"`
+	MACAddress  string                     `json:"mac-address"`
+	IPAddresses []string                   `json:"ip-addresses"`
+	VLANID      string                     `json:"vlan-id,omitempty"`
+	Routes      []l3GatewayBridgeRouteJSON `json:"routes,omitempty"`
+}
+
+type l3GatewayBridgeRouteJSON struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"next-hop"`
 }
 
 type l3GatewayConfigJSON struct {
-	Mode                config.GatewayMode `json:"mode"`
-	InterfaceID         string             `json:"interface-id,omitempty"`
-	MACAddress          string             `json:"mac-address,omitempty"`
-	IPAddresses         []string           `json:"ip-addresses,omitempty"`
-	IPAddress           string             `json:"ip-address,omitempty"`
-	EgressGWInterfaceID string             `json:"exgw-interface-id,omitempty"`
-	EgressGWMACAddress  string             `json:"exgw-mac-address,omitempty"`
-	EgressGWIPAddresses []string           `json:"exgw-ip-addresses,omitempty"`
-	EgressGWIPAddress   string             `json:"exgw-ip-address,omitempty"`
-	NextHops            []string           `json:"next-hops,omitempty"`
-	NextHop             string             `json:"next-hop,omitempty"`
-	NodePortEnable      string             `json:"node-port-enable,omitempty"`
-	VLANID              string             `json:"vlan-id,omitempty"`
+	Mode                config.GatewayMode                    `json:"mode"`
+	InterfaceID         string                                `json:"interface-id,omitempty"`
+	MACAddress          string                                `json:"mac-address,omitempty"`
+	IPAddresses         []string                              `json:"ip-addresses,omitempty"`
+	IPAddress           string                                `json:"ip-address,omitempty"`
+	EgressGWInterfaceID string                                `json:"exgw-interface-id,omitempty"`
+	EgressGWMACAddress  string                                `json:"exgw-mac-address,omitempty"`
+	EgressGWIPAddresses []string                              `json:"exgw-ip-addresses,omitempty"`
+	EgressGWIPAddress   string                                `json:"exgw-ip-address,omitempty"`
+	NextHops            []string                              `json:"next-hops,omitempty"`
+	NextHop             string                                `json:"next-hop,omitempty"`
+	NodePortEnable      string                                `json:"node-port-enable,omitempty"`
+	VLANID              string                                `json:"vlan-id,omitempty"`
+	Bridges             map[string]*l3GatewayBridgeConfigJSON `json:"bridges,omitempty"`
 }
 
 func (cfg *L3GatewayConfig) MarshalJSON() ([]byte, error) {
@@ -143,10 +178,76 @@ func (cfg *L3GatewayConfig) MarshalJSON() ([]byte, error) {
 	if len(cfgjson.NextHops) == 1 {
 		cfgjson.NextHop = cfgjson.NextHops[0]
 	}
+	if len(cfg.Bridges) > 0 {
+		cfgjson.Bridges = make(map[string]*l3GatewayBridgeConfigJSON, len(cfg.Bridges))
+		for name, bridge := range cfg.Bridges {
+			cfgjson.Bridges[name] = bridge.toJSON()
+		}
+	}
 
 	return json.Marshal(&cfgjson)
 }
 
+func (cfg *L3GatewayBridgeConfig) toJSON() *l3GatewayBridgeConfigJSON {
+	cfgjson := &l3GatewayBridgeConfigJSON{
+		InterfaceID: cfg.InterfaceID,
+		MACAddress:  cfg.MACAddress.String(),
+		IPAddresses: make([]string, len(cfg.IPAddresses)),
+	}
+	for i, ip := range cfg.IPAddresses {
+		cfgjson.IPAddresses[i] = ip.String()
+	}
+	if cfg.VLANID != nil {
+		cfgjson.VLANID = fmt.Sprintf("%d", *cfg.VLANID)
+	}
+	for _, route := range cfg.Routes {
+		cfgjson.Routes = append(cfgjson.Routes, l3GatewayBridgeRouteJSON{
+			Destination: route.Destination.String(),
+			NextHop:     route.NextHop.String(),
+		})
+	}
+	return cfgjson
+}
+
+func (cfgjson *l3GatewayBridgeConfigJSON) toConfig() (*L3GatewayBridgeConfig, error) {
+	cfg := &L3GatewayBridgeConfig{
+		InterfaceID: cfgjson.InterfaceID,
+		IPAddresses: make([]*net.IPNet, len(cfgjson.IPAddresses)),
+	}
+	var err error
+	cfg.MACAddress, err = net.ParseMAC(cfgjson.MACAddress)
+	if err != nil {
+		return nil, fmt.Errorf("bad 'mac-address' value %q: %v", cfgjson.MACAddress, err)
+	}
+	for i, ipStr := range cfgjson.IPAddresses {
+		ip, ipnet, err := net.ParseCIDR(ipStr)
+		if err != nil {
+			return nil, fmt.Errorf("bad 'ip-addresses' value %q: %v", ipStr, err)
+		}
+		cfg.IPAddresses[i] = &net.IPNet{IP: ip, Mask: ipnet.Mask}
+	}
+	if cfgjson.VLANID != "" {
+		vlanID, err := strconv.ParseUint(cfgjson.VLANID, 10, 0)
+		if err != nil || vlanID > 4095 {
+			return nil, fmt.Errorf("bad 'vlan-id' value %q", cfgjson.VLANID)
+		}
+		vlanIDUint := uint(vlanID)
+		cfg.VLANID = &vlanIDUint
+	}
+	for _, routejson := range cfgjson.Routes {
+		_, destination, err := net.ParseCIDR(routejson.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("bad 'destination' value %q: %v", routejson.Destination, err)
+		}
+		nextHop := net.ParseIP(routejson.NextHop)
+		if nextHop == nil {
+			return nil, fmt.Errorf("bad 'next-hop' value %q", routejson.NextHop)
+		}
+		cfg.Routes = append(cfg.Routes, config.GatewayBridgeRoute{Destination: destination, NextHop: nextHop})
+	}
+	return cfg, nil
+}
+
 func (cfg *L3GatewayConfig) UnmarshalJSON(bytes []byte) error {
 	cfgjson := l3GatewayConfigJSON{}
 	if err := json.Unmarshal(bytes, &cfgjson); err != nil {
@@ -236,6 +337,17 @@ func (cfg *L3GatewayConfig) UnmarshalJSON(bytes []byte) error {
 		}
 	}
 
+	if len(cfgjson.Bridges) > 0 {
+		cfg.Bridges = make(map[string]*L3GatewayBridgeConfig, len(cfgjson.Bridges))
+		for name, bridgejson := range cfgjson.Bridges {
+			bridge, err := bridgejson.toConfig()
+			if err != nil {
+				return fmt.Errorf("bad gateway bridge %q: %v", name, err)
+			}
+			cfg.Bridges[name] = bridge
+		}
+	}
+
 	return nil
 }
 
@@ -252,6 +364,18 @@ func SetL3GatewayConfig(nodeAnnotator kube.Annotator, cfg *L3GatewayConfig) erro
 	return nil
 }
 
+// GetGatewayBridgeSwitchPrefix returns the prefix of the names of the external
+// switch of the named gateway bridge, and of its ports
+func GetGatewayBridgeSwitchPrefix(bridgeName string) string {
+	return types.GatewayBridgeSwitchPrefix + bridgeName + "-"
+}
+
+// GetGatewayBridgePhysicalNetworkName returns the name of the physical network
+// mapped to the named gateway bridge
+func GetGatewayBridgePhysicalNetworkName(bridgeName string) string {
+	return types.PhysicalNetworkGatewayBridgePrefix + bridgeName
+}
+
 // SetGatewayMTUSupport sets annotation "k8s.ovn.org/gateway-mtu-support" to "false" or removes the annotation from
 // this node.
 func SetGatewayMTUSupport(nodeAnnotator kube.Annotator, set bool) error {
@@ -72,6 +72,24 @@ func TestL3GatewayConfig_MarshalJSON(t *testing.T) {
 			},
 			expOutput: []byte(`{"mode":"local","interface-id":"INTERFACE-ID","mac-address":"11:22:33:44:55:66","ip-addresses":["192.168.1.10/24","fd01::1234/64"],"next-hops":["192.168.1.1","fd01::1"],"node-port-enable":"false","vlan-id":"1024"}`),
 		},
+		{
+			desc: "test gateway bridges",
+			inpL3GwCfg: &L3GatewayConfig{
+				Mode: config.GatewayModeShared,
+				Bridges: map[string]*L3GatewayBridgeConfig{
+					"storage": {
+						InterfaceID: "breth1_node1",
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: ovntest.MustParseIPNets("192.168.10.5/24"),
+						VLANID:      &vlanid,
+						Routes: []config.GatewayBridgeRoute{
+							{Destination: ovntest.MustParseIPNet("10.50.0.0/16"), NextHop: ovntest.MustParseIP("192.168.10.1")},
+						},
+					},
+				},
+			},
+			expOutput: []byte(`{"mode":"shared","node-port-enable":"false","bridges":{"storage":{"interface-id":"breth1_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.10.5/24"],"vlan-id":"1024","routes":[{"destination":"10.50.0.0/16","next-hop":"192.168.10.1"}]}}}`),
+		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
@@ -183,6 +201,32 @@ func TestL3GatewayConfig_UnmarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:       "test valid gateway bridges",
+			inputParam: []byte(`{"mode":"shared","mac-address":"11:22:33:44:55:66","ip-address":"192.168.1.5/24","bridges":{"storage":{"interface-id":"breth1_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.10.5/24"],"vlan-id":"100","routes":[{"destination":"10.50.0.0/16","next-hop":"192.168.10.1"}]}}}`),
+			expOut: L3GatewayConfig{
+				Mode:        "shared",
+				MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses: ovntest.MustParseIPNets("192.168.1.5/24"),
+				NextHops:    []net.IP{},
+				Bridges: map[string]*L3GatewayBridgeConfig{
+					"storage": {
+						InterfaceID: "breth1_node1",
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: ovntest.MustParseIPNets("192.168.10.5/24"),
+						VLANID:      &[]uint{100}[0],
+						Routes: []config.GatewayBridgeRoute{
+							{Destination: ovntest.MustParseIPNet("10.50.0.0/16"), NextHop: ovntest.MustParseIP("192.168.10.1")},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:       "test bad gateway bridge route",
+			inputParam: []byte(`{"mode":"shared","mac-address":"11:22:33:44:55:66","ip-address":"192.168.1.5/24","bridges":{"storage":{"interface-id":"breth1_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.10.5/24"],"routes":[{"destination":"10.50.0.0/16","next-hop":"192.168.10."}]}}}`),
+			errMatch:   fmt.Errorf("bad gateway bridge \"storage\": bad 'next-hop' value"),
+		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
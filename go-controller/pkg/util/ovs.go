@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os/exec"
 	"regexp"
 	"runtime"
@@ -509,6 +510,15 @@ func RunOVSDBClient(args ...string) (string, string, error) {
 	return strings.Trim(strings.TrimSpace(stdout.String()), "\""), stderr.String(), err
 }
 
+// RunOVSDBClientWithStdin runs an 'ovsdb-client [OPTIONS] COMMAND [ARG...] command'
+// that reads its input from stdin.
+func RunOVSDBClientWithStdin(stdin io.Reader, args ...string) (string, string, error) {
+	cmd := runner.exec.Command(runner.ovsdbClientPath, args...)
+	cmd.SetStdin(stdin)
+	stdout, stderr, err := runCmd(cmd, runner.ovsdbClientPath, args...)
+	return strings.Trim(strings.TrimSpace(stdout.String()), "\""), stderr.String(), err
+}
+
 // RunOVSDBTool runs an 'ovsdb-tool [OPTIONS] COMMAND [ARG...] command'.
 func RunOVSDBTool(args ...string) (string, string, error) {
 	stdout, stderr, err := run(runner.ovsdbToolPath, args...)
@@ -3,6 +3,7 @@ package util
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,6 +13,11 @@ const (
 	EgressSVCAnnotation     = "k8s.ovn.org/egress-service"
 	EgressSVCHostAnnotation = "k8s.ovn.org/egress-service-host"
 	EgressSVCLabelPrefix    = "egress-service.k8s.ovn.org"
+
+	// ServiceNetworkAnnotation selects the secondary network a service is
+	// exposed on. The value is the name of a network-attachment-definition,
+	// either as <namespace>/<name> or as <name> in the service's namespace.
+	ServiceNetworkAnnotation = "k8s.ovn.org/service-network"
 )
 
 type EgressSVCConfig struct {
@@ -67,3 +73,24 @@ func GetEgressSVCHost(svc *kapi.Service) (string, error) {
 func EgressSVCHostChanged(oldSVC, newSVC *kapi.Service) bool {
 	return oldSVC.Annotations[EgressSVCHostAnnotation] != newSVC.Annotations[EgressSVCHostAnnotation]
 }
+
+// GetServiceNetworkNADName returns the fully qualified name of the
+// network-attachment-definition selected by the service-network annotation,
+// and whether the annotation is set at all.
+func GetServiceNetworkNADName(svc *kapi.Service) (string, bool) {
+	nadName, ok := svc.Annotations[ServiceNetworkAnnotation]
+	if !ok || strings.TrimSpace(nadName) == "" {
+		return "", false
+	}
+	nadName = strings.TrimSpace(nadName)
+	if !strings.Contains(nadName, "/") {
+		nadName = GetNADName(svc.Namespace, nadName)
+	}
+	return nadName, true
+}
+
+// ServiceNetworkChanged returns true if the service-network annotation
+// differs between the two services.
+func ServiceNetworkChanged(oldSVC, newSVC *kapi.Service) bool {
+	return oldSVC.Annotations[ServiceNetworkAnnotation] != newSVC.Annotations[ServiceNetworkAnnotation]
+}
@@ -273,3 +273,47 @@ func TestEgressSVCHostChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestGetServiceNetworkNADName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations map[string]string
+		expectedNAD string
+		expectedOK  bool
+	}{
+		{
+			desc:        "no annotation",
+			annotations: nil,
+		},
+		{
+			desc:        "empty annotation",
+			annotations: map[string]string{ServiceNetworkAnnotation: " "},
+		},
+		{
+			desc:        "name only is qualified with the service namespace",
+			annotations: map[string]string{ServiceNetworkAnnotation: "blue"},
+			expectedNAD: "testns/blue",
+			expectedOK:  true,
+		},
+		{
+			desc:        "namespaced name",
+			annotations: map[string]string{ServiceNetworkAnnotation: "other/blue"},
+			expectedNAD: "other/blue",
+			expectedOK:  true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			svc := &kapi.Service{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "svc",
+					Namespace:   "testns",
+					Annotations: tc.annotations,
+				},
+			}
+			nadName, ok := GetServiceNetworkNADName(svc)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedNAD, nadName)
+		})
+	}
+}
@@ -6,13 +6,19 @@ package util
 import (
 	"fmt"
 	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/Mellanox/sriovnet"
+	utilfs "github.com/Mellanox/sriovnet/pkg/utils/filesystem"
 	"github.com/k8snetworkplumbingwg/govdpa/pkg/kvdpa"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type SriovnetOps interface {
 	GetNetDevicesFromPci(pciAddress string) ([]string, error)
+	GetNetDevicesFromAux(auxDev string) ([]string, error)
 	GetUplinkRepresentor(vfPciAddress string) (string, error)
 	GetUplinkRepresentorFromAux(auxDev string) (string, error)
 	GetVfIndexByPciAddress(vfPciAddress string) (int, error)
@@ -20,7 +26,9 @@ type SriovnetOps interface {
 	GetVfRepresentor(uplink string, vfIndex int) (string, error)
 	GetSfRepresentor(uplink string, sfIndex int) (string, error)
 	GetPfPciFromVfPci(vfPciAddress string) (string, error)
+	GetPfPciFromAux(auxDev string) (string, error)
 	GetVfRepresentorDPU(pfID, vfIndex string) (string, error)
+	GetSfRepresentorDPU(pfID, sfIndex string) (string, error)
 	GetRepresentorPeerMacAddress(netdev string) (net.HardwareAddr, error)
 	GetRepresentorPortFlavour(netdev string) (sriovnet.PortFlavour, error)
 }
@@ -44,6 +52,10 @@ func (defaultSriovnetOps) GetNetDevicesFromPci(pciAddress string) ([]string, err
 	return sriovnet.GetNetDevicesFromPci(pciAddress)
 }
 
+func (defaultSriovnetOps) GetNetDevicesFromAux(auxDev string) ([]string, error) {
+	return sriovnet.GetNetDevicesFromAux(auxDev)
+}
+
 func (defaultSriovnetOps) GetUplinkRepresentor(vfPciAddress string) (string, error) {
 	return sriovnet.GetUplinkRepresentor(vfPciAddress)
 }
@@ -72,10 +84,51 @@ func (defaultSriovnetOps) GetPfPciFromVfPci(vfPciAddress string) (string, error)
 	return sriovnet.GetPfPciFromVfPci(vfPciAddress)
 }
 
+func (defaultSriovnetOps) GetPfPciFromAux(auxDev string) (string, error) {
+	return sriovnet.GetPfPciFromAux(auxDev)
+}
+
 func (defaultSriovnetOps) GetVfRepresentorDPU(pfID, vfIndex string) (string, error) {
 	return sriovnet.GetVfRepresentorDPU(pfID, vfIndex)
 }
 
+// GetSfRepresentorDPU returns the SF representor on the DPU for a host SF identified by pfID and sfIndex. It is the
+// SF counterpart of sriovnet.GetVfRepresentorDPU, which sriovnet does not provide.
+func (defaultSriovnetOps) GetSfRepresentorDPU(pfID, sfIndex string) (string, error) {
+	// pfID should be 0 or 1
+	if pfID != "0" && pfID != "1" {
+		return "", fmt.Errorf("unexpected pfID(%s). It should be 0 or 1", pfID)
+	}
+	// sfIndex should be an unsigned integer provided as a decimal number
+	if _, err := strconv.ParseUint(sfIndex, 10, 32); err != nil {
+		return "", fmt.Errorf("unexpected sfIndex(%s). It should be an unsigned decimal number", sfIndex)
+	}
+	// the physical port name of the SF representor is pf<pfID>sf<sfIndex>, or c1pf<pfID>sf<sfIndex> on DPUs
+	expectedPhysPortNames := sets.NewString(
+		fmt.Sprintf("pf%ssf%s", pfID, sfIndex),
+		fmt.Sprintf("c1pf%ssf%s", pfID, sfIndex),
+	)
+	netdevs, err := utilfs.Fs.ReadDir(sriovnet.NetSysDir)
+	if err != nil {
+		return "", err
+	}
+	for _, netdev := range netdevs {
+		// skip netdevs that are not eswitch ports
+		switchID, err := utilfs.Fs.ReadFile(filepath.Join(sriovnet.NetSysDir, netdev.Name(), "phys_switch_id"))
+		if err != nil || len(switchID) == 0 {
+			continue
+		}
+		portName, err := utilfs.Fs.ReadFile(filepath.Join(sriovnet.NetSysDir, netdev.Name(), "phys_port_name"))
+		if err != nil {
+			continue
+		}
+		if expectedPhysPortNames.Has(strings.TrimSpace(string(portName))) {
+			return netdev.Name(), nil
+		}
+	}
+	return "", fmt.Errorf("sf representor for pfID:%s, sfIndex:%s not found", pfID, sfIndex)
+}
+
 func (defaultSriovnetOps) GetRepresentorPeerMacAddress(netdev string) (net.HardwareAddr, error) {
 	return sriovnet.GetRepresentorPeerMacAddress(netdev)
 }
@@ -134,12 +187,16 @@ func GetNetdevNameFromDeviceId(deviceId string) (string, error) {
 		}
 
 		netdevices, err = GetSriovnetOps().GetNetDevicesFromPci(deviceId)
+	} else if IsAuxDeviceName(deviceId) {
+		netdevices, err = GetSriovnetOps().GetNetDevicesFromAux(deviceId)
+	} else {
+		return "", fmt.Errorf("cannot determine device type for id '%s'", deviceId)
 	}
 	if err != nil {
 		return "", err
 	}
 
-	// Make sure we have 1 netdevice per pci address
+	// Make sure we have 1 netdevice per device ID
 	numNetDevices := len(netdevices)
 	if numNetDevices != 1 {
 		return "", fmt.Errorf("failed to get one netdevice interface (count %d) per Device ID %s", numNetDevices, deviceId)
@@ -5,8 +5,12 @@ package util
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/Mellanox/sriovnet"
+	utilfs "github.com/Mellanox/sriovnet/pkg/utils/filesystem"
+
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 )
@@ -220,3 +224,122 @@ func TestGetFunctionRepresentorName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNetdevNameFromDeviceIdAux(t *testing.T) {
+	mockSriovnetOps := mocks.NewSriovnetOps(t)
+	SetSriovnetOpsInst(mockSriovnetOps)
+
+	mockErr := fmt.Errorf("mock failed to get netdevices")
+	tests := []struct {
+		desc           string
+		deviceID       string
+		expVal         string
+		expErr         bool
+		sriovOpsHelper []ovntest.TestifyMockHelper
+	}{
+		{
+			desc:     "success",
+			deviceID: "mlx5_core.sf.2",
+			expVal:   "enp3s0f0s2",
+			sriovOpsHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "GetNetDevicesFromAux", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{[]string{"enp3s0f0s2"}, nil}},
+			},
+		},
+		{
+			desc:     "GetNetDevicesFromAux failure",
+			deviceID: "mlx5_core.sf.3",
+			expErr:   true,
+			sriovOpsHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "GetNetDevicesFromAux", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, mockErr}},
+			},
+		},
+		{
+			desc:     "no netdevice",
+			deviceID: "mlx5_core.sf.4",
+			expErr:   true,
+			sriovOpsHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "GetNetDevicesFromAux", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{[]string{}, nil}},
+			},
+		},
+		{
+			desc:     "unknown device type",
+			deviceID: "foo",
+			expErr:   true,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockSriovnetOps.Mock, tc.sriovOpsHelper)
+
+			ret, err := GetNetdevNameFromDeviceId(tc.deviceID)
+			if tc.expVal != ret {
+				t.Errorf("Expected - '%v', got - '%v' for '%s'", tc.expVal, ret, tc.deviceID)
+			}
+			if tc.expErr != (err != nil) {
+				t.Errorf("Expected error %v for '%s', got: %v", tc.expErr, tc.deviceID, err)
+			}
+
+			mockSriovnetOps.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetSfRepresentorDPU(t *testing.T) {
+	fakeFs, teardown, err := utilfs.NewFakeFs(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+	origFs := utilfs.Fs
+	utilfs.Fs = fakeFs
+	defer func() { utilfs.Fs = origFs }()
+
+	// netdevs with their physical switch ID and physical port name, an empty switch ID for netdevs that are not
+	// eswitch ports
+	netdevs := map[string][2]string{
+		"eth0":        {"", ""},
+		"p0":          {"c2ea0b6e", "p0"},
+		"pf0hpf":      {"c2ea0b6e", "pf0"},
+		"pf0vf5":      {"c2ea0b6e", "pf0vf5"},
+		"en3f0pf0sf5": {"c2ea0b6e", "c1pf0sf5"},
+		"pf1sf7":      {"c2ea0b6e", "pf1sf7"},
+	}
+	for name, attrs := range netdevs {
+		dir := filepath.Join(sriovnet.NetSysDir, name)
+		if err := fakeFs.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fakeFs.WriteFile(filepath.Join(dir, "phys_switch_id"), []byte(attrs[0]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := fakeFs.WriteFile(filepath.Join(dir, "phys_port_name"), []byte(attrs[1]+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		pfID    string
+		sfIndex string
+		expVal  string
+		expErr  bool
+	}{
+		{desc: "DPU port name", pfID: "0", sfIndex: "5", expVal: "en3f0pf0sf5"},
+		{desc: "port name", pfID: "1", sfIndex: "7", expVal: "pf1sf7"},
+		{desc: "not found", pfID: "0", sfIndex: "7", expErr: true},
+		{desc: "invalid pfID", pfID: "2", sfIndex: "5", expErr: true},
+		{desc: "invalid sfIndex", pfID: "0", sfIndex: "-5", expErr: true},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ret, err := defaultSriovnetOps{}.GetSfRepresentorDPU(tc.pfID, tc.sfIndex)
+			if tc.expVal != ret {
+				t.Errorf("Expected - '%v', got - '%v'", tc.expVal, ret)
+			}
+			if tc.expErr != (err != nil) {
+				t.Errorf("Expected error %v, got: %v", tc.expErr, err)
+			}
+		})
+	}
+}